@@ -5,6 +5,9 @@
 //	go run cmd/visualize/main.go --system xor --samples 100000 --bins 2
 //	go run cmd/visualize/main.go --system duplicated
 //	go run cmd/visualize/main.go --system independent
+//	go run cmd/visualize/main.go --system logistic --bins 8
+//	go run cmd/visualize/main.go --input data.csv --header --target 0 --bins 8
+//	go run cmd/visualize/main.go --input data.mat --var X --target 2
 package main
 
 import (
@@ -14,52 +17,71 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/causalgo/causalgo/internal/cliconfig"
 	"github.com/causalgo/causalgo/internal/validation"
+	"github.com/causalgo/causalgo/pkg/matdata"
 	"github.com/causalgo/causalgo/pkg/visualization"
 	"github.com/causalgo/causalgo/surd"
 )
 
-const (
-	defaultSamples = 100000
-	defaultBins    = 2
-	defaultDT      = 1
-	defaultSeed    = 42
-)
-
 func main() {
 	// Command line flags
-	systemType := flag.String("system", "xor", "System type: duplicated, independent, xor")
-	samples := flag.Int("samples", defaultSamples, "Number of samples")
-	bins := flag.Int("bins", defaultBins, "Number of bins per variable")
-	dt := flag.Int("dt", defaultDT, "Time delay")
-	seed := flag.Int64("seed", defaultSeed, "Random seed")
-	output := flag.String("output", "", "Output file (PNG/SVG/PDF). If empty, shows ASCII chart only")
-	format := flag.String("format", "png", "Output format: png, svg, pdf (auto-detected from --output if not specified)")
+	cfg := cliconfig.Register(flag.CommandLine)
+	systemType := flag.String("system", "xor", "System type: duplicated, independent, xor, logistic (ignored with --input)")
+	target := flag.Int("target", 0, "Target column of the input file (0-based)")
+	matVar := flag.String("var", "X", "MAT file variable holding the [variables x samples] matrix")
+	header := flag.Bool("header", false, "CSV input has a header row")
 
 	flag.Parse()
 
-	// Generate data based on system type
 	var data [][]float64
 	var systemName string
+	var err error
 
-	switch strings.ToLower(*systemType) {
-	case "duplicated", "dup", "redundant":
-		data = validation.GenerateDuplicatedInput(*samples, *dt, *seed)
-		systemName = "Duplicated Input (Redundancy)"
-	case "independent", "ind", "unique":
-		data = validation.GenerateIndependentInputs(*samples, *dt, *seed)
-		systemName = "Independent Inputs (Unique)"
-	case "xor", "synergy":
-		data = validation.GenerateXORSystem(*samples, *dt, *seed)
-		systemName = "XOR System (Synergy)"
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown system type: %s\n", *systemType)
-		fmt.Fprintf(os.Stderr, "Available: duplicated, independent, xor\n")
+	if cfg.Input != "" {
+		// Real data: target at t+dt, all columns at t
+		data, err = loadInput(cfg.Input, *matVar, *header)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load input: %v\n", err)
+			os.Exit(1)
+		}
+		data, err = matdata.PrepareWithLag(data, *target, cfg.DT)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to prepare input: %v\n", err)
+			os.Exit(1)
+		}
+		systemName = fmt.Sprintf("%s (target column %d)", filepath.Base(cfg.Input), *target)
+	} else {
+		// Generate data based on system type
+		switch strings.ToLower(*systemType) {
+		case "duplicated", "dup", "redundant":
+			data = validation.GenerateDuplicatedInput(cfg.Samples, cfg.DT, cfg.Seed)
+			systemName = "Duplicated Input (Redundancy)"
+		case "independent", "ind", "unique":
+			data = validation.GenerateIndependentInputs(cfg.Samples, cfg.DT, cfg.Seed)
+			systemName = "Independent Inputs (Unique)"
+		case "xor", "synergy":
+			data = validation.GenerateXORSystem(cfg.Samples, cfg.DT, cfg.Seed)
+			systemName = "XOR System (Synergy)"
+		case "logistic", "chaos":
+			data = validation.GenerateLogisticMap(cfg.Samples, cfg.DT, cfg.Seed)
+			systemName = "Coupled Logistic Maps (Chaotic Drive)"
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown system type: %s\n", *systemType)
+			fmt.Fprintf(os.Stderr, "Available: duplicated, independent, xor, logistic\n")
+			os.Exit(1)
+		}
+	}
+	if len(data) == 0 {
+		fmt.Fprintf(os.Stderr, "No samples to analyze\n")
 		os.Exit(1)
 	}
 
-	// Create bins array
-	binsArray := []int{*bins, *bins, *bins}
+	// Create bins array: same number of bins for the target and every agent
+	binsArray := make([]int, len(data[0]))
+	for i := range binsArray {
+		binsArray[i] = cfg.Bins
+	}
 
 	// Run SURD decomposition
 	result, err := surd.DecomposeFromData(data, binsArray)
@@ -68,98 +90,48 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Calculate totals
-	totalRedundant := 0.0
-	for _, r := range result.Redundant {
-		totalRedundant += r
-	}
-
-	totalUnique := 0.0
-	for _, u := range result.Unique {
-		totalUnique += u
-	}
-
-	totalSynergistic := 0.0
-	for _, s := range result.Synergistic {
-		totalSynergistic += s
-	}
-
-	totalInfo := totalRedundant + totalUnique + totalSynergistic
-	if totalInfo == 0 {
-		totalInfo = 1.0 // Avoid division by zero
-	}
-
 	// Print results
 	fmt.Printf("\nSURD Decomposition: %s\n", systemName)
 	fmt.Printf("==================================================\n")
 	fmt.Printf("Configuration:\n")
-	fmt.Printf("  Samples: %d\n", *samples)
-	fmt.Printf("  Bins: %d\n", *bins)
-	fmt.Printf("  Time Delay: %d\n", *dt)
-	fmt.Printf("  Seed: %d\n\n", *seed)
-
-	// ASCII bar chart
-	barWidth := 40
-	fmt.Printf("Components:\n")
-	printBar("Redundant", totalRedundant, totalInfo, barWidth)
-	printBar("Unique", totalUnique, totalInfo, barWidth)
-	printBar("Synergistic", totalSynergistic, totalInfo, barWidth)
-	fmt.Printf("\n")
-
-	// InfoLeak
-	fmt.Printf("Information Leak:\n")
-	printBar("InfoLeak", result.InfoLeak, 1.0, barWidth)
-	fmt.Printf("\n")
-
-	// Detailed breakdown
-	if totalUnique > 0 {
-		fmt.Printf("Unique Breakdown:\n")
-		for key, val := range result.Unique {
-			if val > 0 {
-				agentName := fmt.Sprintf("  Agent[%s]", key)
-				printBar(agentName, val, totalInfo, barWidth)
-			}
-		}
-		fmt.Printf("\n")
+	if cfg.Input != "" {
+		fmt.Printf("  Input: %s\n", cfg.Input)
+		fmt.Printf("  Agents: %d (Agent[i] = column i)\n", len(data[0])-1)
 	}
-
-	if totalRedundant > 0 {
-		fmt.Printf("Redundant Combinations:\n")
-		for key, val := range result.Redundant {
-			if val > 0 {
-				combName := fmt.Sprintf("  {%s}", key)
-				printBar(combName, val, totalInfo, barWidth)
-			}
-		}
-		fmt.Printf("\n")
+	fmt.Printf("  Samples: %d\n", len(data))
+	fmt.Printf("  Bins: %d\n", cfg.Bins)
+	fmt.Printf("  Time Delay: %d\n", cfg.DT)
+	if cfg.Input == "" {
+		fmt.Printf("  Seed: %d\n", cfg.Seed)
 	}
+	fmt.Println()
 
-	if totalSynergistic > 0 {
-		fmt.Printf("Synergistic Combinations:\n")
-		for key, val := range result.Synergistic {
-			if val > 0 {
-				combName := fmt.Sprintf("  {%s}", key)
-				printBar(combName, val, totalInfo, barWidth)
-			}
-		}
-		fmt.Printf("\n")
-	}
-
-	// Summary statistics
-	fmt.Printf("Summary:\n")
-	fmt.Printf("  Total Information: %.4f bits\n", totalInfo)
-	fmt.Printf("  Redundant: %.4f bits (%.1f%%)\n", totalRedundant, 100*totalRedundant/totalInfo)
-	fmt.Printf("  Unique: %.4f bits (%.1f%%)\n", totalUnique, 100*totalUnique/totalInfo)
-	fmt.Printf("  Synergistic: %.4f bits (%.1f%%)\n", totalSynergistic, 100*totalSynergistic/totalInfo)
-	fmt.Printf("  InfoLeak: %.4f (%.1f%%)\n", result.InfoLeak, 100*result.InfoLeak)
+	// ASCII bar chart and summary
+	barWidth := 40
+	fmt.Print(visualization.ASCIIReport(result, barWidth))
 
 	// Generate graphical plot if output specified
-	if *output != "" {
-		if err := generatePlot(result, systemName, *output, *format); err != nil {
+	if cfg.Output != "" {
+		if err := generatePlot(result, systemName, cfg.Output, cfg.Format); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to generate plot: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("\nPlot saved to: %s\n", *output)
+		fmt.Printf("\nPlot saved to: %s\n", cfg.Output)
+	}
+}
+
+// loadInput loads a [samples x variables] matrix from a CSV or MAT file,
+// chosen by extension. For MAT files varName is a [variables x samples]
+// matrix, as in the reference datasets.
+func loadInput(path, varName string, header bool) ([][]float64, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		data, _, err := matdata.LoadCSV(path, header)
+		return data, err
+	case ".mat":
+		return matdata.LoadMatrixTransposed(path, varName)
+	default:
+		return nil, fmt.Errorf("unsupported input format %q (want .csv or .mat)", filepath.Ext(path))
 	}
 }
 
@@ -203,29 +175,3 @@ func generatePlot(result *surd.Result, systemName, outputPath, formatStr string)
 
 	return nil
 }
-
-// printBar prints an ASCII bar chart line
-func printBar(label string, value, total float64, width int) {
-	percentage := value / total
-	if percentage < 0 {
-		percentage = 0
-	}
-	if percentage > 1 {
-		percentage = 1
-	}
-
-	// Calculate bar length
-	barLen := int(percentage * float64(width))
-	if barLen < 0 {
-		barLen = 0
-	}
-	if barLen > width {
-		barLen = width
-	}
-
-	// Create bar with filled and empty parts
-	bar := strings.Repeat("█", barLen) + strings.Repeat("░", width-barLen)
-
-	// Print line
-	fmt.Printf("%-20s %s %.1f%%\n", label+":", bar, 100*percentage)
-}
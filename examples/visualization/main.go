@@ -107,29 +107,10 @@ func main() {
 
 // printSummary displays a brief summary of SURD results.
 func printSummary(result *surd.Result) {
-	totalRedundant := 0.0
-	for _, r := range result.Redundant {
-		totalRedundant += r
-	}
-
-	totalUnique := 0.0
-	for _, u := range result.Unique {
-		totalUnique += u
-	}
-
-	totalSynergistic := 0.0
-	for _, s := range result.Synergistic {
-		totalSynergistic += s
-	}
-
-	totalInfo := totalRedundant + totalUnique + totalSynergistic
-	if totalInfo == 0 {
-		totalInfo = 1.0
-	}
-
+	n := result.Normalized()
 	fmt.Printf("  Redundant: %.1f%% | Unique: %.1f%% | Synergistic: %.1f%% | Leak: %.1f%%\n",
-		100*totalRedundant/totalInfo,
-		100*totalUnique/totalInfo,
-		100*totalSynergistic/totalInfo,
+		100*n.TotalRedundant,
+		100*n.TotalUnique,
+		100*n.TotalSynergistic,
 		100*result.InfoLeak)
 }
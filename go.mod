@@ -4,6 +4,7 @@ go 1.25
 
 require (
 	github.com/causalgo/lasso v0.2.1
+	github.com/scigolib/hdf5 v0.13.1
 	github.com/scigolib/matlab v0.3.1
 	gonum.org/v1/gonum v0.16.0
 	gonum.org/v1/plot v0.16.0
@@ -18,7 +19,6 @@ require (
 	github.com/campoy/embedmd v1.0.0 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/scigolib/hdf5 v0.13.1 // indirect
 	golang.org/x/image v0.25.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
 )
@@ -0,0 +1,69 @@
+// Package cliconfig defines the command-line flags shared by the causalgo
+// commands, so every tool spells and defaults them the same way.
+//
+// Typical use:
+//
+//	fs := flag.NewFlagSet("mytool", flag.ExitOnError)
+//	cfg := cliconfig.Register(fs)
+//	system := fs.String("system", "xor", "...") // command-specific flags
+//	_ = fs.Parse(os.Args[1:])
+package cliconfig
+
+import "flag"
+
+// Default values for the shared flags.
+const (
+	DefaultSamples = 100000
+	DefaultBins    = 2
+	DefaultDT      = 1
+	DefaultSeed    = 42
+	DefaultFormat  = "png"
+)
+
+// Config holds the values of the shared flags after parsing.
+type Config struct {
+	Samples int    // number of samples to generate
+	Bins    int    // histogram bins per variable
+	DT      int    // time delay between agents and target
+	Seed    int64  // random seed
+	Input   string // input data file; empty means synthetic data
+	Output  string // output file; empty means terminal output only
+	Format  string // output format: png, svg, pdf
+}
+
+// Default returns a Config populated with the default flag values.
+func Default() Config {
+	return Config{
+		Samples: DefaultSamples,
+		Bins:    DefaultBins,
+		DT:      DefaultDT,
+		Seed:    DefaultSeed,
+		Format:  DefaultFormat,
+	}
+}
+
+// Register defines the shared flags on fs and returns the Config they fill in.
+// The Config holds defaults until fs.Parse is called.
+func Register(fs *flag.FlagSet) *Config {
+	cfg := Default()
+	fs.IntVar(&cfg.Samples, "samples", cfg.Samples, "Number of samples")
+	fs.IntVar(&cfg.Bins, "bins", cfg.Bins, "Number of bins per variable")
+	fs.IntVar(&cfg.DT, "dt", cfg.DT, "Time delay")
+	fs.Int64Var(&cfg.Seed, "seed", cfg.Seed, "Random seed")
+	fs.StringVar(&cfg.Input, "input", cfg.Input, "Input data file (CSV/MAT). If empty, synthetic data is generated")
+	fs.StringVar(&cfg.Output, "output", cfg.Output, "Output file (PNG/SVG/PDF). If empty, shows ASCII chart only")
+	fs.StringVar(&cfg.Format, "format", cfg.Format, "Output format: png, svg, pdf")
+	return &cfg
+}
+
+// Parse registers the shared flags on a new FlagSet named name, parses args
+// and returns the resulting Config. Commands with their own flags should use
+// Register instead.
+func Parse(name string, args []string) (*Config, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	cfg := Register(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
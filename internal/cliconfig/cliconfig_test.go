@@ -0,0 +1,59 @@
+package cliconfig
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestParse_Defaults(t *testing.T) {
+	cfg, err := Parse("test", nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if *cfg != Default() {
+		t.Errorf("defaults = %+v, want %+v", *cfg, Default())
+	}
+}
+
+func TestParse_Args(t *testing.T) {
+	args := []string{
+		"--samples", "5000", "--bins=4", "--dt", "3", "--seed", "7",
+		"--input", "data.csv", "--output", "out.svg", "--format", "svg",
+	}
+	cfg, err := Parse("test", args)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := Config{
+		Samples: 5000, Bins: 4, DT: 3, Seed: 7,
+		Input: "data.csv", Output: "out.svg", Format: "svg",
+	}
+	if *cfg != want {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestRegister_WithCommandFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg := Register(fs)
+	system := fs.String("system", "xor", "system type")
+	if err := fs.Parse([]string{"--system", "dup", "--bins", "3", "extra"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if *system != "dup" || cfg.Bins != 3 || cfg.Samples != DefaultSamples {
+		t.Errorf("system=%q bins=%d samples=%d", *system, cfg.Bins, cfg.Samples)
+	}
+	if fs.NArg() != 1 || fs.Arg(0) != "extra" {
+		t.Errorf("positional args = %v, want [extra]", fs.Args())
+	}
+}
+
+func TestParse_InvalidValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	Register(fs)
+	if err := fs.Parse([]string{"--samples", "many"}); err == nil {
+		t.Error("expected error for non-integer --samples")
+	}
+}
@@ -14,6 +14,10 @@ type System struct {
 	Name        string
 	Description string
 	Generator   func(n int, seed int64) (*mat.Dense, []int) // Returns (data, true_order)
+
+	// TrueAdjacency holds the true direct causal links, nil if unknown.
+	// Same convention as varselect.Result.Adjacency: [effect][cause] = true.
+	TrueAdjacency [][]bool
 }
 
 // ComparisonResult stores results from both algorithms for comparison.
@@ -21,6 +25,7 @@ type ComparisonResult struct {
 	SystemName     string
 	TrueOrder      []int
 	VarSelectOrder []int
+	VarSelectAdj   [][]bool           // Recovered adjacency [effect][cause]
 	SURDResults    map[string]float64 // R, U, S values
 	ExecutionTime  struct {
 		VarSelect float64 // milliseconds
@@ -29,6 +34,9 @@ type ComparisonResult struct {
 	Accuracy struct {
 		VarSelectOrderCorrect bool
 		VarSelectSpearman     float64 // Rank correlation
+		VarSelectPrecision    float64 // Adjacency precision (if TrueAdjacency is known)
+		VarSelectRecall       float64 // Adjacency recall
+		VarSelectF1           float64 // Adjacency F1 score
 	}
 }
 
@@ -36,34 +44,40 @@ type ComparisonResult struct {
 func TestSystems() []System {
 	return []System{
 		{
-			Name:        "Linear Chain",
-			Description: "Y = a*X1 + b*X2 + noise (linear dependencies)",
-			Generator:   generateLinearChain,
+			Name:          "Linear Chain",
+			Description:   "Y = a*X1 + b*X2 + noise (linear dependencies)",
+			Generator:     generateLinearChain,
+			TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 2}, {1, 2}}),
 		},
 		{
-			Name:        "Nonlinear Multiplicative",
-			Description: "Y = X1 * X2 (nonlinear multiplicative)",
-			Generator:   generateNonlinearMultiplicative,
+			Name:          "Nonlinear Multiplicative",
+			Description:   "Y = X1 * X2 (nonlinear multiplicative)",
+			Generator:     generateNonlinearMultiplicative,
+			TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 2}, {1, 2}}),
 		},
 		{
-			Name:        "XOR System",
-			Description: "Y = X1 XOR X2 (logical synergy)",
-			Generator:   generateXOR,
+			Name:          "XOR System",
+			Description:   "Y = X1 XOR X2 (logical synergy)",
+			Generator:     generateXOR,
+			TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 2}, {1, 2}}),
 		},
 		{
-			Name:        "Redundant Sources",
-			Description: "X1 ≈ X2, both cause Y (redundancy)",
-			Generator:   generateRedundant,
+			Name:          "Redundant Sources",
+			Description:   "X1 ≈ X2, both cause Y (redundancy)",
+			Generator:     generateRedundant,
+			TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 1}, {0, 2}, {1, 2}}),
 		},
 		{
-			Name:        "Mediator Chain",
-			Description: "X1 → X2 → X3 (causal chain)",
-			Generator:   generateMediatorChain,
+			Name:          "Mediator Chain",
+			Description:   "X1 → X2 → X3 (causal chain)",
+			Generator:     generateMediatorChain,
+			TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 1}, {1, 2}}),
 		},
 		{
-			Name:        "Confounder",
-			Description: "X1 ← Z → X2 (common cause)",
-			Generator:   generateConfounder,
+			Name:          "Confounder",
+			Description:   "X1 ← Z → X2 (common cause)",
+			Generator:     generateConfounder,
+			TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 2}, {1, 2}}),
 		},
 	}
 }
@@ -262,6 +276,58 @@ func generateConfounder(n int, seed int64) (*mat.Dense, []int) {
 	return data, []int{0, 1, 2}
 }
 
+// AdjacencyFromEdges builds a p×p adjacency matrix from (cause, effect) pairs
+// using the varselect convention adj[effect][cause] = true.
+func AdjacencyFromEdges(p int, edges [][2]int) [][]bool {
+	adj := make([][]bool, p)
+	for i := range adj {
+		adj[i] = make([]bool, p)
+	}
+	for _, e := range edges {
+		adj[e[1]][e[0]] = true
+	}
+	return adj
+}
+
+// AdjacencyMetrics scores a recovered adjacency matrix against the true one.
+//
+// Every off-diagonal entry of truth is a candidate edge; entries missing from
+// predicted count as absent. Direction matters: predicting j → i for a true
+// i → j is both a false positive and a false negative.
+//
+// Returns precision = TP/(TP+FP), recall = TP/(TP+FN) and their harmonic mean
+// F1. Each is 0 when its denominator is 0.
+func AdjacencyMetrics(predicted, truth [][]bool) (precision, recall, f1 float64) {
+	var tp, fp, fn int
+	for i := range truth {
+		for j := range truth[i] {
+			if i == j {
+				continue
+			}
+			pred := i < len(predicted) && j < len(predicted[i]) && predicted[i][j]
+			switch {
+			case pred && truth[i][j]:
+				tp++
+			case pred:
+				fp++
+			case truth[i][j]:
+				fn++
+			}
+		}
+	}
+
+	if tp+fp > 0 {
+		precision = float64(tp) / float64(tp+fp)
+	}
+	if tp+fn > 0 {
+		recall = float64(tp) / float64(tp+fn)
+	}
+	if precision+recall > 0 {
+		f1 = 2 * precision * recall / (precision + recall)
+	}
+	return precision, recall, f1
+}
+
 // SpearmanCorrelation computes Spearman rank correlation between two orderings.
 // Returns value in [-1, 1] where 1 = perfect agreement, -1 = perfect disagreement.
 func SpearmanCorrelation(order1, order2 []int) (float64, error) {
@@ -342,3 +408,80 @@ func NormalizeData(data *mat.Dense) *mat.Dense {
 
 	return normalized
 }
+
+// MinMaxNormalize scales each column to [0, 1]: (x - min) / (max - min).
+//
+// Unlike NormalizeData, the scaled columns keep their shape and span exactly
+// [0, 1], so equal-width histogram bins stay interpretable. Constant columns
+// are set to 0. NaN values are ignored when finding the range and stay NaN.
+func MinMaxNormalize(data *mat.Dense) *mat.Dense {
+	n, p := data.Dims()
+	normalized := mat.NewDense(n, p, nil)
+	normalized.Copy(data)
+
+	for j := 0; j < p; j++ {
+		lo, span := columnRange(mat.Col(nil, j, normalized))
+		for i := 0; i < n; i++ {
+			normalized.Set(i, j, minMaxScale(normalized.At(i, j), lo, span))
+		}
+	}
+
+	return normalized
+}
+
+// MinMaxNormalizeSlice is MinMaxNormalize for [samples x variables] slices,
+// e.g. before surd.DecomposeFromData. data is not modified.
+func MinMaxNormalizeSlice(data [][]float64) [][]float64 {
+	normalized := make([][]float64, len(data))
+	if len(data) == 0 {
+		return normalized
+	}
+
+	p := len(data[0])
+	col := make([]float64, len(data))
+	los := make([]float64, p)
+	spans := make([]float64, p)
+	for j := 0; j < p; j++ {
+		for i, row := range data {
+			col[i] = row[j]
+		}
+		los[j], spans[j] = columnRange(col)
+	}
+
+	for i, row := range data {
+		normalized[i] = make([]float64, p)
+		for j, v := range row {
+			normalized[i][j] = minMaxScale(v, los[j], spans[j])
+		}
+	}
+	return normalized
+}
+
+// columnRange returns the minimum and max - min of the non-NaN values of col.
+// span is 0 for constant or all-NaN columns.
+func columnRange(col []float64) (lo, span float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range col {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	if hi <= lo {
+		return 0, 0
+	}
+	return lo, hi - lo
+}
+
+// minMaxScale maps v from [lo, lo+span] to [0, 1]; 0 if span is 0.
+func minMaxScale(v, lo, span float64) float64 {
+	if span == 0 {
+		if math.IsNaN(v) {
+			return v
+		}
+		return 0
+	}
+	return (v - lo) / span
+}
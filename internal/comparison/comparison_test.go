@@ -2,11 +2,14 @@ package comparison
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/causalgo/causalgo/internal/varselect"
+	"github.com/causalgo/causalgo/regression"
 	"github.com/causalgo/causalgo/surd"
+	"gonum.org/v1/gonum/mat"
 )
 
 const (
@@ -99,12 +102,85 @@ func TestRedundantSystem(t *testing.T) {
 	}
 }
 
+// TestRedundantSystem_ElasticNet compares LASSO and elastic net on the step
+// where VarSelect regresses X3 on the collinear pair X1 ≈ X2. With strong
+// regularization LASSO keeps one of the pair and drops the other, depending on
+// the seed; elastic net splits the weight evenly in every run.
+func TestRedundantSystem_ElasticNet(t *testing.T) {
+	const (
+		lambda = 400.0
+		seeds  = 30
+	)
+
+	fitShares := func(reg regression.Regressor) (shares []float64, dropped int) {
+		for seed := int64(0); seed < seeds; seed++ {
+			data, _ := generateRedundant(500, seed)
+			data = NormalizeData(data)
+			n, _ := data.Dims()
+			x := mat.NewDense(n, 2, nil)
+			y := make([]float64, n)
+			for i := 0; i < n; i++ {
+				x.Set(i, 0, data.At(i, 0))
+				x.Set(i, 1, data.At(i, 1))
+				y[i] = data.At(i, 2)
+			}
+
+			w := reg.Fit(x, y)
+			if w[0] == 0 || w[1] == 0 {
+				dropped++
+				continue
+			}
+			shares = append(shares, math.Abs(w[0])/(math.Abs(w[0])+math.Abs(w[1])))
+		}
+		return shares, dropped
+	}
+	spread := func(shares []float64) float64 {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, s := range shares {
+			lo, hi = math.Min(lo, s), math.Max(hi, s)
+		}
+		return hi - lo
+	}
+
+	lassoShares, lassoDropped := fitShares(regression.NewLASSO(regression.LASSOConfig{Lambda: lambda}))
+	enetShares, enetDropped := fitShares(regression.NewElasticNet(regression.ElasticNetConfig{Lambda: lambda, L1Ratio: 0.5}))
+	t.Logf("LASSO: dropped a predictor in %d/%d runs, X1 share spread %.3f", lassoDropped, seeds, spread(lassoShares))
+	t.Logf("Elastic net: dropped a predictor in %d/%d runs, X1 share spread %.3f", enetDropped, seeds, spread(enetShares))
+
+	if lassoDropped == 0 {
+		t.Errorf("expected LASSO to drop one of the collinear predictors in some runs")
+	}
+	if enetDropped != 0 {
+		t.Errorf("elastic net dropped a collinear predictor in %d runs", enetDropped)
+	}
+	if spread(enetShares) >= spread(lassoShares) {
+		t.Errorf("elastic net X1 share spread (%.3f) should be below LASSO (%.3f)",
+			spread(enetShares), spread(lassoShares))
+	}
+	for _, s := range enetShares {
+		if math.Abs(s-0.5) > 0.05 {
+			t.Errorf("elastic net X1 share %.3f, expected ≈ 0.5", s)
+		}
+	}
+
+	// The elastic-net selector runs end to end on the same system
+	data, _ := generateRedundant(testSamples, testSeed)
+	result, err := varselect.NewElasticNet(varselect.Config{Lambda: lambda}, 0.5).Fit(data)
+	if err != nil {
+		t.Fatalf("VarSelect (elastic net) failed: %v", err)
+	}
+	if len(result.Order) != 3 {
+		t.Errorf("expected an order of 3 variables, got %v", result.Order)
+	}
+}
+
 // TestMediatorChain tests a causal chain X1 → X2 → X3.
 func TestMediatorChain(t *testing.T) {
 	system := System{
-		Name:        "Mediator Chain",
-		Description: "X1 → X2 → X3",
-		Generator:   generateMediatorChain,
+		Name:          "Mediator Chain",
+		Description:   "X1 → X2 → X3",
+		Generator:     generateMediatorChain,
+		TrueAdjacency: AdjacencyFromEdges(3, [][2]int{{0, 1}, {1, 2}}),
 	}
 
 	result := runComparison(t, system, testSamples, testSeed)
@@ -114,6 +190,26 @@ func TestMediatorChain(t *testing.T) {
 	if !result.Accuracy.VarSelectOrderCorrect {
 		t.Logf("WARNING: VarSelect did not recover correct order in mediator chain")
 	}
+
+	// Metrics must agree with a direct count over the recovered adjacency
+	var tp, fp, fn int
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			pred, truth := result.VarSelectAdj[i][j], system.TrueAdjacency[i][j]
+			switch {
+			case pred && truth:
+				tp++
+			case pred:
+				fp++
+			case truth:
+				fn++
+			}
+		}
+	}
+	wantF1 := 2 * float64(tp) / float64(2*tp+fp+fn)
+	if math.Abs(result.Accuracy.VarSelectF1-wantF1) > 1e-12 {
+		t.Errorf("VarSelect F1 = %.4f, want %.4f (TP=%d FP=%d FN=%d)", result.Accuracy.VarSelectF1, wantF1, tp, fp, fn)
+	}
 }
 
 // TestConfounder tests a confounded system.
@@ -166,6 +262,87 @@ func TestAllSystems(t *testing.T) {
 	t.Log("SURD: Best for nonlinear systems, detects synergy/redundancy, slower")
 }
 
+// TestAdjacencyMetrics checks precision/recall/F1 on the mediator chain X1 → X2 → X3.
+func TestAdjacencyMetrics(t *testing.T) {
+	truth := AdjacencyFromEdges(3, [][2]int{{0, 1}, {1, 2}})
+
+	tests := []struct {
+		name                      string
+		predicted                 [][]bool
+		precision, recall, wantF1 float64
+	}{
+		{"exact", AdjacencyFromEdges(3, [][2]int{{0, 1}, {1, 2}}), 1, 1, 1},
+		// Extra X1 → X3 shortcut: one false positive
+		{"false positive", AdjacencyFromEdges(3, [][2]int{{0, 1}, {1, 2}, {0, 2}}), 2.0 / 3, 1, 0.8},
+		// X2 → X3 reversed: one false positive and one false negative
+		{"reversed edge", AdjacencyFromEdges(3, [][2]int{{0, 1}, {2, 1}}), 0.5, 0.5, 0.5},
+		{"empty", AdjacencyFromEdges(3, nil), 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precision, recall, f1 := AdjacencyMetrics(tt.predicted, truth)
+			if math.Abs(precision-tt.precision) > 1e-12 || math.Abs(recall-tt.recall) > 1e-12 || math.Abs(f1-tt.wantF1) > 1e-12 {
+				t.Errorf("AdjacencyMetrics() = (%.4f, %.4f, %.4f), want (%.4f, %.4f, %.4f)",
+					precision, recall, f1, tt.precision, tt.recall, tt.wantF1)
+			}
+		})
+	}
+}
+
+func TestMinMaxNormalize(t *testing.T) {
+	rows := [][]float64{
+		{2, 5, -1},
+		{4, 5, math.NaN()},
+		{6, 5, 3},
+		{3, 5, 1},
+	}
+	want := [][]float64{
+		{0, 0, 0},
+		{0.5, 0, math.NaN()},
+		{1, 0, 1},
+		{0.25, 0, 0.5},
+	}
+
+	check := func(t *testing.T, got [][]float64) {
+		t.Helper()
+		for i := range want {
+			for j := range want[i] {
+				w, g := want[i][j], got[i][j]
+				if math.IsNaN(w) != math.IsNaN(g) || (!math.IsNaN(w) && math.Abs(g-w) > 1e-12) {
+					t.Errorf("[%d][%d] = %v, want %v", i, j, g, w)
+				}
+			}
+		}
+	}
+
+	t.Run("slice", func(t *testing.T) {
+		check(t, MinMaxNormalizeSlice(rows))
+		if rows[0][0] != 2 {
+			t.Error("input was modified")
+		}
+		if got := MinMaxNormalizeSlice(nil); len(got) != 0 {
+			t.Errorf("empty input: got %v", got)
+		}
+	})
+
+	t.Run("dense", func(t *testing.T) {
+		data := mat.NewDense(len(rows), len(rows[0]), nil)
+		for i, row := range rows {
+			data.SetRow(i, row)
+		}
+		normalized := MinMaxNormalize(data)
+		got := make([][]float64, len(rows))
+		for i := range got {
+			got[i] = mat.Row(nil, i, normalized)
+		}
+		check(t, got)
+		if data.At(0, 0) != 2 {
+			t.Error("input was modified")
+		}
+	})
+}
+
 // runComparison runs both algorithms on a system and returns results.
 func runComparison(t *testing.T, system System, n int, seed int64) ComparisonResult {
 	t.Helper()
@@ -210,6 +387,13 @@ func runComparison(t *testing.T, system System, n int, seed int64) ComparisonRes
 	}
 	result.Accuracy.VarSelectSpearman = spearman
 
+	// Score recovered adjacency against the true links
+	result.VarSelectAdj = vsResult.Adjacency
+	if system.TrueAdjacency != nil {
+		result.Accuracy.VarSelectPrecision, result.Accuracy.VarSelectRecall, result.Accuracy.VarSelectF1 =
+			AdjacencyMetrics(vsResult.Adjacency, system.TrueAdjacency)
+	}
+
 	// Run SURD
 	t.Logf("Running SURD on %s...", system.Name)
 	startSURD := time.Now()
@@ -316,6 +500,11 @@ func printComparisonResult(t *testing.T, result ComparisonResult) {
 		result.Accuracy.VarSelectOrderCorrect,
 		result.Accuracy.VarSelectSpearman,
 	)
+	t.Logf("VarSelect Adjacency: precision=%.3f, recall=%.3f, F1=%.3f",
+		result.Accuracy.VarSelectPrecision,
+		result.Accuracy.VarSelectRecall,
+		result.Accuracy.VarSelectF1,
+	)
 	t.Logf("Execution Times: VarSelect=%.2fms, SURD=%.2fms",
 		result.ExecutionTime.VarSelect,
 		result.ExecutionTime.SURD,
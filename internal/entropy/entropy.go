@@ -1,8 +1,14 @@
 // Package entropy provides information-theoretic functions for causal analysis.
-// It implements Shannon entropy and related measures for discrete probability distributions.
+// It implements Shannon entropy and related measures for discrete probability distributions,
+// and the KSG nearest-neighbor estimator of mutual information for continuous data.
 package entropy
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
 
 // Log2Safe computes the base-2 logarithm of x, returning 0 for x <= 0.
 // This avoids singularities in entropy calculations where 0*log(0) should be treated as 0.
@@ -47,6 +53,209 @@ func Entropy(p []float64) float64 {
 	return -sum
 }
 
+// EntropyBase computes the Shannon entropy of p in units of the given
+// logarithm base: H_b(p) = -Σ p_i * log_b(p_i) = H(p) / log2(b)
+//
+// Base 2 gives bits (same as Entropy), base e gives nats and base 10 gives
+// hartleys. The base-2 functions of this package remain the default; use this
+// when comparing against tools that report in other units.
+//
+// Parameters:
+//   - p: Probability distribution (should sum to 1.0)
+//   - base: Logarithm base, > 0 and != 1
+//
+// Returns:
+//   - Shannon entropy in base-b units, or NaN for an invalid base
+//
+// Example:
+//
+//	p := []float64{0.5, 0.5}
+//	h := EntropyBase(p, math.E) // h = ln 2 ≈ 0.693 nats
+func EntropyBase(p []float64, base float64) float64 {
+	return convertBits(Entropy(p), base)
+}
+
+// convertBits converts an information quantity from bits to base-b units.
+// Returns NaN for a base that is not finite, <= 0 or 1.
+func convertBits(bits, base float64) float64 {
+	if !(base > 0) || base == 1 || math.IsInf(base, 1) {
+		return math.NaN()
+	}
+	if base == 2 {
+		return bits
+	}
+	return bits / math.Log2(base)
+}
+
+// renyiShannonTol is the distance from alpha = 1 below which RenyiEntropy
+// returns the Shannon limit, avoiding the 0/0 form of the general formula.
+const renyiShannonTol = 1e-9
+
+// RenyiEntropy computes the Rényi entropy of order alpha:
+// H_α(p) = 1/(1-α) * log2(Σ p_i^α)
+//
+// The input slice p should sum to 1.0; like Entropy, normalization is not
+// enforced. Entries p_i <= 0 lie outside the support and are ignored, so
+// negative values never enter the power sum.
+//
+// Special orders:
+//   - α = 0: Hartley entropy, log2 of the support size
+//   - α → 1: Shannon entropy (falls back to Entropy)
+//   - α = +Inf: min-entropy, -log2(max p_i)
+//
+// Small α weights rare events more heavily, large α is dominated by the most
+// likely outcomes. H_α is non-increasing in α.
+//
+// Parameters:
+//   - p: Probability distribution (should sum to 1.0)
+//   - alpha: Order α >= 0
+//
+// Returns:
+//   - Rényi entropy in bits, or NaN for negative or NaN alpha
+//
+// Example:
+//
+//	p := []float64{0.5, 0.25, 0.25}
+//	h2 := RenyiEntropy(p, 2) // collision entropy, -log2(0.375) ≈ 1.415 bits
+func RenyiEntropy(p []float64, alpha float64) float64 {
+	switch {
+	case alpha < 0 || math.IsNaN(alpha):
+		return math.NaN()
+	case math.Abs(alpha-1) < renyiShannonTol:
+		return Entropy(p)
+	case math.IsInf(alpha, 1):
+		var maxP float64
+		for _, pi := range p {
+			maxP = math.Max(maxP, pi)
+		}
+		return -Log2Safe(maxP)
+	}
+
+	var sum float64
+	for _, pi := range p {
+		if pi <= 0 {
+			continue
+		}
+		if alpha == 0 {
+			sum++
+		} else {
+			sum += math.Pow(pi, alpha)
+		}
+	}
+	return Log2Safe(sum) / (1 - alpha)
+}
+
+// KLDivergence computes the Kullback-Leibler divergence of q from p:
+// D_KL(p || q) = Σ p_i * log2(p_i / q_i)
+//
+// Terms with p_i = 0 contribute 0. If q_i = 0 for some p_i > 0, p is not
+// absolutely continuous with respect to q and the divergence is +Inf.
+// Like Entropy, the inputs are not required to be normalized.
+//
+// Panics if p and q have different lengths.
+//
+// Parameters:
+//   - p: Observed probability distribution
+//   - q: Reference probability distribution
+//
+// Returns:
+//   - KL divergence in bits (0 if p == q)
+//
+// Example:
+//
+//	p := []float64{0.5, 0.5}
+//	q := []float64{0.75, 0.25}
+//	d := KLDivergence(p, q) // d ≈ 0.2075 bits
+func KLDivergence(p, q []float64) float64 {
+	checkSameLength(p, q)
+
+	var sum float64
+	for i, pi := range p {
+		if pi <= 0 {
+			continue
+		}
+		if q[i] <= 0 {
+			return math.Inf(1)
+		}
+		sum += pi * (Log2Safe(pi) - Log2Safe(q[i]))
+	}
+	return sum
+}
+
+// CrossEntropy computes the cross-entropy of q relative to p:
+// H(p, q) = -Σ p_i * log2(q_i) = H(p) + D_KL(p || q)
+//
+// Terms with p_i = 0 contribute 0; q_i = 0 for some p_i > 0 gives +Inf.
+//
+// Panics if p and q have different lengths.
+//
+// Parameters:
+//   - p: Observed probability distribution
+//   - q: Reference probability distribution
+//
+// Returns:
+//   - Cross-entropy in bits
+//
+// Example:
+//
+//	p := []float64{0.5, 0.5}
+//	q := []float64{0.75, 0.25}
+//	h := CrossEntropy(p, q) // h ≈ 1.2075 bits
+func CrossEntropy(p, q []float64) float64 {
+	checkSameLength(p, q)
+
+	var sum float64
+	for i, pi := range p {
+		if pi <= 0 {
+			continue
+		}
+		if q[i] <= 0 {
+			return math.Inf(1)
+		}
+		sum += pi * Log2Safe(q[i])
+	}
+	return -sum
+}
+
+// JensenShannonDivergence computes the Jensen-Shannon divergence between p
+// and q:
+// JSD(p, q) = ½ D_KL(p || m) + ½ D_KL(q || m), with m = (p + q) / 2
+//
+// Unlike KLDivergence it is symmetric and always finite: m_i > 0 wherever
+// p_i > 0 or q_i > 0. For normalized p and q the result is in [0, 1] bits,
+// 0 if and only if p == q and 1 for disjoint supports. Like KLDivergence,
+// the inputs are not normalized here.
+//
+// Panics if p and q have different lengths.
+//
+// Parameters:
+//   - p, q: Probability distributions
+//
+// Returns:
+//   - JS divergence in bits
+//
+// Example:
+//
+//	p := []float64{1, 0}
+//	q := []float64{0.5, 0.5}
+//	d := JensenShannonDivergence(p, q) // d ≈ 0.3113 bits
+func JensenShannonDivergence(p, q []float64) float64 {
+	checkSameLength(p, q)
+
+	m := make([]float64, len(p))
+	for i := range p {
+		m[i] = (p[i] + q[i]) / 2
+	}
+	return KLDivergence(p, m)/2 + KLDivergence(q, m)/2
+}
+
+// checkSameLength panics if two distributions have different lengths.
+func checkSameLength(p, q []float64) {
+	if len(p) != len(q) {
+		panic(fmt.Sprintf("entropy: distribution lengths differ (%d != %d)", len(p), len(q)))
+	}
+}
+
 // NDArray represents an N-dimensional array for joint probability distributions.
 // Data is stored in row-major (C-contiguous) order.
 //
@@ -184,6 +393,140 @@ func marginalize(arr *NDArray, keepAxes []int) []float64 {
 	return result
 }
 
+// DefaultParallelMinSize is the default array size below which Marginalize
+// runs serially, since spawning workers costs more than it saves.
+const DefaultParallelMinSize = 1 << 16
+
+// MarginalizeOptions configures Marginalize.
+type MarginalizeOptions struct {
+	// Workers is the number of goroutines (<= 0 means runtime.GOMAXPROCS(0)).
+	Workers int
+
+	// MinSize is the array size below which the serial loop is used
+	// (<= 0 means DefaultParallelMinSize).
+	MinSize int
+}
+
+// DefaultMarginalizeOptions returns options using runtime.GOMAXPROCS(0) workers.
+func DefaultMarginalizeOptions() MarginalizeOptions {
+	return MarginalizeOptions{
+		Workers: runtime.GOMAXPROCS(0),
+		MinSize: DefaultParallelMinSize,
+	}
+}
+
+// Marginalize sums the N-dimensional array over all axes except keepAxes,
+// splitting the cells of the marginal across workers for large arrays.
+//
+// Each marginal cell is summed by a single worker, adding its elements in
+// increasing flat index as the serial loop does, so the result is
+// bit-identical to the serial computation for any number of workers.
+// Parallelism is limited to the number of marginal cells.
+//
+// Returns an error if an axis in keepAxes is out of range or repeated; the
+// axes are checked before any worker starts.
+//
+// Example:
+//
+//	// P(X0, X1, ..., X5) with 10 bins each (1e6 elements)
+//	marginal, err := Marginalize(arr, []int{0, 3}, DefaultMarginalizeOptions())
+func Marginalize(arr *NDArray, keepAxes []int, opts MarginalizeOptions) ([]float64, error) {
+	ndim := len(arr.Shape)
+	seen := make([]bool, ndim)
+	for _, ax := range keepAxes {
+		if ax < 0 || ax >= ndim {
+			return nil, fmt.Errorf("entropy: axis %d out of range [0, %d)", ax, ndim)
+		}
+		if seen[ax] {
+			return nil, fmt.Errorf("entropy: axis %d repeated in keepAxes", ax)
+		}
+		seen[ax] = true
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	minSize := opts.MinSize
+	if minSize <= 0 {
+		minSize = DefaultParallelMinSize
+	}
+
+	if workers == 1 || len(arr.Data) < minSize || ndim == 0 || len(keepAxes) == ndim {
+		return marginalize(arr, keepAxes), nil
+	}
+
+	return marginalizeParallel(arr, keepAxes, workers), nil
+}
+
+// marginalizeParallel is the multi-worker implementation of Marginalize.
+func marginalizeParallel(arr *NDArray, keepAxes []int, workers int) []float64 {
+	ndim := len(arr.Shape)
+
+	// Row-major stride of each source axis
+	stride := make([]int, ndim)
+	size := 1
+	for ax := ndim - 1; ax >= 0; ax-- {
+		stride[ax] = size
+		size *= arr.Shape[ax]
+	}
+
+	kept := make([]bool, ndim)
+	marginalSize := 1
+	for _, ax := range keepAxes {
+		kept[ax] = true
+		marginalSize *= arr.Shape[ax]
+	}
+
+	// Flat offsets of the summed axes in row-major order: for a fixed cell
+	// they are increasing, i.e. the order in which the serial loop visits them
+	offsets := []int{0}
+	for ax := 0; ax < ndim; ax++ {
+		if kept[ax] {
+			continue
+		}
+		next := make([]int, 0, len(offsets)*arr.Shape[ax])
+		for _, off := range offsets {
+			for i := 0; i < arr.Shape[ax]; i++ {
+				next = append(next, off+i*stride[ax])
+			}
+		}
+		offsets = next
+	}
+
+	workers = min(workers, marginalSize)
+	chunk := (marginalSize + workers - 1) / workers
+
+	result := make([]float64, marginalSize)
+	var wg sync.WaitGroup
+	for start := 0; start < marginalSize; start += chunk {
+		end := min(start+chunk, marginalSize)
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for cell := start; cell < end; cell++ {
+				// Source offset of the cell's kept indices (last kept axis fastest)
+				rem, base := cell, 0
+				for k := len(keepAxes) - 1; k >= 0; k-- {
+					ax := keepAxes[k]
+					base += (rem % arr.Shape[ax]) * stride[ax]
+					rem /= arr.Shape[ax]
+				}
+
+				sum := 0.0
+				for _, off := range offsets {
+					sum += arr.Data[base+off]
+				}
+				result[cell] = sum
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return result
+}
+
 // flatToMultiIndex converts a flat index to multi-dimensional indices.
 // Uses row-major (C-contiguous) ordering.
 func flatToMultiIndex(shape []int, flatIdx int) []int {
@@ -241,6 +584,19 @@ func MutualInformation(arr *NDArray, set1, set2 []int) float64 {
 	return entropySet1 - conditionalEntropy
 }
 
+// MutualInformationBase computes the mutual information I(X;Y) in units of
+// the given logarithm base, e.g. math.E for nats. See EntropyBase.
+//
+// Returns NaN for a base that is not finite, <= 0 or 1.
+//
+// Example:
+//
+//	// I(X0;X1) in nats
+//	MutualInformationBase(arr, []int{0}, []int{1}, math.E)
+func MutualInformationBase(arr *NDArray, set1, set2 []int, base float64) float64 {
+	return convertBits(MutualInformation(arr, set1, set2), base)
+}
+
 // ConditionalMutualInformation computes the conditional mutual information
 // between two sets of variables given a third set.
 // It measures the information between X and Y when Z is known.
@@ -284,6 +640,66 @@ func ConditionalMutualInformation(arr *NDArray, set1, set2, conditioning []int)
 	return hXgivenZ - hXgivenYZ
 }
 
+// InteractionInformation computes the interaction information (co-information)
+// of three sets of variables: how much knowing Z changes the information
+// between X and Y.
+// The formula is: II(X;Y;Z) = I(X;Y) - I(X;Y|Z)
+//
+// Sign convention: II > 0 means redundancy (Z already carries part of what X
+// and Y share), II < 0 means synergy (X and Y are only informative about each
+// other together with Z). Some texts (e.g. Jakulin & Bratko) define it as
+// I(X;Y|Z) - I(X;Y), with the opposite sign. II is symmetric in X, Y and Z.
+//
+// Parameters:
+//   - arr: N-dimensional joint probability distribution
+//   - x: Axes of the first set of variables (X)
+//   - y: Axes of the second set of variables (Y)
+//   - z: Axes of the third set of variables (Z)
+//
+// Returns:
+//   - Interaction information II(X;Y;Z) in bits (positive: redundancy,
+//     negative: synergy)
+//
+// Example:
+//
+//	// For P(X0, X1, X2) with X0 = X1 XOR X2
+//	// InteractionInformation(arr, []int{0}, []int{1}, []int{2}) = -1 (synergy)
+func InteractionInformation(arr *NDArray, x, y, z []int) float64 {
+	return MutualInformation(arr, x, y) - ConditionalMutualInformation(arr, x, y, z)
+}
+
+// TotalCorrelation computes the total correlation (multi-information) of a
+// set of variables: the information shared among all of them at once.
+// The formula is: TC(X1;...;Xn) = Σ H(Xi) - H(X1,...,Xn)
+//
+// TC is 0 if and only if the variables are mutually independent; for two
+// variables it equals the mutual information I(X1;X2).
+//
+// Parameters:
+//   - arr: N-dimensional joint probability distribution
+//   - axes: Axes of the variables (each axis is one variable)
+//
+// Returns:
+//   - Total correlation in bits (0 for fewer than two axes)
+//
+// Example:
+//
+//	// For P(X0, X1, X2)
+//	// TotalCorrelation(arr, []int{0, 1, 2}) = H(X0) + H(X1) + H(X2) - H(X0,X1,X2)
+func TotalCorrelation(arr *NDArray, axes []int) float64 {
+	if len(axes) < 2 {
+		// TC of a single variable (or none) is 0
+		return 0.0
+	}
+
+	sumMarginals := 0.0
+	for _, ax := range axes {
+		sumMarginals += JointEntropy(arr, []int{ax})
+	}
+
+	return sumMarginals - JointEntropy(arr, axes)
+}
+
 // unionIndices returns the union of two index slices, preserving order.
 func unionIndices(a, b []int) []int {
 	seen := make(map[int]bool)
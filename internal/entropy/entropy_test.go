@@ -75,6 +75,160 @@ func TestEntropy(t *testing.T) {
 	}
 }
 
+// TestRenyiEntropy tests the Rényi entropy and its special orders.
+func TestRenyiEntropy(t *testing.T) {
+	skewed := []float64{0.5, 0.25, 0.25, 0}
+	tests := []struct {
+		name     string
+		prob     []float64
+		alpha    float64
+		expected float64
+	}{
+		{"uniform, alpha=2", []float64{0.25, 0.25, 0.25, 0.25}, 2, 2},
+		{"uniform, alpha=0.5", []float64{0.25, 0.25, 0.25, 0.25}, 0.5, 2},
+		{"hartley ignores zeros", skewed, 0, math.Log2(3)},
+		{"shannon limit", skewed, 1, 1.5},
+		{"near shannon", skewed, 1 + 1e-12, 1.5},
+		{"collision", skewed, 2, -math.Log2(0.375)},
+		{"min-entropy", skewed, math.Inf(1), 1},
+		{"negative probability ignored", []float64{0.5, 0.5, -0.1}, 2, 1},
+		{"certain outcome", []float64{1, 0}, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenyiEntropy(tt.prob, tt.alpha)
+			if math.Abs(result-tt.expected) > 1e-10 {
+				t.Errorf("RenyiEntropy(%v, %v) = %v, want %v", tt.prob, tt.alpha, result, tt.expected)
+			}
+		})
+	}
+
+	// Approaching alpha = 1 from either side converges to Shannon entropy.
+	for _, alpha := range []float64{0.999, 1.001} {
+		if got := RenyiEntropy(skewed, alpha); math.Abs(got-1.5) > 1e-3 {
+			t.Errorf("RenyiEntropy(alpha=%v) = %v, want ~1.5", alpha, got)
+		}
+	}
+
+	// Non-increasing in alpha.
+	prev := math.Inf(1)
+	for _, alpha := range []float64{0, 0.5, 1, 2, 5, math.Inf(1)} {
+		h := RenyiEntropy(skewed, alpha)
+		if h > prev+1e-12 {
+			t.Errorf("RenyiEntropy not monotone: H_%v = %v > %v", alpha, h, prev)
+		}
+		prev = h
+	}
+
+	if !math.IsNaN(RenyiEntropy(skewed, -1)) {
+		t.Error("expected NaN for negative alpha")
+	}
+}
+
+// TestEntropyBase tests entropy in nats, hartleys and other bases.
+func TestEntropyBase(t *testing.T) {
+	p := []float64{0.5, 0.25, 0.25}
+
+	tests := []struct {
+		name     string
+		base     float64
+		expected float64
+	}{
+		{"bits", 2, 1.5},
+		{"nats", math.E, 1.5 * math.Ln2},
+		{"hartleys", 10, 1.5 * math.Log10(2)},
+		{"base 4", 4, 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EntropyBase(p, tt.base); math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("EntropyBase(base=%v) = %v, want %v", tt.base, got, tt.expected)
+			}
+		})
+	}
+
+	if got, want := EntropyBase(p, 2), Entropy(p); got != want {
+		t.Errorf("EntropyBase(base=2) = %v, want Entropy = %v", got, want)
+	}
+
+	for _, base := range []float64{0, -2, 1, math.NaN(), math.Inf(1)} {
+		if got := EntropyBase(p, base); !math.IsNaN(got) {
+			t.Errorf("EntropyBase(base=%v) = %v, want NaN", base, got)
+		}
+	}
+}
+
+// TestKLDivergence tests KL divergence and cross-entropy on known distributions.
+func TestKLDivergence(t *testing.T) {
+	// D_KL([1/2,1/2] || [3/4,1/4]) = 1 - ½ log2 3 ≈ 0.2075
+	kl := 1 - 0.5*math.Log2(3)
+
+	tests := []struct {
+		name      string
+		p, q      []float64
+		wantKL    float64
+		wantCross float64
+	}{
+		{"identical", []float64{0.25, 0.75}, []float64{0.25, 0.75}, 0, Entropy([]float64{0.25, 0.75})},
+		{"fair vs biased", []float64{0.5, 0.5}, []float64{0.75, 0.25}, kl, 1 + kl},
+		{"zero p term", []float64{1, 0}, []float64{0.5, 0.5}, 1, 1},
+		{"zero q where p > 0", []float64{0.5, 0.5}, []float64{1, 0}, math.Inf(1), math.Inf(1)},
+		{"empty", []float64{}, []float64{}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KLDivergence(tt.p, tt.q); got != tt.wantKL && math.Abs(got-tt.wantKL) > 1e-10 {
+				t.Errorf("KLDivergence(%v, %v) = %v, want %v", tt.p, tt.q, got, tt.wantKL)
+			}
+			if got := CrossEntropy(tt.p, tt.q); got != tt.wantCross && math.Abs(got-tt.wantCross) > 1e-10 {
+				t.Errorf("CrossEntropy(%v, %v) = %v, want %v", tt.p, tt.q, got, tt.wantCross)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for length mismatch")
+		}
+	}()
+	KLDivergence([]float64{0.5, 0.5}, []float64{1})
+}
+
+func TestJensenShannonDivergence(t *testing.T) {
+	tests := []struct {
+		name string
+		p, q []float64
+		want float64
+	}{
+		{"identical", []float64{0.25, 0.75}, []float64{0.25, 0.75}, 0},
+		{"disjoint", []float64{1, 0}, []float64{0, 1}, 1},
+		{"certain vs fair", []float64{1, 0}, []float64{0.5, 0.5}, 0.3112781244591328},
+		{"empty", []float64{}, []float64{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JensenShannonDivergence(tt.p, tt.q)
+			if math.Abs(got-tt.want) > 1e-10 {
+				t.Errorf("JensenShannonDivergence(%v, %v) = %v, want %v", tt.p, tt.q, got, tt.want)
+			}
+			if rev := JensenShannonDivergence(tt.q, tt.p); math.Abs(rev-got) > 1e-15 {
+				t.Errorf("not symmetric: %v vs %v", got, rev)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for length mismatch")
+		}
+	}()
+	JensenShannonDivergence([]float64{0.5, 0.5}, []float64{1})
+}
+
 func BenchmarkEntropy(b *testing.B) {
 	p := []float64{0.1, 0.2, 0.3, 0.15, 0.25}
 	b.ResetTimer()
@@ -223,6 +377,86 @@ func TestMarginalize(t *testing.T) {
 	}
 }
 
+// largeTestArray returns a normalized 6-variable × 10-bin array (1e6 elements).
+func largeTestArray() *NDArray {
+	shape := []int{10, 10, 10, 10, 10, 10}
+	data := make([]float64, 1_000_000)
+	total := 0.0
+	for i := range data {
+		data[i] = float64((i*7919)%1000 + 1)
+		total += data[i]
+	}
+	for i := range data {
+		data[i] /= total
+	}
+	return &NDArray{Data: data, Shape: shape}
+}
+
+func TestMarginalize_ParallelMatchesSerial(t *testing.T) {
+	arr := largeTestArray()
+
+	for _, keepAxes := range [][]int{{0}, {5}, {0, 3}, {4, 1}, {0, 2, 4}, {1, 2, 3, 4, 5}} {
+		serial := marginalize(arr, keepAxes)
+		for _, workers := range []int{1, 2, 3, 8} {
+			parallel := marginalizeParallel(arr, keepAxes, workers)
+			if len(parallel) != len(serial) {
+				t.Fatalf("axes %v, %d workers: length %d, want %d", keepAxes, workers, len(parallel), len(serial))
+			}
+			// Bit-identical: every cell is summed in the serial order
+			for i := range serial {
+				if parallel[i] != serial[i] {
+					t.Errorf("axes %v, %d workers: cell %d = %v, want %v", keepAxes, workers, i, parallel[i], serial[i])
+					break
+				}
+			}
+		}
+	}
+
+	// Small arrays fall back to the serial loop
+	small := &NDArray{
+		Data:  []float64{0.1, 0.2, 0.3, 0.15, 0.15, 0.1},
+		Shape: []int{2, 3},
+	}
+	got, err := Marginalize(small, []int{1}, DefaultMarginalizeOptions())
+	if err != nil {
+		t.Fatalf("Marginalize failed: %v", err)
+	}
+	want := marginalize(small, []int{1})
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("small array: Marginalize() = %v, want %v", got, want)
+			break
+		}
+	}
+
+	// Invalid axes are rejected before the pool starts, not inside a worker
+	opts := MarginalizeOptions{Workers: 4, MinSize: 1}
+	for _, keepAxes := range [][]int{{6}, {-1}, {0, 0}} {
+		if _, err := Marginalize(arr, keepAxes, opts); err == nil {
+			t.Errorf("Marginalize(%v) expected error", keepAxes)
+		}
+	}
+}
+
+func BenchmarkMarginalize_Serial(b *testing.B) {
+	arr := largeTestArray()
+	keepAxes := []int{0, 3}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = marginalize(arr, keepAxes)
+	}
+}
+
+func BenchmarkMarginalize_Parallel(b *testing.B) {
+	arr := largeTestArray()
+	keepAxes := []int{0, 3}
+	opts := DefaultMarginalizeOptions()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Marginalize(arr, keepAxes, opts)
+	}
+}
+
 func TestJointEntropy(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -474,6 +708,53 @@ func TestMutualInformation(t *testing.T) {
 	}
 }
 
+// TestTotalCorrelation tests total correlation on independent, copied and XOR variables.
+func TestTotalCorrelation(t *testing.T) {
+	// X2 = X0 XOR X1 with uniform X0, X1: pairwise independent, jointly dependent
+	xor := &NDArray{
+		Data:  []float64{0.25, 0, 0, 0.25, 0, 0.25, 0.25, 0},
+		Shape: []int{2, 2, 2},
+	}
+
+	tests := []struct {
+		name     string
+		arr      *NDArray
+		axes     []int
+		expected float64
+	}{
+		{"independent", &NDArray{Data: []float64{0.25, 0.25, 0.25, 0.25}, Shape: []int{2, 2}}, []int{0, 1}, 0},
+		{"copy equals MI", &NDArray{Data: []float64{0.5, 0, 0, 0.5}, Shape: []int{2, 2}}, []int{0, 1}, 1},
+		{"XOR triple", xor, []int{0, 1, 2}, 1},
+		{"XOR pair", xor, []int{0, 2}, 0},
+		{"single axis", xor, []int{1}, 0},
+		{"empty axes", xor, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TotalCorrelation(tt.arr, tt.axes); math.Abs(got-tt.expected) > 1e-10 {
+				t.Errorf("TotalCorrelation(axes=%v) = %v, want %v", tt.axes, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMutualInformationBase tests mutual information in bases other than 2.
+func TestMutualInformationBase(t *testing.T) {
+	// X = Y uniform binary: I(X;Y) = 1 bit = ln 2 nats
+	arr := &NDArray{Data: []float64{0.5, 0, 0, 0.5}, Shape: []int{2, 2}}
+
+	if got := MutualInformationBase(arr, []int{0}, []int{1}, math.E); math.Abs(got-math.Ln2) > 1e-12 {
+		t.Errorf("MutualInformationBase(nats) = %v, want %v", got, math.Ln2)
+	}
+	if got := MutualInformationBase(arr, []int{0}, []int{1}, 2); got != MutualInformation(arr, []int{0}, []int{1}) {
+		t.Errorf("MutualInformationBase(bits) = %v, want MutualInformation", got)
+	}
+	if got := MutualInformationBase(arr, []int{0}, []int{1}, 1); !math.IsNaN(got) {
+		t.Errorf("MutualInformationBase(base=1) = %v, want NaN", got)
+	}
+}
+
 func TestConditionalMutualInformation(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -546,6 +827,45 @@ func TestConditionalMutualInformation(t *testing.T) {
 	}
 }
 
+func TestInteractionInformation(t *testing.T) {
+	// X0 = X1 XOR X2 with uniform X1, X2: pure synergy
+	xor := &NDArray{
+		Data:  []float64{0.25, 0, 0, 0.25, 0, 0.25, 0.25, 0},
+		Shape: []int{2, 2, 2},
+	}
+	// X0 = X1 = X2: pure redundancy
+	duplicated := &NDArray{
+		Data:  []float64{0.5, 0, 0, 0, 0, 0, 0, 0.5},
+		Shape: []int{2, 2, 2},
+	}
+	independent := &NDArray{
+		Data:  []float64{0.125, 0.125, 0.125, 0.125, 0.125, 0.125, 0.125, 0.125},
+		Shape: []int{2, 2, 2},
+	}
+
+	tests := []struct {
+		name     string
+		arr      *NDArray
+		x, y, z  []int
+		expected float64
+	}{
+		{"XOR synergy", xor, []int{0}, []int{1}, []int{2}, -1},
+		{"XOR permuted", xor, []int{2}, []int{0}, []int{1}, -1},
+		{"duplicated redundancy", duplicated, []int{0}, []int{1}, []int{2}, 1},
+		{"duplicated permuted", duplicated, []int{1}, []int{2}, []int{0}, 1},
+		{"independent", independent, []int{0}, []int{1}, []int{2}, 0},
+		{"empty z", duplicated, []int{0}, []int{1}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InteractionInformation(tt.arr, tt.x, tt.y, tt.z); math.Abs(got-tt.expected) > 1e-10 {
+				t.Errorf("InteractionInformation(%v, %v, %v) = %v, want %v", tt.x, tt.y, tt.z, got, tt.expected)
+			}
+		})
+	}
+}
+
 func BenchmarkMutualInformation(b *testing.B) {
 	arr := &NDArray{
 		Data:  []float64{0.1, 0.15, 0.2, 0.05, 0.1, 0.15, 0.15, 0.1},
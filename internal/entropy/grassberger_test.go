@@ -0,0 +1,59 @@
+package entropy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEntropyGrassberger_LessBiasedThanPlugin(t *testing.T) {
+	// Uniform over 8 states, 12 samples per trial: H = 3 bits
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+	const (
+		states = 8
+		n      = 12
+		trials = 2000
+	)
+
+	var plugin, corrected float64
+	for trial := 0; trial < trials; trial++ {
+		counts := make([]float64, states)
+		for i := 0; i < n; i++ {
+			counts[rng.Intn(states)]++
+		}
+		p := make([]float64, states)
+		for i, c := range counts {
+			p[i] = c / n
+		}
+		plugin += Entropy(p)
+		corrected += EntropyGrassberger(counts, n)
+	}
+	plugin /= trials
+	corrected /= trials
+
+	t.Logf("true 3.000, plug-in %.3f, Grassberger %.3f", plugin, corrected)
+	if math.Abs(corrected-3) >= math.Abs(plugin-3) {
+		t.Errorf("Grassberger bias %.3f not smaller than plug-in bias %.3f", corrected-3, plugin-3)
+	}
+	if math.Abs(corrected-3) > 0.2 {
+		t.Errorf("Grassberger mean %.3f too far from 3 bits", corrected)
+	}
+}
+
+func TestEntropyGrassberger_EdgeCases(t *testing.T) {
+	if h := EntropyGrassberger([]float64{1, 2}, 0); h != 0 {
+		t.Errorf("nSamples = 0: got %v, want 0", h)
+	}
+
+	// One occupied bin of N samples: G(N) ≈ ln N for even N, so H ≈ 0
+	if h := EntropyGrassberger([]float64{1000, 0}, 1000); math.Abs(h) > 0.01 {
+		t.Errorf("single bin: got %v, want ~0", h)
+	}
+
+	// Large samples agree with plug-in
+	counts := []float64{5000, 3000, 2000}
+	plugin := Entropy([]float64{0.5, 0.3, 0.2})
+	if h := EntropyGrassberger(counts, 10000); math.Abs(h-plugin) > 0.001 {
+		t.Errorf("large N: got %.4f, plug-in %.4f", h, plugin)
+	}
+}
@@ -0,0 +1,87 @@
+package entropy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestKNNDifferentialEntropy(t *testing.T) {
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic test data
+	n := 3000
+
+	normal := make([][]float64, n)
+	uniform := make([][]float64, n)
+	normal2D := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		normal[i] = []float64{rng.NormFloat64()}
+		uniform[i] = []float64{4 * rng.Float64()}
+		normal2D[i] = []float64{rng.NormFloat64(), 2 * rng.NormFloat64()}
+	}
+
+	tests := []struct {
+		name      string
+		data      [][]float64
+		expected  float64
+		tolerance float64
+	}{
+		{"standard normal", normal, 0.5 * math.Log2(2*math.Pi*math.E), 0.05},
+		{"uniform [0, 4]", uniform, 2, 0.05},
+		// Independent N(0,1) and N(0,4): h = log2(2πe) + log2(2).
+		// The finite-sample bias grows with the dimension.
+		{"2D normal", normal2D, math.Log2(2*math.Pi*math.E) + 1, 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := KNNDifferentialEntropy(tt.data, 3)
+			t.Logf("h = %.4f bits, analytic %.4f", got, tt.expected)
+			if math.Abs(got-tt.expected) > tt.tolerance {
+				t.Errorf("KNNDifferentialEntropy = %v, want %v ± %v", got, tt.expected, tt.tolerance)
+			}
+		})
+	}
+
+	// Small samples use the brute-force backend, which must find the same
+	// neighbors as the kd-tree used above.
+	small := normal[:ksgBruteForceMaxN]
+	brute, tree := NewBruteForceSearcher(small), NewKDTreeSearcher(small)
+	for i := range small {
+		if b, kd := brute.KthNeighborDistance(i, 3), tree.KthNeighborDistance(i, 3); b != kd {
+			t.Fatalf("point %d: brute-force distance %v, kd-tree %v", i, b, kd)
+		}
+	}
+
+	// The per-sample terms of the estimator have variance ≈ ψ'(k) + Var[ln p(X)],
+	// with ψ'(3) = π²/6 - 1 - 1/4 ≈ 0.395 and Var[ln p(X)] = 1/2 nats² for a
+	// normal, so the standard error at N = 1000 is ≈ 0.043 bits; allow 3.
+	trigamma3 := math.Pi*math.Pi/6 - 1.25
+	stdErr := math.Sqrt((trigamma3+0.5)/float64(len(small))) / math.Ln2
+	want := 0.5 * math.Log2(2*math.Pi*math.E)
+	if got := KNNDifferentialEntropy(small, 3); math.Abs(got-want) > 3*stdErr {
+		t.Errorf("small sample: got %v, want %v ± %.3f (3 standard errors)", got, want, 3*stdErr)
+	}
+}
+
+func TestKNNDifferentialEntropy_EdgeCases(t *testing.T) {
+	points := [][]float64{{0}, {1}, {2}, {3}}
+
+	for _, tt := range []struct {
+		name string
+		data [][]float64
+		k    int
+	}{
+		{"k zero", points, 0},
+		{"k too large", points, 4},
+		{"empty", nil, 1},
+		{"ragged", [][]float64{{0}, {1, 2}, {3}}, 1},
+	} {
+		if got := KNNDifferentialEntropy(tt.data, tt.k); !math.IsNaN(got) {
+			t.Errorf("%s: got %v, want NaN", tt.name, got)
+		}
+	}
+
+	if got := KNNDifferentialEntropy([][]float64{{0}, {0}, {1}}, 1); !math.IsInf(got, -1) {
+		t.Errorf("duplicate samples: got %v, want -Inf", got)
+	}
+}
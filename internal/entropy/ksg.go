@@ -0,0 +1,252 @@
+package entropy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/spatial/kdtree"
+)
+
+// ksgBruteForceMaxN is the largest sample size for which KSGMutualInformation
+// uses brute-force neighbor search by default; larger inputs use a kd-tree.
+const ksgBruteForceMaxN = 1000
+
+// NeighborSearcher answers neighbor queries in the Chebyshev (max-norm) metric
+// over a fixed set of points, identified by their index.
+type NeighborSearcher interface {
+	// KthNeighborDistance returns the Chebyshev distance from point i to its
+	// k-th nearest other point (k >= 1).
+	KthNeighborDistance(i, k int) float64
+
+	// CountWithin returns the number of points other than i whose Chebyshev
+	// distance to point i is strictly less than r.
+	CountWithin(i int, r float64) int
+}
+
+// NeighborSearcherFactory builds a NeighborSearcher over points [samples x dims].
+type NeighborSearcherFactory func(points [][]float64) NeighborSearcher
+
+// KSGMutualInformation estimates I(X; Y) in bits for continuous variables with
+// the Kraskov–Stögbauer–Grassberger estimator (algorithm 1):
+//
+//	I = ψ(k) + ψ(N) - <ψ(n_x + 1) + ψ(n_y + 1)>
+//
+// where ε_i is the Chebyshev distance from sample i to its k-th neighbor in the
+// joint (X, Y) space and n_x, n_y count samples strictly within ε_i in each
+// marginal space.
+//
+// Parameters:
+//   - x, y: samples [samples x dims]; rows must align
+//   - k: number of neighbors (typically 3-10)
+//   - newSearcher: neighbor search backend, or nil for the default
+//     (brute force for up to 1000 samples, kd-tree above)
+//
+// The estimate can be slightly negative for independent variables.
+//
+// Example:
+//
+//	mi, err := KSGMutualInformation(x, y, 3, nil)
+//	mi, err = KSGMutualInformation(x, y, 3, NewBruteForceSearcher) // force a backend
+func KSGMutualInformation(x, y [][]float64, k int, newSearcher NeighborSearcherFactory) (float64, error) {
+	n := len(x)
+	if len(y) != n {
+		return 0, fmt.Errorf("x and y have different lengths: %d vs %d", n, len(y))
+	}
+	if k < 1 {
+		return 0, fmt.Errorf("k must be positive, got %d", k)
+	}
+	if n <= k {
+		return 0, fmt.Errorf("need more than k=%d samples, got %d", k, n)
+	}
+	if err := checkPoints(x, "x"); err != nil {
+		return 0, err
+	}
+	if err := checkPoints(y, "y"); err != nil {
+		return 0, err
+	}
+
+	if newSearcher == nil {
+		newSearcher = defaultSearcher(n)
+	}
+
+	joint := make([][]float64, n)
+	for i := range joint {
+		joint[i] = append(append(make([]float64, 0, len(x[i])+len(y[i])), x[i]...), y[i]...)
+	}
+
+	jointSearch := newSearcher(joint)
+	xSearch := newSearcher(x)
+	ySearch := newSearcher(y)
+
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		eps := jointSearch.KthNeighborDistance(i, k)
+		nx := xSearch.CountWithin(i, eps)
+		ny := ySearch.CountWithin(i, eps)
+		sum += mathext.Digamma(float64(nx+1)) + mathext.Digamma(float64(ny+1))
+	}
+
+	nats := mathext.Digamma(float64(k)) + mathext.Digamma(float64(n)) - sum/float64(n)
+	return nats / math.Ln2, nil
+}
+
+// defaultSearcher returns the neighbor search backend for n samples:
+// brute force up to ksgBruteForceMaxN samples, a kd-tree above.
+func defaultSearcher(n int) NeighborSearcherFactory {
+	if n <= ksgBruteForceMaxN {
+		return NewBruteForceSearcher
+	}
+	return NewKDTreeSearcher
+}
+
+// checkPoints validates that all samples have the same positive dimension.
+func checkPoints(points [][]float64, name string) error {
+	dims := len(points[0])
+	if dims == 0 {
+		return fmt.Errorf("%s has zero dimensions", name)
+	}
+	for i, p := range points {
+		if len(p) != dims {
+			return fmt.Errorf("%s sample %d has %d dimensions, expected %d", name, i, len(p), dims)
+		}
+	}
+	return nil
+}
+
+// chebyshev returns the max-norm distance between a and b.
+func chebyshev(a, b []float64) float64 {
+	d := 0.0
+	for i := range a {
+		d = math.Max(d, math.Abs(a[i]-b[i]))
+	}
+	return d
+}
+
+// bruteForceSearcher scans all points for every query: O(n) memory, O(n log n) per query.
+type bruteForceSearcher struct {
+	points [][]float64
+}
+
+// NewBruteForceSearcher returns a NeighborSearcher that scans all points.
+// It has no build cost and is fastest for small samples.
+func NewBruteForceSearcher(points [][]float64) NeighborSearcher {
+	return &bruteForceSearcher{points: points}
+}
+
+func (s *bruteForceSearcher) KthNeighborDistance(i, k int) float64 {
+	dists := make([]float64, 0, len(s.points)-1)
+	for j, p := range s.points {
+		if j != i {
+			dists = append(dists, chebyshev(s.points[i], p))
+		}
+	}
+	sort.Float64s(dists)
+	return dists[k-1]
+}
+
+func (s *bruteForceSearcher) CountWithin(i int, r float64) int {
+	count := 0
+	for j, p := range s.points {
+		if j != i && chebyshev(s.points[i], p) < r {
+			count++
+		}
+	}
+	return count
+}
+
+// kdTreeSearcher answers queries with a gonum kd-tree.
+type kdTreeSearcher struct {
+	points []chebPoint
+	tree   *kdtree.Tree
+}
+
+// NewKDTreeSearcher returns a NeighborSearcher backed by a gonum/spatial kd-tree.
+// Queries are O(log n) on average, which pays off for large samples.
+func NewKDTreeSearcher(points [][]float64) NeighborSearcher {
+	pts := make(chebPoints, len(points))
+	for i, p := range points {
+		pts[i] = chebPoint{coords: p, idx: i}
+	}
+	// The tree reorders its input; keep the original order for lookups
+	byIndex := append([]chebPoint(nil), pts...)
+	return &kdTreeSearcher{points: byIndex, tree: kdtree.New(pts, false)}
+}
+
+func (s *kdTreeSearcher) KthNeighborDistance(i, k int) float64 {
+	// k+1 nearest including the query point itself
+	keeper := kdtree.NewNKeeper(k + 1)
+	s.tree.NearestSet(keeper, s.points[i])
+
+	dists := make([]float64, 0, k+1)
+	selfSeen := false
+	for _, c := range keeper.Heap {
+		if !selfSeen && c.Comparable.(chebPoint).idx == i {
+			selfSeen = true
+			continue
+		}
+		dists = append(dists, c.Dist)
+	}
+	sort.Float64s(dists)
+	return math.Sqrt(dists[k-1])
+}
+
+func (s *kdTreeSearcher) CountWithin(i int, r float64) int {
+	keeper := kdtree.NewDistKeeper(r * r)
+	s.tree.NearestSet(keeper, s.points[i])
+
+	count := 0
+	for _, c := range keeper.Heap {
+		// DistKeeper keeps points at distance <= r; KSG needs strictly < r
+		if c.Comparable.(chebPoint).idx != i && c.Dist < r*r {
+			count++
+		}
+	}
+	return count
+}
+
+// chebPoint is a kd-tree point with the squared Chebyshev distance.
+// Squared distances keep the tree's pruning test (plane offset² <= distance) valid.
+type chebPoint struct {
+	coords []float64
+	idx    int
+}
+
+func (p chebPoint) Compare(c kdtree.Comparable, d kdtree.Dim) float64 {
+	return p.coords[d] - c.(chebPoint).coords[d]
+}
+
+func (p chebPoint) Dims() int { return len(p.coords) }
+
+func (p chebPoint) Distance(c kdtree.Comparable) float64 {
+	d := chebyshev(p.coords, c.(chebPoint).coords)
+	return d * d
+}
+
+// chebPoints implements kdtree.Interface.
+type chebPoints []chebPoint
+
+func (p chebPoints) Index(i int) kdtree.Comparable         { return p[i] }
+func (p chebPoints) Len() int                              { return len(p) }
+func (p chebPoints) Slice(start, end int) kdtree.Interface { return p[start:end] }
+func (p chebPoints) Pivot(d kdtree.Dim) int {
+	plane := chebPlane{points: p, dim: d}
+	return kdtree.Partition(plane, kdtree.MedianOfRandoms(plane, 100))
+}
+
+// chebPlane sorts chebPoints along one dimension for pivoting.
+type chebPlane struct {
+	points chebPoints
+	dim    kdtree.Dim
+}
+
+func (p chebPlane) Len() int { return len(p.points) }
+func (p chebPlane) Less(i, j int) bool {
+	return p.points[i].coords[p.dim] < p.points[j].coords[p.dim]
+}
+func (p chebPlane) Swap(i, j int) { p.points[i], p.points[j] = p.points[j], p.points[i] }
+func (p chebPlane) Slice(start, end int) kdtree.SortSlicer {
+	p.points = p.points[start:end]
+	return p
+}
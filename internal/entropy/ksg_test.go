@@ -0,0 +1,83 @@
+package entropy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// correlatedGaussian returns n samples of (x, y) with correlation rho.
+func correlatedGaussian(n int, rho float64, seed int64) (x, y [][]float64) {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	x = make([][]float64, n)
+	y = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		a := rng.NormFloat64()
+		b := rho*a + math.Sqrt(1-rho*rho)*rng.NormFloat64()
+		x[i] = []float64{a}
+		y[i] = []float64{b}
+	}
+	return x, y
+}
+
+func TestKSGMutualInformation_Backends(t *testing.T) {
+	rho := 0.6
+	x, y := correlatedGaussian(2000, rho, 42)
+	want := -0.5 * math.Log2(1-rho*rho) // analytic MI for a bivariate Gaussian
+
+	brute, err := KSGMutualInformation(x, y, 3, NewBruteForceSearcher)
+	if err != nil {
+		t.Fatalf("brute force: %v", err)
+	}
+	tree, err := KSGMutualInformation(x, y, 3, NewKDTreeSearcher)
+	if err != nil {
+		t.Fatalf("kd-tree: %v", err)
+	}
+	auto, err := KSGMutualInformation(x, y, 3, nil)
+	if err != nil {
+		t.Fatalf("default: %v", err)
+	}
+
+	t.Logf("KSG MI: brute=%.6f kdtree=%.6f default=%.6f analytic=%.6f", brute, tree, auto, want)
+
+	if brute != tree || auto != tree {
+		t.Errorf("backends disagree: brute=%.12f kdtree=%.12f default=%.12f", brute, tree, auto)
+	}
+	if math.Abs(brute-want) > 0.05 {
+		t.Errorf("KSG MI = %.4f, want %.4f ± 0.05", brute, want)
+	}
+}
+
+func TestKSGMutualInformation_Independent(t *testing.T) {
+	x, y := correlatedGaussian(1000, 0, 7)
+	mi, err := KSGMutualInformation(x, y, 5, nil)
+	if err != nil {
+		t.Fatalf("KSGMutualInformation failed: %v", err)
+	}
+	if math.Abs(mi) > 0.03 {
+		t.Errorf("independent variables: MI = %.4f, want ~0", mi)
+	}
+}
+
+func TestKSGMutualInformation_ErrorCases(t *testing.T) {
+	x, y := correlatedGaussian(10, 0.5, 1)
+
+	tests := []struct {
+		name string
+		x, y [][]float64
+		k    int
+	}{
+		{"length mismatch", x, y[:5], 3},
+		{"zero k", x, y, 0},
+		{"k too large", x, y, 10},
+		{"ragged x", append([][]float64{{1, 2}}, x[1:]...), y, 3},
+		{"zero dims", make([][]float64, 10), y, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := KSGMutualInformation(tt.x, tt.y, tt.k, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
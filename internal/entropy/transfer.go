@@ -0,0 +1,52 @@
+package entropy
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// TransferEntropy estimates the directed information flow from source X to
+// target Y in bits:
+//
+//	TE(X→Y) = I(Y_{t+lag} ; X_t | Y_t)
+//
+// Both series are discretized into bins equal-width bins over their own range
+// and the joint distribution of (Y_{t+lag}, Y_t, X_t) is estimated from the
+// len-lag aligned triples.
+//
+// Parameters:
+//   - source, target: time series of equal length
+//   - bins: number of bins per variable (>= 1)
+//   - lag: prediction horizon in samples (>= 1)
+//
+// Example:
+//
+//	te, err := TransferEntropy(x, y, 8, 1) // how much x_t tells about y_{t+1} beyond y_t
+func TransferEntropy(source, target []float64, bins, lag int) (float64, error) {
+	if len(source) != len(target) {
+		return 0, fmt.Errorf("source and target have different lengths: %d vs %d", len(source), len(target))
+	}
+	if lag < 1 {
+		return 0, fmt.Errorf("lag must be positive, got %d", lag)
+	}
+	if bins < 1 {
+		return 0, fmt.Errorf("bins must be positive, got %d", bins)
+	}
+	n := len(target) - lag
+	if n < 1 {
+		return 0, fmt.Errorf("series of length %d too short for lag %d", len(target), lag)
+	}
+
+	data := make([][]float64, n)
+	for t := range n {
+		data[t] = []float64{target[t+lag], target[t], source[t]}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{bins, bins, bins})
+	if err != nil {
+		return 0, fmt.Errorf("failed to build histogram: %w", err)
+	}
+
+	arr := &NDArray{Data: hist.Probabilities(), Shape: hist.Shape()}
+	return ConditionalMutualInformation(arr, []int{0}, []int{2}, []int{1}), nil
+}
@@ -0,0 +1,68 @@
+package entropy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTransferEntropy_Directed(t *testing.T) {
+	// y copies x with a one-step delay, so x drives y and not the reverse.
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+	n := 20000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = float64(rng.Intn(2))
+		if i > 0 {
+			y[i] = x[i-1]
+		}
+	}
+
+	forward, err := TransferEntropy(x, y, 2, 1)
+	if err != nil {
+		t.Fatalf("TransferEntropy failed: %v", err)
+	}
+	if math.Abs(forward-1) > 0.01 {
+		t.Errorf("TE(x→y) = %.4f, want ~1 bit", forward)
+	}
+
+	backward, err := TransferEntropy(y, x, 2, 1)
+	if err != nil {
+		t.Fatalf("TransferEntropy failed: %v", err)
+	}
+	if backward > 0.01 {
+		t.Errorf("TE(y→x) = %.4f, want ~0", backward)
+	}
+
+	// At lag 2 x_t no longer determines y_{t+2}.
+	lagged, err := TransferEntropy(x, y, 2, 2)
+	if err != nil {
+		t.Fatalf("TransferEntropy failed: %v", err)
+	}
+	if lagged > 0.01 {
+		t.Errorf("TE(x→y, lag 2) = %.4f, want ~0", lagged)
+	}
+}
+
+func TestTransferEntropy_Errors(t *testing.T) {
+	x := []float64{1, 2, 3, 4}
+	tests := []struct {
+		name           string
+		source, target []float64
+		bins, lag      int
+	}{
+		{"length mismatch", x, x[:3], 2, 1},
+		{"zero lag", x, x, 2, 0},
+		{"negative lag", x, x, 2, -1},
+		{"zero bins", x, x, 0, 1},
+		{"lag too long", x, x, 2, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TransferEntropy(tt.source, tt.target, tt.bins, tt.lag); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package histogram
+
+import (
+	"math"
+	"sort"
+)
+
+// SuggestBins returns a default number of bins for one variable.
+//
+// The Freedman–Diaconis rule (width = 2·IQR / n^(1/3)) is used when the
+// interquartile range is positive, since it adapts to heavy tails; otherwise
+// Sturges' rule (⌈log2 n⌉ + 1) is used. The result never exceeds the number of
+// distinct values, so discrete variables get one bin per level (a binary
+// variable gets 2 bins). Non-finite values are ignored; returns 1 if no finite
+// value remains.
+//
+// Example:
+//
+//	bins := []int{SuggestBins(target), SuggestBins(source)}
+func SuggestBins(values []float64) int {
+	finite := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			finite = append(finite, v)
+		}
+	}
+	n := len(finite)
+	if n == 0 {
+		return minBins
+	}
+	sort.Float64s(finite)
+
+	distinct := 1
+	for i := 1; i < n; i++ {
+		if finite[i] != finite[i-1] {
+			distinct++
+		}
+	}
+
+	bins := math.Ceil(math.Log2(float64(n))) + 1
+	if iqr := sortedQuantile(finite, 0.75) - sortedQuantile(finite, 0.25); iqr > 0 {
+		width := 2 * iqr / math.Cbrt(float64(n))
+		bins = math.Ceil((finite[n-1] - finite[0]) / width)
+	}
+
+	bins = math.Min(bins, float64(distinct))
+	return int(math.Max(minBins, math.Min(maxBins, bins)))
+}
+
+// sortedQuantile returns the p-quantile of sorted values with linear interpolation.
+func sortedQuantile(sorted []float64, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
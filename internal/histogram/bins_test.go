@@ -0,0 +1,42 @@
+package histogram
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSuggestBins(t *testing.T) {
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+
+	binary := make([]float64, 1000)
+	for i := range binary {
+		binary[i] = float64(rng.Intn(2))
+	}
+	if got := SuggestBins(binary); got != 2 {
+		t.Errorf("binary: got %d bins, want 2", got)
+	}
+
+	// Uniform on [0, 1): FD gives range / (2·0.5/n^(1/3)) = n^(1/3) = 10 for n = 1000
+	uniform := make([]float64, 1000)
+	for i := range uniform {
+		uniform[i] = rng.Float64()
+	}
+	if got := SuggestBins(uniform); got < 8 || got > 12 {
+		t.Errorf("uniform: got %d bins, want ~10", got)
+	}
+
+	// Zero IQR (mostly constant): Sturges, capped by the 3 distinct values
+	spiky := make([]float64, 100)
+	spiky[0], spiky[1] = -5, 5
+	if got := SuggestBins(spiky); got != 3 {
+		t.Errorf("spiky: got %d bins, want 3", got)
+	}
+
+	if got := SuggestBins([]float64{math.NaN(), math.Inf(1)}); got != 1 {
+		t.Errorf("non-finite: got %d bins, want 1", got)
+	}
+	if got := SuggestBins(nil); got != 1 {
+		t.Errorf("empty: got %d bins, want 1", got)
+	}
+}
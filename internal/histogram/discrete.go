@@ -0,0 +1,59 @@
+package histogram
+
+import "fmt"
+
+// NewNDHistogramDiscrete constructs an N-dimensional histogram from data that
+// is already discrete, e.g. integer category codes or 0/1 signals.
+//
+// Each value of column j is used directly as the bin index in [0, levels[j]),
+// so no range or bin edges are computed and no value can land in a
+// neighbouring bin. Counts are smoothed and normalized as in NewNDHistogram.
+//
+// Parameters:
+//   - data: Sample matrix [samples x variables] of category indices
+//   - levels: Number of categories of each variable
+//
+// Returns an error if a value is outside [0, levels[j]).
+//
+// Example:
+//
+//	// XOR: target = a ^ b
+//	data := [][]int{{0, 0, 0}, {1, 0, 1}, {1, 1, 0}, {0, 1, 1}}
+//	hist, err := NewNDHistogramDiscrete(data, []int{2, 2, 2})
+func NewNDHistogramDiscrete(data [][]int, levels []int) (*NDHistogram, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data cannot be empty")
+	}
+	nVars := len(data[0])
+	if nVars == 0 {
+		return nil, fmt.Errorf("data must have at least one variable")
+	}
+	if len(levels) != nVars {
+		return nil, fmt.Errorf("levels length (%d) must match number of variables (%d)", len(levels), nVars)
+	}
+	if err := validateBins(levels); err != nil {
+		return nil, err
+	}
+
+	totalBins := 1
+	for _, l := range levels {
+		totalBins *= l
+	}
+	counts := make([]float64, totalBins)
+
+	for i, sample := range data {
+		if len(sample) != nVars {
+			return nil, fmt.Errorf("sample %d has length %d, expected %d", i, len(sample), nVars)
+		}
+		for j, v := range sample {
+			if v < 0 || v >= levels[j] {
+				return nil, fmt.Errorf("sample %d: variable %d value %d outside [0, %d)", i, j, v, levels[j])
+			}
+		}
+		counts[multiToFlatIndex(levels, sample)]++
+	}
+
+	bins := make([]int, nVars)
+	copy(bins, levels)
+	return normalizeCounts(counts, bins, smoothingFactor)
+}
@@ -0,0 +1,75 @@
+package histogram
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewNDHistogramDiscrete(t *testing.T) {
+	// Every value used, so equal-width binning gives the same cells
+	data := [][]int{{0, 0}, {1, 2}, {2, 1}, {2, 2}, {0, 2}, {1, 2}}
+	floatData := make([][]float64, len(data))
+	for i, s := range data {
+		floatData[i] = []float64{float64(s[0]), float64(s[1])}
+	}
+
+	got, err := NewNDHistogramDiscrete(data, []int{3, 3})
+	if err != nil {
+		t.Fatalf("NewNDHistogramDiscrete failed: %v", err)
+	}
+	want, err := NewNDHistogram(floatData, []int{3, 3})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	for i, p := range want.Probabilities() {
+		if math.Abs(got.Probabilities()[i]-p) > 1e-15 {
+			t.Errorf("cell %d: got %v, want %v", i, got.Probabilities()[i], p)
+		}
+	}
+	if s := got.Shape(); s[0] != 3 || s[1] != 3 {
+		t.Errorf("expected shape [3 3], got %v", s)
+	}
+}
+
+func TestNewNDHistogramDiscrete_UnusedLevels(t *testing.T) {
+	// Codes 1 and 2 of three levels: min/max binning would stretch them over
+	// bins 0 and 2, the discrete path keeps them in cells 1 and 2.
+	data := [][]int{{1}, {2}, {2}, {2}}
+	hist, err := NewNDHistogramDiscrete(data, []int{3})
+	if err != nil {
+		t.Fatalf("NewNDHistogramDiscrete failed: %v", err)
+	}
+	probs := hist.Probabilities()
+	want := []float64{0, 0.25, 0.75}
+	for i := range want {
+		if math.Abs(probs[i]-want[i]) > 1e-12 {
+			t.Errorf("cell %d: got %v, want %v", i, probs[i], want[i])
+		}
+	}
+}
+
+func TestNewNDHistogramDiscrete_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   [][]int
+		levels []int
+		want   string
+	}{
+		{"empty data", nil, []int{2}, "empty"},
+		{"no variables", [][]int{{}}, []int{}, "at least one variable"},
+		{"levels length", [][]int{{0, 1}}, []int{2}, "levels length"},
+		{"zero levels", [][]int{{0}}, []int{0}, "less than minimum"},
+		{"ragged", [][]int{{0, 1}, {0}}, []int{2, 2}, "sample 1 has length 1"},
+		{"negative", [][]int{{0, -1}}, []int{2, 2}, "value -1 outside [0, 2)"},
+		{"too large", [][]int{{2, 0}}, []int{2, 2}, "value 2 outside [0, 2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNDHistogramDiscrete(tt.data, tt.levels)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
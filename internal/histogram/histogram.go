@@ -70,36 +70,427 @@ const (
 //	probs := hist.Probabilities() // Get normalized distribution
 func NewNDHistogram(data [][]float64, bins []int) (*NDHistogram, error) {
 	// Validate inputs
+	if err := validate(data, bins); err != nil {
+		return nil, err
+	}
+
+	nVars := len(data[0])
+
+	minVals, maxVals, err := computeRanges(data, nVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return fill(data, bins, minVals, maxVals, Clamp)
+}
+
+// NewNDHistogramWithSmoothing constructs an N-dimensional histogram like
+// NewNDHistogram, adding alpha instead of 1e-14 to every bin count before
+// normalization (additive, or Laplace, smoothing).
+//
+// The tradeoff: a larger alpha pulls the distribution toward uniform, which
+// biases entropies up and mutual information down, but keeps sparse
+// resamples (e.g. small bootstrap samples) from producing degenerate
+// probabilities. alpha = 1 is the classic Laplace estimator. alpha = 0 leaves
+// empty bins at exactly zero probability; downstream code must then treat
+// 0·log(0) as 0 and avoid ratios of zero probabilities.
+//
+// Parameters:
+//   - data: Sample matrix [samples x variables]
+//   - bins: Number of bins for each variable
+//   - alpha: Pseudo-count added to each bin (>= 0, finite)
+//
+// Example:
+//
+//	// 30 samples over 64 cells: one pseudo-count per cell
+//	hist, err := NewNDHistogramWithSmoothing(data, []int{4, 4, 4}, 1)
+func NewNDHistogramWithSmoothing(data [][]float64, bins []int, alpha float64) (*NDHistogram, error) {
+	if alpha < 0 || math.IsNaN(alpha) || math.IsInf(alpha, 0) {
+		return nil, fmt.Errorf("smoothing alpha must be finite and non-negative, got %v", alpha)
+	}
+	if err := validate(data, bins); err != nil {
+		return nil, err
+	}
+
+	minVals, maxVals, err := computeRanges(data, len(data[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := countSamples(data, bins, minVals, maxVals, Clamp)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeCounts(counts, bins, alpha)
+}
+
+// NewNDHistogramWithRanges constructs an N-dimensional histogram using fixed
+// per-variable ranges instead of ranges computed from data.
+//
+// Fixed ranges make histograms built from different subsets of a dataset
+// (e.g. rolling windows) directly comparable: the same value always falls
+// into the same bin. Values outside [minVals[j], maxVals[j]] are clamped
+// into the first or last bin; use NewNDHistogramWithOptions with an
+// OutOfRangePolicy to drop them or fail instead.
+//
+// Parameters:
+//   - data: Sample matrix [samples x variables]
+//   - bins: Number of bins for each variable
+//   - minVals, maxVals: Range of each variable (len must equal number of variables)
+//
+// Example:
+//
+//	// Bin a window with ranges taken from the whole series
+//	hist, err := NewNDHistogramWithRanges(window, []int{8, 8}, []float64{0, 0}, []float64{1, 1})
+func NewNDHistogramWithRanges(data [][]float64, bins []int, minVals, maxVals []float64) (*NDHistogram, error) {
+	if err := validate(data, bins); err != nil {
+		return nil, err
+	}
+
+	lo, hi, err := fixedRanges(minVals, maxVals, len(data[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	return fill(data, bins, lo, hi, Clamp)
+}
+
+// NewNDHistogramByWidth constructs an N-dimensional histogram from bin widths
+// instead of bin counts.
+//
+// The number of bins for variable j is ceil(range_j / widths[j]), where range_j
+// is the data range, clamped to [1, 10000]. The histogram covers the data range
+// exactly, so the effective bin width is range_j / bins[j] (at most widths[j]
+// unless clamped).
+//
+// Parameters:
+//   - data: Sample matrix [samples x variables]
+//   - widths: Desired bin width for each variable (> 0, in data units)
+//
+// Returns:
+//   - *NDHistogram: Constructed histogram
+//   - []int: Effective number of bins for each variable
+//   - error: Non-nil if validation fails
+//
+// Example:
+//
+//	// Velocity in m/s with 0.1 m/s resolution, pressure with 5 Pa resolution
+//	hist, bins, err := NewNDHistogramByWidth(data, []float64{0.1, 5})
+func NewNDHistogramByWidth(data [][]float64, widths []float64) (*NDHistogram, []int, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("data cannot be empty")
+	}
+	nVars := len(data[0])
+	if len(widths) != nVars {
+		return nil, nil, fmt.Errorf("widths length (%d) must match number of variables (%d)", len(widths), nVars)
+	}
+	for j, w := range widths {
+		if w <= 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, nil, fmt.Errorf("widths[%d] must be positive and finite, got %v", j, w)
+		}
+	}
+
+	// Validate data shape with placeholder bins
+	placeholder := make([]int, nVars)
+	for j := range placeholder {
+		placeholder[j] = minBins
+	}
+	if err := validate(data, placeholder); err != nil {
+		return nil, nil, err
+	}
+
+	minVals, maxVals, err := computeRanges(data, nVars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bins := make([]int, nVars)
+	for j := 0; j < nVars; j++ {
+		// Tolerance keeps exact multiples (e.g. 1.0 / 0.1) from rounding up
+		count := math.Ceil((maxVals[j]-minVals[j])/widths[j] - 1e-9)
+		bins[j] = int(math.Max(minBins, math.Min(maxBins, count)))
+	}
+
+	hist, err := fill(data, bins, minVals, maxVals, Clamp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]int, nVars)
+	copy(result, bins)
+	return hist, result, nil
+}
+
+// OutOfRangePolicy selects how values outside fixed ranges are binned.
+type OutOfRangePolicy int
+
+const (
+	// Clamp puts out-of-range values into the first or last bin (default).
+	Clamp OutOfRangePolicy = iota
+
+	// Drop skips samples with any out-of-range value, reducing the effective
+	// number of samples. Construction fails if no sample is left.
+	Drop
+
+	// Error fails the construction on the first out-of-range value.
+	Error
+)
+
+// String returns the policy name.
+func (p OutOfRangePolicy) String() string {
+	switch p {
+	case Clamp:
+		return "clamp"
+	case Drop:
+		return "drop"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("OutOfRangePolicy(%d)", int(p))
+	}
+}
+
+// Options configures NewNDHistogramWithOptions.
+type Options struct {
+	// Circular marks circular (angular) variables, e.g. phases. A circular
+	// variable is reduced modulo its period and binned over [0, period), so a
+	// value just above the period falls into the first bin instead of beyond the
+	// last one, and angles that differ by whole turns share a bin.
+	// nil means no circular variables; otherwise len must equal the number of variables.
+	Circular []bool
+
+	// Period is the period of each circular variable (0 = 2π). Ignored for
+	// non-circular variables. nil means 2π for all.
+	Period []float64
+
+	// MinVals and MaxVals fix the range of each variable, as in
+	// NewNDHistogramWithRanges. Both nil means the data range. Circular
+	// variables always use [0, period).
+	MinVals, MaxVals []float64
+
+	// OutOfRange selects how values outside MinVals/MaxVals are handled.
+	// The zero value is Clamp. Has no effect with data ranges.
+	OutOfRange OutOfRangePolicy
+}
+
+// NewNDHistogramWithOptions constructs an N-dimensional histogram like
+// NewNDHistogram, with per-variable options such as circular variables.
+//
+// Non-circular variables use the data range, as in NewNDHistogram, unless
+// opts.MinVals and opts.MaxVals are set.
+//
+// Example:
+//
+//	// Phase (radians) of two oscillators and an amplitude
+//	opts := Options{Circular: []bool{true, true, false}}
+//	hist, err := NewNDHistogramWithOptions(data, []int{8, 8, 8}, opts)
+//
+//	// Fixed ranges, dropping outliers instead of piling them into edge bins
+//	opts = Options{MinVals: []float64{-3, -3}, MaxVals: []float64{3, 3}, OutOfRange: Drop}
+//	hist, err = NewNDHistogramWithOptions(data, []int{8, 8}, opts)
+func NewNDHistogramWithOptions(data [][]float64, bins []int, opts Options) (*NDHistogram, error) {
+	if err := validate(data, bins); err != nil {
+		return nil, err
+	}
+
+	nVars := len(data[0])
+	if opts.Circular != nil && len(opts.Circular) != nVars {
+		return nil, fmt.Errorf("circular length (%d) must match number of variables (%d)", len(opts.Circular), nVars)
+	}
+	if opts.Period != nil && len(opts.Period) != nVars {
+		return nil, fmt.Errorf("period length (%d) must match number of variables (%d)", len(opts.Period), nVars)
+	}
+	if opts.OutOfRange < Clamp || opts.OutOfRange > Error {
+		return nil, fmt.Errorf("unknown out-of-range policy %v", opts.OutOfRange)
+	}
+	fixed := opts.MinVals != nil || opts.MaxVals != nil
+
+	periods := make([]float64, nVars)
+	anyCircular := false
+	for j := 0; j < nVars; j++ {
+		if opts.Circular == nil || !opts.Circular[j] {
+			continue
+		}
+		anyCircular = true
+		periods[j] = 2 * math.Pi
+		if opts.Period != nil && opts.Period[j] != 0 {
+			periods[j] = opts.Period[j]
+		}
+		if periods[j] < 0 || math.IsNaN(periods[j]) || math.IsInf(periods[j], 0) {
+			return nil, fmt.Errorf("period of variable %d must be positive and finite, got %v", j, periods[j])
+		}
+	}
+	if !anyCircular && !fixed {
+		return NewNDHistogram(data, bins)
+	}
+
+	// Reduce circular variables to [0, period)
+	wrapped := data
+	if anyCircular {
+		wrapped = make([][]float64, len(data))
+		for i, sample := range data {
+			row := make([]float64, nVars)
+			copy(row, sample)
+			for j, period := range periods {
+				if period > 0 {
+					row[j] = wrapAngle(row[j], period)
+				}
+			}
+			wrapped[i] = row
+		}
+	}
+
+	var minVals, maxVals []float64
+	var err error
+	if fixed {
+		minVals, maxVals, err = fixedRanges(opts.MinVals, opts.MaxVals, nVars)
+	} else {
+		minVals, maxVals, err = computeRanges(wrapped, nVars)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for j, period := range periods {
+		if period > 0 {
+			minVals[j], maxVals[j] = 0, period
+		}
+	}
+
+	return fill(wrapped, bins, minVals, maxVals, opts.OutOfRange)
+}
+
+// wrapAngle reduces x to [0, period). NaN and Inf are returned unchanged.
+func wrapAngle(x, period float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	r := math.Mod(x, period)
+	if r < 0 {
+		r += period
+	}
+	if r >= period {
+		// -tiny + period rounds up to period
+		r = 0
+	}
+	return r
+}
+
+// NewNDHistogramFromProbabilities constructs a histogram from an existing
+// probability array, e.g. after transforming another histogram.
+//
+// probs is a flattened row-major array of length product(shape). Values must be
+// finite and non-negative; they are normalized to sum to 1.0. No smoothing is
+// applied.
+//
+// Example:
+//
+//	hist, err := NewNDHistogramFromProbabilities([]float64{0.1, 0.2, 0.3, 0.4}, []int{2, 2})
+func NewNDHistogramFromProbabilities(probs []float64, shape []int) (*NDHistogram, error) {
+	if len(shape) == 0 {
+		return nil, fmt.Errorf("shape is empty")
+	}
+
+	size := 1
+	for i, dim := range shape {
+		if dim < minBins || dim > maxBins {
+			return nil, fmt.Errorf("dimension %d has %d bins, must be in [%d, %d]", i, dim, minBins, maxBins)
+		}
+		size *= dim
+	}
+	if len(probs) != size {
+		return nil, fmt.Errorf("probabilities length (%d) must match shape size (%d)", len(probs), size)
+	}
+
+	total := 0.0
+	for i, p := range probs {
+		if p < 0 || math.IsNaN(p) || math.IsInf(p, 0) {
+			return nil, fmt.Errorf("probability %d must be finite and non-negative, got %v", i, p)
+		}
+		total += p
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("probabilities sum to zero")
+	}
+
+	normalized := make([]float64, size)
+	for i, p := range probs {
+		normalized[i] = p / total
+	}
+	dims := make([]int, len(shape))
+	copy(dims, shape)
+
+	return &NDHistogram{
+		probs: normalized,
+		shape: dims,
+		bins:  dims,
+	}, nil
+}
+
+// fixedRanges validates user-supplied ranges and widens degenerate ones.
+func fixedRanges(minVals, maxVals []float64, nVars int) ([]float64, []float64, error) {
+	if len(minVals) != nVars || len(maxVals) != nVars {
+		return nil, nil, fmt.Errorf("ranges length (%d, %d) must match number of variables (%d)", len(minVals), len(maxVals), nVars)
+	}
+
+	lo := make([]float64, nVars)
+	hi := make([]float64, nVars)
+	for j := 0; j < nVars; j++ {
+		if math.IsNaN(minVals[j]) || math.IsNaN(maxVals[j]) || math.IsInf(minVals[j], 0) || math.IsInf(maxVals[j], 0) {
+			return nil, nil, fmt.Errorf("range of variable %d must be finite, got [%v, %v]", j, minVals[j], maxVals[j])
+		}
+		if minVals[j] > maxVals[j] {
+			return nil, nil, fmt.Errorf("range of variable %d is inverted: [%v, %v]", j, minVals[j], maxVals[j])
+		}
+		lo[j] = minVals[j]
+		hi[j] = maxVals[j]
+		if lo[j] == hi[j] {
+			hi[j] += 1e-10
+		}
+	}
+	return lo, hi, nil
+}
+
+// validate checks data and bins shared by all histogram constructors.
+func validate(data [][]float64, bins []int) error {
 	if len(data) == 0 {
-		return nil, fmt.Errorf("data cannot be empty")
+		return fmt.Errorf("data cannot be empty")
 	}
 	if len(data[0]) == 0 {
-		return nil, fmt.Errorf("data must have at least one variable")
+		return fmt.Errorf("data must have at least one variable")
 	}
 
 	nVars := len(data[0])
 
 	if len(bins) != nVars {
-		return nil, fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), nVars)
+		return fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), nVars)
 	}
 
 	// Validate all samples have same length
 	for i, sample := range data {
 		if len(sample) != nVars {
-			return nil, fmt.Errorf("sample %d has length %d, expected %d", i, len(sample), nVars)
+			return fmt.Errorf("sample %d has length %d, expected %d", i, len(sample), nVars)
 		}
 	}
 
-	// Validate bins
+	return validateBins(bins)
+}
+
+// validateBins checks that every bin count is within [minBins, maxBins].
+func validateBins(bins []int) error {
 	for i, b := range bins {
 		if b < minBins {
-			return nil, fmt.Errorf("bins[%d] = %d is less than minimum %d", i, b, minBins)
+			return fmt.Errorf("bins[%d] = %d is less than minimum %d", i, b, minBins)
 		}
 		if b > maxBins {
-			return nil, fmt.Errorf("bins[%d] = %d exceeds maximum %d", i, b, maxBins)
+			return fmt.Errorf("bins[%d] = %d exceeds maximum %d", i, b, maxBins)
 		}
 	}
 
+	return nil
+}
+
+// computeRanges returns the min/max of each variable, ignoring NaN and Inf.
+func computeRanges(data [][]float64, nVars int) ([]float64, []float64, error) {
 	// Compute min/max for each variable
 	minVals := make([]float64, nVars)
 	maxVals := make([]float64, nVars)
@@ -126,7 +517,7 @@ func NewNDHistogram(data [][]float64, bins []int) (*NDHistogram, error) {
 	// Check for valid ranges
 	for j := 0; j < nVars; j++ {
 		if math.IsInf(minVals[j], 0) || math.IsInf(maxVals[j], 0) {
-			return nil, fmt.Errorf("variable %d has no valid (non-NaN, non-Inf) values", j)
+			return nil, nil, fmt.Errorf("variable %d has no valid (non-NaN, non-Inf) values", j)
 		}
 		// Handle case where all values are the same
 		if minVals[j] == maxVals[j] {
@@ -135,6 +526,21 @@ func NewNDHistogram(data [][]float64, bins []int) (*NDHistogram, error) {
 		}
 	}
 
+	return minVals, maxVals, nil
+}
+
+// fill assigns samples to bins, applies smoothing, and normalizes.
+// policy decides what happens to values outside [minVals, maxVals].
+func fill(data [][]float64, bins []int, minVals, maxVals []float64, policy OutOfRangePolicy) (*NDHistogram, error) {
+	counts, err := countSamples(data, bins, minVals, maxVals, policy)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeCounts(counts, bins, smoothingFactor)
+}
+
+// countSamples returns the raw bin counts of data.
+func countSamples(data [][]float64, bins []int, minVals, maxVals []float64, policy OutOfRangePolicy) ([]float64, error) {
 	// Calculate total size of histogram
 	totalBins := 1
 	for _, b := range bins {
@@ -145,41 +551,80 @@ func NewNDHistogram(data [][]float64, bins []int) (*NDHistogram, error) {
 	counts := make([]float64, totalBins)
 
 	// Fill histogram: assign each sample to bins
-	for _, sample := range data {
-		// Compute bin indices for this sample
-		binIndices := make([]int, nVars)
-		validSample := true
-
-		for j, val := range sample {
-			if math.IsNaN(val) || math.IsInf(val, 0) {
-				validSample = false
-				break
+	binIndices := make([]int, len(bins))
+	dropped, counted := 0, 0
+	for i, sample := range data {
+		if policy != Clamp {
+			if j := outOfRange(sample, minVals, maxVals); j >= 0 {
+				if policy == Error {
+					return nil, fmt.Errorf("sample %d: variable %d value %v outside range [%v, %v]",
+						i, j, sample[j], minVals[j], maxVals[j])
+				}
+				dropped++
+				continue
 			}
+		}
+		if flatIdx, ok := sampleIndex(sample, bins, minVals, maxVals, binIndices); ok {
+			counts[flatIdx]++
+			counted++
+		}
+	}
+
+	// Smoothing alone would turn an empty histogram into a uniform one
+	if dropped > 0 && counted == 0 {
+		return nil, fmt.Errorf("no valid samples left after %d out-of-range samples were dropped", dropped)
+	}
 
-			// Normalize to [0, 1] and scale to bin index
-			normalized := (val - minVals[j]) / (maxVals[j] - minVals[j])
-			binIdx := int(normalized * float64(bins[j]))
+	return counts, nil
+}
 
-			// Handle edge case where value == maxVal
-			if binIdx >= bins[j] {
-				binIdx = bins[j] - 1
-			}
+// outOfRange returns the first variable of sample outside [minVals, maxVals],
+// or -1. NaN and Inf are left to sampleIndex, which skips them.
+func outOfRange(sample, minVals, maxVals []float64) int {
+	for j, val := range sample {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			continue
+		}
+		if val < minVals[j] || val > maxVals[j] {
+			return j
+		}
+	}
+	return -1
+}
 
-			binIndices[j] = binIdx
+// sampleIndex returns the flat bin index of sample, using binIndices as
+// scratch space. ok is false if the sample contains NaN or Inf.
+func sampleIndex(sample []float64, bins []int, minVals, maxVals []float64, binIndices []int) (flatIdx int, ok bool) {
+	for j, val := range sample {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return 0, false
 		}
 
-		if !validSample {
-			continue
+		// Normalize to [0, 1] and scale to bin index
+		normalized := (val - minVals[j]) / (maxVals[j] - minVals[j])
+		binIdx := int(normalized * float64(bins[j]))
+
+		// Handle edge case where value == maxVal (or beyond a fixed range)
+		if binIdx >= bins[j] {
+			binIdx = bins[j] - 1
+		}
+		if binIdx < 0 {
+			binIdx = 0
 		}
 
-		// Convert multi-dimensional bin indices to flat index
-		flatIdx := multiToFlatIndex(bins, binIndices)
-		counts[flatIdx]++
+		binIndices[j] = binIdx
 	}
 
+	// Convert multi-dimensional bin indices to flat index
+	return multiToFlatIndex(bins, binIndices), true
+}
+
+// normalizeCounts adds alpha to each raw bin count and normalizes them.
+// counts is modified in place.
+func normalizeCounts(counts []float64, bins []int, alpha float64) (*NDHistogram, error) {
 	// Apply additive smoothing (matches Python: hist += 1e-14)
 	for i := range counts {
-		counts[i] += smoothingFactor
+		counts[i] += alpha
 	}
 
 	// Normalize to create probability distribution
@@ -192,7 +637,7 @@ func NewNDHistogram(data [][]float64, bins []int) (*NDHistogram, error) {
 		return nil, fmt.Errorf("all samples were invalid (NaN or Inf)")
 	}
 
-	probs := make([]float64, totalBins)
+	probs := make([]float64, len(counts))
 	for i, count := range counts {
 		probs[i] = count / total
 	}
@@ -259,6 +704,31 @@ func (h *NDHistogram) NDims() int {
 	return len(h.shape)
 }
 
+// OccupancyEntropy returns the Shannon entropy (in bits) of the fraction of
+// samples per bin, treating the joint grid as a single flat distribution.
+//
+// The maximum, log2(Size()), is reached when samples fill all bins evenly.
+// Values far below it mean most of the grid is empty and resolution is
+// wasted, which also biases the plug-in entropies; consider fewer bins.
+// Smoothed empty bins contribute a negligible amount.
+//
+// Returns:
+//   - float64: Occupancy entropy in bits, in [0, log2(Size())]
+//
+// Example:
+//
+//	hist, _ := NewNDHistogram(data, []int{16, 16})
+//	utilization := hist.OccupancyEntropy() / math.Log2(float64(hist.Size()))
+func (h *NDHistogram) OccupancyEntropy() float64 {
+	entropy := 0.0
+	for _, p := range h.probs {
+		if p > 0 {
+			entropy -= p * math.Log2(p)
+		}
+	}
+	return entropy
+}
+
 // multiToFlatIndex converts multi-dimensional bin indices to a flat index.
 // Uses row-major (C-contiguous) ordering, consistent with entropy.NDArray.
 func multiToFlatIndex(shape, multiIdx []int) int {
@@ -2,6 +2,7 @@ package histogram
 
 import (
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -519,3 +520,382 @@ func TestNDHistogram_SmoothingEffect(t *testing.T) {
 		t.Errorf("max probability = %v, expected higher for populated bin", maxProb)
 	}
 }
+
+// TestNewNDHistogramWithRanges tests fixed-range binning and clamping
+func TestNewNDHistogramWithRanges(t *testing.T) {
+	// All values in the lower half of [0, 10] → all mass in bin 0 with fixed range,
+	// but spread over both bins with data-derived range
+	data := [][]float64{{1.0}, {2.0}, {3.0}, {4.0}}
+
+	fixed, err := NewNDHistogramWithRanges(data, []int{2}, []float64{0}, []float64{10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	probs := fixed.Probabilities()
+	if probs[0] < 0.999 {
+		t.Errorf("fixed range: probs[0] = %v, want ~1.0", probs[0])
+	}
+
+	auto, err := NewNDHistogram(data, []int{2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := auto.Probabilities(); math.Abs(p[0]-0.5) > 1e-10 {
+		t.Errorf("data range: probs[0] = %v, want 0.5", p[0])
+	}
+
+	// Values outside the range are clamped to the edge bins
+	outside := [][]float64{{-5.0}, {15.0}}
+	clamped, err := NewNDHistogramWithRanges(outside, []int{2}, []float64{0}, []float64{10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := clamped.Probabilities(); math.Abs(p[0]-0.5) > 1e-10 || math.Abs(p[1]-0.5) > 1e-10 {
+		t.Errorf("clamped probs = %v, want [0.5 0.5]", p)
+	}
+
+	errorCases := []struct {
+		name     string
+		min, max []float64
+	}{
+		{"ranges length mismatch", []float64{0, 0}, []float64{1, 1}},
+		{"inverted range", []float64{10}, []float64{0}},
+		{"non-finite range", []float64{math.Inf(-1)}, []float64{1}},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNDHistogramWithRanges(data, []int{2}, tt.min, tt.max); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestNewNDHistogramFromProbabilities(t *testing.T) {
+	hist, err := NewNDHistogramFromProbabilities([]float64{1, 2, 3, 4}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	probs := hist.Probabilities()
+	for i, want := range []float64{0.1, 0.2, 0.3, 0.4} {
+		if math.Abs(probs[i]-want) > 1e-12 {
+			t.Errorf("probs[%d] = %v, want %v", i, probs[i], want)
+		}
+	}
+	if shape := hist.Shape(); len(shape) != 2 || shape[0] != 2 || shape[1] != 2 {
+		t.Errorf("shape = %v, want [2 2]", shape)
+	}
+
+	errorCases := []struct {
+		name  string
+		probs []float64
+		shape []int
+	}{
+		{"empty shape", []float64{1}, []int{}},
+		{"length mismatch", []float64{1, 2, 3}, []int{2, 2}},
+		{"zero dimension", []float64{}, []int{0, 2}},
+		{"negative probability", []float64{1, -1}, []int{2}},
+		{"NaN probability", []float64{1, math.NaN()}, []int{2}},
+		{"all zero", []float64{0, 0}, []int{2}},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNDHistogramFromProbabilities(tt.probs, tt.shape); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestNDHistogram_OccupancyEntropy(t *testing.T) {
+	// Uniform: every cell of the 10x10 grid holds the same number of samples
+	var uniform [][]float64
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			for k := 0; k < 5; k++ {
+				uniform = append(uniform, []float64{float64(i), float64(j)})
+			}
+		}
+	}
+
+	// Concentrated: same range, but almost all samples in one corner
+	concentrated := [][]float64{{0, 0}, {9, 9}}
+	for k := 0; k < 498; k++ {
+		concentrated = append(concentrated, []float64{0.1, 0.1})
+	}
+
+	maxEntropy := math.Log2(100)
+	hu, err := NewNDHistogram(uniform, []int{10, 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hc, err := NewNDHistogram(concentrated, []int{10, 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hUniform := hu.OccupancyEntropy()
+	hConcentrated := hc.OccupancyEntropy()
+	t.Logf("uniform %.4f, concentrated %.4f, max %.4f bits", hUniform, hConcentrated, maxEntropy)
+
+	if math.Abs(hUniform-maxEntropy) > 1e-9 {
+		t.Errorf("uniform occupancy entropy = %v, want log2(100) = %v", hUniform, maxEntropy)
+	}
+	if hConcentrated > 0.1*maxEntropy {
+		t.Errorf("concentrated occupancy entropy = %v, expected well below %v", hConcentrated, maxEntropy)
+	}
+}
+
+func TestNewNDHistogramByWidth(t *testing.T) {
+	// Variable 0 spans [0, 1], variable 1 spans [0, 10], variable 2 is constant
+	data := make([][]float64, 101)
+	for i := range data {
+		x := float64(i) / 100
+		data[i] = []float64{x, 10 * x, 3.0}
+	}
+
+	hist, bins, err := NewNDHistogramByWidth(data, []float64{0.1, 4, 0.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []int{10, 3, 1} // 1/0.1, ceil(10/4), constant → 1
+	for j := range want {
+		if bins[j] != want[j] {
+			t.Errorf("bins[%d] = %d, want %d", j, bins[j], want[j])
+		}
+	}
+	if shape := hist.Shape(); shape[0] != 10 || shape[1] != 3 || shape[2] != 1 {
+		t.Errorf("shape = %v, want %v", shape, want)
+	}
+
+	// Tiny width is clamped to maxBins
+	_, bins, err = NewNDHistogramByWidth([][]float64{{0}, {1}}, []float64{1e-9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bins[0] != maxBins {
+		t.Errorf("clamped bins = %d, want %d", bins[0], maxBins)
+	}
+
+	errorCases := []struct {
+		name   string
+		data   [][]float64
+		widths []float64
+	}{
+		{"empty data", [][]float64{}, []float64{0.1}},
+		{"widths mismatch", data, []float64{0.1}},
+		{"zero width", data, []float64{0.1, 0, 0.5}},
+		{"NaN width", data, []float64{0.1, math.NaN(), 0.5}},
+		{"ragged data", [][]float64{{1, 2}, {3}}, []float64{0.1, 0.1}},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := NewNDHistogramByWidth(tt.data, tt.widths); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestNewNDHistogramWithOptions_Circular(t *testing.T) {
+	// Angles just below 2π, at 2π and beyond wrap into the first bins
+	data := [][]float64{{0.1, 0}, {2 * math.Pi, 1}, {2*math.Pi + 0.1, 2}, {-0.1, 3}, {math.Pi + 0.1, 4}}
+	hist, err := NewNDHistogramWithOptions(data, []int{4, 5}, Options{Circular: []bool{true, false}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Marginal over the circular axis: bins [0, π/2), ..., [3π/2, 2π)
+	probs := hist.Probabilities()
+	marginal := make([]float64, 4)
+	for i := range marginal {
+		for j := 0; j < 5; j++ {
+			marginal[i] += probs[i*5+j]
+		}
+	}
+	want := []float64{0.6, 0, 0.2, 0.2}
+	for i := range want {
+		if math.Abs(marginal[i]-want[i]) > 1e-10 {
+			t.Errorf("marginal[%d] = %.4f, want %.4f", i, marginal[i], want[i])
+		}
+	}
+
+	// Custom period: hours of day
+	hours := [][]float64{{23.5}, {24.5}, {0.5}, {12}}
+	hist, err = NewNDHistogramWithOptions(hours, []int{2}, Options{Circular: []bool{true}, Period: []float64{24}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := hist.Probabilities(); math.Abs(p[0]-0.5) > 1e-10 {
+		t.Errorf("P(hour < 12) = %.4f, want 0.5", p[0])
+	}
+
+	errorCases := []struct {
+		name string
+		opts Options
+	}{
+		{"circular mismatch", Options{Circular: []bool{true}}},
+		{"period mismatch", Options{Circular: []bool{true, false}, Period: []float64{1}}},
+		{"negative period", Options{Circular: []bool{true, false}, Period: []float64{-1, 0}}},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNDHistogramWithOptions(data, []int{4, 5}, tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestNewNDHistogramWithOptions_OutOfRange(t *testing.T) {
+	// Range [0, 10] is narrower than the data: -5 and 15 are outliers.
+	data := [][]float64{{1.0}, {2.0}, {7.0}, {-5.0}, {15.0}}
+	bins := []int{2}
+	lo, hi := []float64{0}, []float64{10}
+
+	t.Run("clamp matches NewNDHistogramWithRanges", func(t *testing.T) {
+		got, err := NewNDHistogramWithOptions(data, bins, Options{MinVals: lo, MaxVals: hi})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want, err := NewNDHistogramWithRanges(data, bins, lo, hi)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		gp, wp := got.Probabilities(), want.Probabilities()
+		for i := range wp {
+			if gp[i] != wp[i] {
+				t.Errorf("probs[%d] = %v, want %v", i, gp[i], wp[i])
+			}
+		}
+		// {1, 2, -5} in bin 0, {7, 15} in bin 1
+		if math.Abs(gp[0]-0.6) > 1e-10 {
+			t.Errorf("clamped probs = %v, want [0.6 0.4]", gp)
+		}
+	})
+
+	t.Run("drop reduces effective N", func(t *testing.T) {
+		hist, err := NewNDHistogramWithOptions(data, bins, Options{MinVals: lo, MaxVals: hi, OutOfRange: Drop})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Only {1, 2, 7} remain: 2 of 3 samples in bin 0
+		if p := hist.Probabilities(); math.Abs(p[0]-2.0/3) > 1e-10 {
+			t.Errorf("dropped probs = %v, want [2/3 1/3]", p)
+		}
+	})
+
+	t.Run("drop fails when every sample is dropped", func(t *testing.T) {
+		outside := data[3:]
+		_, err := NewNDHistogramWithOptions(outside, bins, Options{MinVals: lo, MaxVals: hi, OutOfRange: Drop})
+		if err == nil {
+			t.Fatal("expected error when no sample is in range")
+		}
+		if !strings.Contains(err.Error(), "dropped") {
+			t.Errorf("error %q should say the samples were dropped", err)
+		}
+	})
+
+	t.Run("error fails", func(t *testing.T) {
+		_, err := NewNDHistogramWithOptions(data, bins, Options{MinVals: lo, MaxVals: hi, OutOfRange: Error})
+		if err == nil {
+			t.Fatal("expected error for out-of-range value")
+		}
+		if !strings.Contains(err.Error(), "sample 3") {
+			t.Errorf("error %q should name the first outlier (sample 3)", err)
+		}
+	})
+
+	t.Run("policies agree when data is in range", func(t *testing.T) {
+		inside := data[:3]
+		for _, policy := range []OutOfRangePolicy{Clamp, Drop, Error} {
+			hist, err := NewNDHistogramWithOptions(inside, bins, Options{MinVals: lo, MaxVals: hi, OutOfRange: policy})
+			if err != nil {
+				t.Fatalf("%v: unexpected error: %v", policy, err)
+			}
+			if p := hist.Probabilities(); math.Abs(p[0]-2.0/3) > 1e-10 {
+				t.Errorf("%v: probs = %v, want [2/3 1/3]", policy, p)
+			}
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		if _, err := NewNDHistogramWithOptions(data, bins, Options{MinVals: lo}); err == nil {
+			t.Error("expected error for missing MaxVals")
+		}
+		if _, err := NewNDHistogramWithOptions(data, bins, Options{OutOfRange: OutOfRangePolicy(7)}); err == nil {
+			t.Error("expected error for unknown policy")
+		}
+	})
+}
+
+func TestNewNDHistogramWithSmoothing(t *testing.T) {
+	// 3 samples over 4 bins: bins 0 and 3 populated, 1 and 2 empty
+	data := [][]float64{{0.0}, {0.1}, {1.0}}
+	bins := []int{4}
+
+	t.Run("default matches NewNDHistogram", func(t *testing.T) {
+		got, err := NewNDHistogramWithSmoothing(data, bins, smoothingFactor)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want, err := NewNDHistogram(data, bins)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		gp, wp := got.Probabilities(), want.Probabilities()
+		for i := range wp {
+			if gp[i] != wp[i] {
+				t.Errorf("probs[%d] = %v, want %v", i, gp[i], wp[i])
+			}
+		}
+	})
+
+	t.Run("zero alpha leaves empty bins at zero", func(t *testing.T) {
+		hist, err := NewNDHistogramWithSmoothing(data, bins, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []float64{2.0 / 3, 0, 0, 1.0 / 3}
+		for i, p := range hist.Probabilities() {
+			if math.Abs(p-want[i]) > 1e-12 {
+				t.Errorf("probs[%d] = %v, want %v", i, p, want[i])
+			}
+		}
+	})
+
+	t.Run("laplace", func(t *testing.T) {
+		hist, err := NewNDHistogramWithSmoothing(data, bins, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// (count + 1) / (3 + 4)
+		want := []float64{3.0 / 7, 1.0 / 7, 1.0 / 7, 2.0 / 7}
+		for i, p := range hist.Probabilities() {
+			if math.Abs(p-want[i]) > 1e-12 {
+				t.Errorf("probs[%d] = %v, want %v", i, p, want[i])
+			}
+		}
+	})
+
+	t.Run("large alpha tends to uniform", func(t *testing.T) {
+		hist, err := NewNDHistogramWithSmoothing(data, bins, 1e6)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, p := range hist.Probabilities() {
+			if math.Abs(p-0.25) > 1e-5 {
+				t.Errorf("probs[%d] = %v, want ~0.25", i, p)
+			}
+		}
+	})
+
+	t.Run("invalid alpha", func(t *testing.T) {
+		for _, alpha := range []float64{-1, math.NaN(), math.Inf(1)} {
+			if _, err := NewNDHistogramWithSmoothing(data, bins, alpha); err == nil {
+				t.Errorf("alpha=%v: expected error", alpha)
+			}
+		}
+	})
+}
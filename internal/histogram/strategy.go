@@ -0,0 +1,112 @@
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// BinStrategy selects how bin edges are placed within each variable's range.
+type BinStrategy int
+
+const (
+	// EqualWidth splits the range [min, max] into bins of equal width
+	// (the behavior of NewNDHistogram).
+	EqualWidth BinStrategy = iota
+
+	// EqualFrequency places edges at quantiles so each bin holds roughly the
+	// same number of samples. Tied values always share a bin, so heavily
+	// discrete variables may leave some bins empty.
+	EqualFrequency
+)
+
+// String returns the strategy name.
+func (s BinStrategy) String() string {
+	switch s {
+	case EqualWidth:
+		return "EqualWidth"
+	case EqualFrequency:
+		return "EqualFrequency"
+	default:
+		return fmt.Sprintf("BinStrategy(%d)", int(s))
+	}
+}
+
+// NewNDHistogramWithStrategy constructs an N-dimensional histogram like
+// NewNDHistogram, with the bin edges placed according to strategy.
+//
+// EqualFrequency resolves skewed variables (e.g. turbulence signals where
+// most samples cluster in a narrow range) much better than equal-width bins,
+// whose resolution is dictated by the extremes. Smoothing and normalization
+// are the same as in NewNDHistogram.
+//
+// Example:
+//
+//	hist, err := NewNDHistogramWithStrategy(data, []int{16, 16}, EqualFrequency)
+func NewNDHistogramWithStrategy(data [][]float64, bins []int, strategy BinStrategy) (*NDHistogram, error) {
+	switch strategy {
+	case EqualWidth:
+		return NewNDHistogram(data, bins)
+	case EqualFrequency:
+	default:
+		return nil, fmt.Errorf("unknown bin strategy %v", strategy)
+	}
+
+	if err := validate(data, bins); err != nil {
+		return nil, err
+	}
+
+	nVars := len(data[0])
+	edges := make([][]float64, nVars)
+	for j := 0; j < nVars; j++ {
+		var err error
+		if edges[j], err = quantileEdges(data, j, bins[j]); err != nil {
+			return nil, err
+		}
+	}
+
+	totalBins := 1
+	for _, b := range bins {
+		totalBins *= b
+	}
+	counts := make([]float64, totalBins)
+
+	binIndices := make([]int, nVars)
+	for _, sample := range data {
+		validSample := true
+		for j, val := range sample {
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				validSample = false
+				break
+			}
+			// Number of inner edges <= val
+			binIndices[j] = sort.Search(len(edges[j]), func(k int) bool { return edges[j][k] > val })
+		}
+		if validSample {
+			counts[multiToFlatIndex(bins, binIndices)]++
+		}
+	}
+
+	return normalizeCounts(counts, bins, smoothingFactor)
+}
+
+// quantileEdges returns the bins-1 inner edges of variable j that split its
+// finite values into groups of (nearly) equal size.
+func quantileEdges(data [][]float64, j, bins int) ([]float64, error) {
+	values := make([]float64, 0, len(data))
+	for _, sample := range data {
+		if val := sample[j]; !math.IsNaN(val) && !math.IsInf(val, 0) {
+			values = append(values, val)
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("variable %d has no valid (non-NaN, non-Inf) values", j)
+	}
+	sort.Float64s(values)
+
+	edges := make([]float64, bins-1)
+	for k := range edges {
+		edges[k] = values[(k+1)*len(values)/bins]
+	}
+	return edges, nil
+}
@@ -0,0 +1,68 @@
+package histogram
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNewNDHistogramWithStrategy_EqualFrequency(t *testing.T) {
+	// Log-normal: heavily skewed, most samples near the lower end
+	rng := rand.New(rand.NewSource(5)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 4000)
+	for i := range data {
+		data[i] = []float64{math.Exp(2 * rng.NormFloat64())}
+	}
+
+	width, err := NewNDHistogramWithStrategy(data, []int{8}, EqualWidth)
+	if err != nil {
+		t.Fatalf("EqualWidth failed: %v", err)
+	}
+	freq, err := NewNDHistogramWithStrategy(data, []int{8}, EqualFrequency)
+	if err != nil {
+		t.Fatalf("EqualFrequency failed: %v", err)
+	}
+
+	for i, p := range freq.Probabilities() {
+		if math.Abs(p-1.0/8) > 1e-3 {
+			t.Errorf("EqualFrequency bin %d has probability %.4f, want 0.125", i, p)
+		}
+	}
+	if width.Probabilities()[0] < 0.9 {
+		t.Errorf("EqualWidth first bin = %.3f, expected skewed data to pile up there", width.Probabilities()[0])
+	}
+	if freq.OccupancyEntropy() <= width.OccupancyEntropy() {
+		t.Errorf("EqualFrequency occupancy %.3f should exceed EqualWidth %.3f",
+			freq.OccupancyEntropy(), width.OccupancyEntropy())
+	}
+}
+
+func TestNewNDHistogramWithStrategy_EqualWidthMatchesDefault(t *testing.T) {
+	data := [][]float64{{0, 1}, {1, 3}, {2, 2}, {3, 0}, {math.NaN(), 1}}
+	want, err := NewNDHistogram(data, []int{2, 3})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	got, err := NewNDHistogramWithStrategy(data, []int{2, 3}, EqualWidth)
+	if err != nil {
+		t.Fatalf("NewNDHistogramWithStrategy failed: %v", err)
+	}
+	for i, p := range want.Probabilities() {
+		if got.Probabilities()[i] != p {
+			t.Errorf("probs[%d] = %v, want %v", i, got.Probabilities()[i], p)
+		}
+	}
+}
+
+func TestNewNDHistogramWithStrategy_Errors(t *testing.T) {
+	data := [][]float64{{0}, {1}}
+	if _, err := NewNDHistogramWithStrategy(data, []int{2}, BinStrategy(7)); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+	if _, err := NewNDHistogramWithStrategy(data, []int{2, 2}, EqualFrequency); err == nil {
+		t.Error("expected error for bins length mismatch")
+	}
+	if _, err := NewNDHistogramWithStrategy([][]float64{{math.NaN()}}, []int{2}, EqualFrequency); err == nil {
+		t.Error("expected error for all-NaN variable")
+	}
+}
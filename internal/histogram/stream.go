@@ -0,0 +1,119 @@
+package histogram
+
+import "fmt"
+
+// StreamBuilder accumulates an N-dimensional histogram one sample at a time,
+// so data sets larger than memory can be binned without being loaded.
+//
+// The ranges must be fixed up front because a streaming pass cannot compute
+// them from the data. With the same ranges, the result is identical to
+// NewNDHistogramWithRanges on all samples.
+//
+// Histogram is the finalize step: it applies smoothing and normalization and
+// returns an NDHistogram ready for surd.Decompose.
+type StreamBuilder struct {
+	bins       []int
+	minVals    []float64
+	maxVals    []float64
+	counts     []float64
+	binIndices []int // scratch space for sampleIndex
+	n          int
+}
+
+// NewStreamBuilder creates a StreamBuilder with fixed per-variable ranges.
+// Values outside [minVals[j], maxVals[j]] are clamped into the first or last bin.
+//
+// Example:
+//
+//	b, _ := NewStreamBuilder([]int{8, 8}, []float64{0, 0}, []float64{1, 1})
+//	for scanner.Scan() {
+//	    b.Add(parse(scanner.Text()))
+//	}
+//	hist, err := b.Histogram()
+func NewStreamBuilder(bins []int, minVals, maxVals []float64) (*StreamBuilder, error) {
+	if len(bins) == 0 {
+		return nil, fmt.Errorf("bins cannot be empty")
+	}
+	if err := validateBins(bins); err != nil {
+		return nil, err
+	}
+	lo, hi, err := fixedRanges(minVals, maxVals, len(bins))
+	if err != nil {
+		return nil, err
+	}
+
+	totalBins := 1
+	for _, b := range bins {
+		totalBins *= b
+	}
+
+	shape := make([]int, len(bins))
+	copy(shape, bins)
+	return &StreamBuilder{
+		bins:       shape,
+		minVals:    lo,
+		maxVals:    hi,
+		counts:     make([]float64, totalBins),
+		binIndices: make([]int, len(bins)),
+	}, nil
+}
+
+// Add bins one sample. Samples containing NaN or Inf are skipped, as in
+// NewNDHistogram. Returns an error if the sample has the wrong length.
+func (b *StreamBuilder) Add(sample []float64) error {
+	if len(sample) != len(b.bins) {
+		return fmt.Errorf("sample has length %d, expected %d", len(sample), len(b.bins))
+	}
+	if flatIdx, ok := sampleIndex(sample, b.bins, b.minVals, b.maxVals, b.binIndices); ok {
+		b.counts[flatIdx]++
+		b.n++
+	}
+	return nil
+}
+
+// Count returns the number of valid samples added so far.
+func (b *StreamBuilder) Count() int {
+	return b.n
+}
+
+// Histogram returns the normalized histogram of the samples added so far.
+// The builder can keep accepting samples afterwards.
+func (b *StreamBuilder) Histogram() (*NDHistogram, error) {
+	if b.n == 0 {
+		return nil, fmt.Errorf("no valid samples added")
+	}
+	counts := make([]float64, len(b.counts))
+	copy(counts, b.counts)
+	return normalizeCounts(counts, b.bins, smoothingFactor)
+}
+
+// OnlineNDHistogram is the online histogram API over StreamBuilder: samples
+// are added one at a time with Add, and Finalize returns the NDHistogram.
+//
+// Example:
+//
+//	h, _ := NewOnlineNDHistogram([]int{8, 8}, []float64{0, 0}, []float64{1, 1})
+//	for _, row := range chunk {
+//	    _ = h.Add(row)
+//	}
+//	hist, err := h.Finalize()
+//	result, err := surd.Decompose(hist)
+type OnlineNDHistogram struct {
+	*StreamBuilder
+}
+
+// NewOnlineNDHistogram creates an OnlineNDHistogram with fixed per-variable
+// ranges. Values outside [mins[j], maxs[j]] are clamped into the edge bins.
+func NewOnlineNDHistogram(bins []int, mins, maxs []float64) (*OnlineNDHistogram, error) {
+	b, err := NewStreamBuilder(bins, mins, maxs)
+	if err != nil {
+		return nil, err
+	}
+	return &OnlineNDHistogram{StreamBuilder: b}, nil
+}
+
+// Finalize applies smoothing and normalization to the samples added so far
+// and returns the histogram, as StreamBuilder.Histogram does.
+func (h *OnlineNDHistogram) Finalize() (*NDHistogram, error) {
+	return h.Histogram()
+}
@@ -0,0 +1,111 @@
+package histogram
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStreamBuilder_MatchesWithRanges(t *testing.T) {
+	rng := rand.New(rand.NewSource(3)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 1000)
+	for i := range data {
+		data[i] = []float64{rng.NormFloat64(), rng.Float64() * 10}
+	}
+	data[10][0] = math.NaN()
+
+	bins := []int{6, 4}
+	minVals, maxVals := []float64{-2, 0}, []float64{2, 10}
+
+	want, err := NewNDHistogramWithRanges(data, bins, minVals, maxVals)
+	if err != nil {
+		t.Fatalf("NewNDHistogramWithRanges failed: %v", err)
+	}
+
+	b, err := NewStreamBuilder(bins, minVals, maxVals)
+	if err != nil {
+		t.Fatalf("NewStreamBuilder failed: %v", err)
+	}
+	for _, sample := range data {
+		if err := b.Add(sample); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if b.Count() != len(data)-1 {
+		t.Errorf("Count = %d, want %d (NaN sample skipped)", b.Count(), len(data)-1)
+	}
+
+	got, err := b.Histogram()
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	gotProbs, wantProbs := got.Probabilities(), want.Probabilities()
+	for i := range wantProbs {
+		if gotProbs[i] != wantProbs[i] {
+			t.Fatalf("probs[%d] = %v, want %v", i, gotProbs[i], wantProbs[i])
+		}
+	}
+}
+
+func TestStreamBuilder_Errors(t *testing.T) {
+	if _, err := NewStreamBuilder(nil, nil, nil); err == nil {
+		t.Error("expected error for empty bins")
+	}
+	if _, err := NewStreamBuilder([]int{2}, []float64{1}, []float64{0}); err == nil {
+		t.Error("expected error for inverted range")
+	}
+
+	b, err := NewStreamBuilder([]int{2, 2}, []float64{0, 0}, []float64{1, 1})
+	if err != nil {
+		t.Fatalf("NewStreamBuilder failed: %v", err)
+	}
+	if _, err := b.Histogram(); err == nil {
+		t.Error("expected error for histogram without samples")
+	}
+	if err := b.Add([]float64{0.5}); err == nil {
+		t.Error("expected error for short sample")
+	}
+}
+
+func TestOnlineNDHistogram(t *testing.T) {
+	// Values outside [0, 1] clamp to the edge bins
+	data := [][]float64{{-5, 0.2}, {0.1, 0.9}, {0.6, 7}, {2, 0.4}}
+	bins := []int{2, 2}
+	minVals, maxVals := []float64{0, 0}, []float64{1, 1}
+
+	want, err := NewNDHistogramWithRanges(data, bins, minVals, maxVals)
+	if err != nil {
+		t.Fatalf("NewNDHistogramWithRanges failed: %v", err)
+	}
+
+	h, err := NewOnlineNDHistogram(bins, minVals, maxVals)
+	if err != nil {
+		t.Fatalf("NewOnlineNDHistogram failed: %v", err)
+	}
+	for _, sample := range data {
+		if err := h.Add(sample); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	got, err := h.Finalize()
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	gotProbs, wantProbs := got.Probabilities(), want.Probabilities()
+	for i := range wantProbs {
+		if gotProbs[i] != wantProbs[i] {
+			t.Fatalf("probs[%d] = %v, want %v", i, gotProbs[i], wantProbs[i])
+		}
+	}
+
+	if _, err := NewOnlineNDHistogram(nil, nil, nil); err == nil {
+		t.Error("expected error for empty bins")
+	}
+	empty, err := NewOnlineNDHistogram(bins, minVals, maxVals)
+	if err != nil {
+		t.Fatalf("NewOnlineNDHistogram failed: %v", err)
+	}
+	if _, err := empty.Finalize(); err == nil {
+		t.Error("expected error for Finalize without samples")
+	}
+}
@@ -0,0 +1,80 @@
+package scic
+
+import "fmt"
+
+// AutoResult is the output of DecomposeAuto.
+type AutoResult struct {
+	// Result is the SCIC decomposition of the embedding. Variable 0 is the
+	// target's own past; variable i+1 is sources[i].
+	*Result
+
+	// SelfDirection is the direction of the target's past on its future
+	// (persistence): positive for persistent dynamics, negative for
+	// alternating or oscillating ones. Same as Directions["0"].
+	SelfDirection float64
+
+	// SelfUnique is the unique information in bits that the target's past
+	// carries about its future. Same as SURD.Unique["0"].
+	SelfUnique float64
+
+	// NumSamples is the number of aligned samples in the embedding.
+	NumSamples int
+}
+
+// DecomposeAuto runs SCIC on a target whose own past is one of the sources
+// (autocausality).
+//
+// Samples are aligned on the target future at time t:
+//
+//	Y    = series[t]
+//	X[0] = series[t - targetLag]
+//	X[i] = sources[i-1][t - sourceLags[i-1]]
+//
+// for every t at which all lagged values exist. config.Bins has length 1 or
+// len(sources)+2 (future, past, sources).
+//
+// Example:
+//
+//	res, err := DecomposeAuto(y, [][]float64{x}, 1, []int{1}, DefaultConfig())
+//	fmt.Println(res.SelfDirection, res.Directions["1"])
+func DecomposeAuto(series []float64, sources [][]float64, targetLag int, sourceLags []int, config Config) (*AutoResult, error) {
+	if targetLag < 1 {
+		return nil, fmt.Errorf("targetLag must be positive, got %d", targetLag)
+	}
+	if len(sourceLags) != len(sources) {
+		return nil, fmt.Errorf("sourceLags length (%d) must match sources (%d)", len(sourceLags), len(sources))
+	}
+	n := len(series)
+	maxLag := targetLag
+	for i, src := range sources {
+		if len(src) != n {
+			return nil, fmt.Errorf("source %d has %d samples, expected %d", i, len(src), n)
+		}
+		if sourceLags[i] < 1 {
+			return nil, fmt.Errorf("source %d lag must be positive, got %d", i, sourceLags[i])
+		}
+		maxLag = max(maxLag, sourceLags[i])
+	}
+	m := n - maxLag
+	if m < 2 {
+		return nil, fmt.Errorf("series of length %d too short for maximum lag %d", n, maxLag)
+	}
+
+	Y := series[maxLag:]
+	X := make([][]float64, len(sources)+1)
+	X[0] = series[maxLag-targetLag : n-targetLag]
+	for i, src := range sources {
+		X[i+1] = src[maxLag-sourceLags[i] : n-sourceLags[i]]
+	}
+
+	result, err := Decompose(Y, X, config)
+	if err != nil {
+		return nil, err
+	}
+	return &AutoResult{
+		Result:        result,
+		SelfDirection: result.Directions["0"],
+		SelfUnique:    result.SURD.Unique["0"],
+		NumSamples:    m,
+	}, nil
+}
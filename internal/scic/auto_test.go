@@ -0,0 +1,98 @@
+package scic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestDecomposeAuto_AR1 checks that a persistent AR(1) process has a strong
+// positive self-direction and that a driving source is still detected.
+func TestDecomposeAuto_AR1(t *testing.T) {
+	n := 20000
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic for testing
+
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = rng.NormFloat64()
+		if i > 0 {
+			y[i] = 0.8*y[i-1] - 0.5*x[i-1] + 0.3*rng.NormFloat64()
+		}
+	}
+
+	config := DefaultConfig()
+	config.Bins = []int{8}
+	res, err := DecomposeAuto(y, [][]float64{x}, 1, []int{1}, config)
+	if err != nil {
+		t.Fatalf("DecomposeAuto failed: %v", err)
+	}
+
+	if res.NumSamples != n-1 {
+		t.Errorf("NumSamples = %d, want %d", res.NumSamples, n-1)
+	}
+	if res.SelfDirection < 0.5 {
+		t.Errorf("SelfDirection = %.3f, want strongly positive", res.SelfDirection)
+	}
+	if res.SelfUnique <= 0 {
+		t.Errorf("SelfUnique = %.4f, want > 0", res.SelfUnique)
+	}
+	if res.Directions["1"] > -0.3 {
+		t.Errorf("source direction = %.3f, want negative", res.Directions["1"])
+	}
+	t.Logf("AR(1): self=%.3f (U=%.3f bits), source=%.3f",
+		res.SelfDirection, res.SelfUnique, res.Directions["1"])
+}
+
+// TestDecomposeAuto_Oscillator checks that half a period ahead a noisy
+// oscillator is anti-persistent: high values are followed by low ones.
+func TestDecomposeAuto_Oscillator(t *testing.T) {
+	n := 20000
+	period := 20
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic for testing
+
+	y := make([]float64, n)
+	for i := range n {
+		y[i] = math.Sin(2*math.Pi*float64(i)/float64(period)+0.1) + 0.2*rng.NormFloat64()
+	}
+
+	config := DefaultConfig()
+	config.Bins = []int{8}
+	res, err := DecomposeAuto(y, nil, period/2, nil, config)
+	if err != nil {
+		t.Fatalf("DecomposeAuto failed: %v", err)
+	}
+	if res.SelfDirection > -0.5 {
+		t.Errorf("SelfDirection = %.3f, want strongly negative", res.SelfDirection)
+	}
+	if res.NumVariables != 1 {
+		t.Errorf("NumVariables = %d, want 1", res.NumVariables)
+	}
+	t.Logf("Oscillator: self=%.3f (U=%.3f bits)", res.SelfDirection, res.SelfUnique)
+}
+
+func TestDecomposeAuto_Errors(t *testing.T) {
+	y := make([]float64, 10)
+	x := make([]float64, 10)
+	config := DefaultConfig()
+
+	tests := []struct {
+		name       string
+		sources    [][]float64
+		targetLag  int
+		sourceLags []int
+	}{
+		{"zero target lag", nil, 0, nil},
+		{"lags mismatch", [][]float64{x}, 1, nil},
+		{"negative source lag", [][]float64{x}, 1, []int{-1}},
+		{"source length", [][]float64{x[:5]}, 1, []int{1}},
+		{"lag too long", nil, 9, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecomposeAuto(y, tt.sources, tt.targetLag, tt.sourceLags, config); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
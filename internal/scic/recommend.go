@@ -0,0 +1,94 @@
+package scic
+
+import "math"
+
+// defaultRecommendBootstrapN is the number of resamples used by
+// RecommendDirectionMethod when config.BootstrapN is 0.
+const defaultRecommendBootstrapN = 100
+
+// DirectionMethods lists all direction methods, from the most to the least
+// robust. RecommendDirectionMethod breaks ties in this order.
+var DirectionMethods = []DirectionMethod{
+	RankMethod,
+	QuartileMethod,
+	MedianSplitMethod,
+	BinnedConditionalMeanMethod,
+	GradientMethod,
+}
+
+// RecommendDirectionMethod runs every direction method on bootstrap resamples of
+// (Y, X) and returns the one with the most stable sign, plus the stability
+// score of each method.
+//
+// The stability score is |#positive - #negative| / B over B resamples: 1 means
+// every resample gives the same nonzero sign, 0 means the sign is random (or
+// always zero). Invalid resamples count as zero. All methods see the same
+// resamples; ties go to the method listed first in DirectionMethods.
+//
+// B is config.BootstrapN, or 100 if it is 0. config.DirectionMethod is ignored.
+//
+// Example:
+//
+//	method, scores := RecommendDirectionMethod(Y, X, DefaultConfig())
+//	config.DirectionMethod = method
+func RecommendDirectionMethod(Y []float64, X []float64, config Config) (DirectionMethod, map[DirectionMethod]float64) { //nolint:gocritic // Y/X are standard mathematical notation
+	scores := make(map[DirectionMethod]float64, len(DirectionMethods))
+	if len(Y) != len(X) || len(Y) == 0 {
+		for _, m := range DirectionMethods {
+			scores[m] = 0
+		}
+		return config.DirectionMethod, scores
+	}
+
+	nBoot := config.BootstrapN
+	if nBoot <= 0 {
+		nBoot = defaultRecommendBootstrapN
+	}
+
+	n := len(Y)
+	seed := int64(n * 1000)
+	for i := 0; i < min(n, 10); i++ {
+		seed += int64(Y[i] * 1000)
+	}
+	rng := newRNG(seed)
+
+	// X is sorted once; every resample reads its sorted values off this order
+	// and shares them across all methods.
+	cols := newSortedColumns([][]float64{X})
+	signSum := make(map[DirectionMethod]int, len(DirectionMethods))
+	yBoot := make([]float64, n)
+	xBoot := make([]float64, n)
+	counts := make([]int, n)
+	for b := 0; b < nBoot; b++ {
+		clear(counts)
+		for i := 0; i < n; i++ {
+			idx := rng.Intn(n)
+			yBoot[i] = Y[idx]
+			xBoot[i] = X[idx]
+			counts[idx]++
+		}
+		sortedX := cols.resampled(0, X, counts)
+
+		for _, m := range DirectionMethods {
+			result := computeDirection(yBoot, xBoot, sortedX, m, config)
+			switch {
+			case !result.Valid:
+			case result.Direction > 0:
+				signSum[m]++
+			case result.Direction < 0:
+				signSum[m]--
+			}
+		}
+	}
+
+	best := DirectionMethods[0]
+	bestScore := math.Inf(-1)
+	for _, m := range DirectionMethods {
+		scores[m] = math.Abs(float64(signSum[m])) / float64(nBoot)
+		if scores[m] > bestScore {
+			best, bestScore = m, scores[m]
+		}
+	}
+
+	return best, scores
+}
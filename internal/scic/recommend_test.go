@@ -0,0 +1,129 @@
+package scic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRecommendDirectionMethod_MonotoneNonlinear(t *testing.T) {
+	// Y = exp(3X) + heavy-tailed (Cauchy) noise: monotone but nonlinear,
+	// with outliers that make the linear gradient unstable.
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic for testing
+	n := 400
+	X := make([]float64, n) //nolint:gocritic // X is standard mathematical notation
+	Y := make([]float64, n) //nolint:gocritic // Y is standard mathematical notation
+	for i := range X {
+		X[i] = rng.Float64()
+		Y[i] = math.Exp(3*X[i]) + 8*math.Tan(math.Pi*(rng.Float64()-0.5))
+	}
+
+	method, scores := RecommendDirectionMethod(Y, X, DefaultConfig())
+
+	if len(scores) != len(DirectionMethods) {
+		t.Fatalf("expected %d scores, got %d", len(DirectionMethods), len(scores))
+	}
+	for m, s := range scores {
+		if s < 0 || s > 1 {
+			t.Errorf("method %d: stability %v outside [0, 1]", m, s)
+		}
+	}
+
+	if method != RankMethod {
+		t.Errorf("expected RankMethod, got %d (scores %v)", method, scores)
+	}
+	if scores[RankMethod] <= scores[GradientMethod] {
+		t.Errorf("rank stability (%.2f) should exceed gradient stability (%.2f)",
+			scores[RankMethod], scores[GradientMethod])
+	}
+}
+
+func TestRecommendDirectionMethod_Deterministic(t *testing.T) {
+	rng := rand.New(rand.NewSource(2)) //nolint:gosec // deterministic for testing
+	n := 200
+	X := make([]float64, n) //nolint:gocritic // X is standard mathematical notation
+	Y := make([]float64, n) //nolint:gocritic // Y is standard mathematical notation
+	for i := range X {
+		X[i] = rng.NormFloat64()
+		Y[i] = X[i] + rng.NormFloat64()
+	}
+
+	config := DefaultConfig()
+	config.BootstrapN = 30
+	m1, s1 := RecommendDirectionMethod(Y, X, config)
+	m2, s2 := RecommendDirectionMethod(Y, X, config)
+	if m1 != m2 {
+		t.Errorf("recommendation not deterministic: %d vs %d", m1, m2)
+	}
+	for m := range s1 {
+		if s1[m] != s2[m] {
+			t.Errorf("method %d: scores differ %v vs %v", m, s1[m], s2[m])
+		}
+	}
+}
+
+func TestComputeDirection_Rank(t *testing.T) {
+	// Strictly increasing nonlinear relation: Spearman correlation is exactly 1
+	X := make([]float64, 50) //nolint:gocritic // X is standard mathematical notation
+	Y := make([]float64, 50) //nolint:gocritic // Y is standard mathematical notation
+	for i := range X {
+		X[i] = float64(i)
+		Y[i] = -math.Exp(float64(i) / 5)
+	}
+
+	result := ComputeDirection(Y, X, RankMethod, DefaultConfig())
+	if !result.Valid {
+		t.Fatalf("expected valid result: %s", result.Reason)
+	}
+	if math.Abs(result.Direction+1) > 1e-12 {
+		t.Errorf("expected direction -1, got %v", result.Direction)
+	}
+}
+
+func TestComputeDirection_SpearmanCubic(t *testing.T) {
+	// Y = X^3: monotone but curved, so the Pearson-based GradientMethod
+	// underestimates the strength of the relation
+	rng := rand.New(rand.NewSource(11)) //nolint:gosec // deterministic for testing
+	n := 500
+	X := make([]float64, n) //nolint:gocritic // X is standard mathematical notation
+	Y := make([]float64, n) //nolint:gocritic // Y is standard mathematical notation
+	for i := range X {
+		X[i] = 6*rng.Float64() - 3
+		Y[i] = X[i]*X[i]*X[i] + rng.NormFloat64()
+	}
+
+	spearman := ComputeDirection(Y, X, SpearmanMethod, DefaultConfig())
+	pearson := ComputeDirection(Y, X, GradientMethod, DefaultConfig())
+	if !spearman.Valid || !pearson.Valid {
+		t.Fatalf("expected valid results: %q, %q", spearman.Reason, pearson.Reason)
+	}
+	t.Logf("Spearman %.4f, Pearson %.4f", spearman.Direction, pearson.Direction)
+
+	if spearman.Direction < 0.95 {
+		t.Errorf("Spearman direction = %v, want > 0.95", spearman.Direction)
+	}
+	if spearman.Direction <= pearson.Direction {
+		t.Errorf("Spearman direction %v should exceed Pearson %v", spearman.Direction, pearson.Direction)
+	}
+}
+
+// BenchmarkRecommendDirectionMethod benchmarks the method comparison, which
+// runs every direction method on each bootstrap resample.
+func BenchmarkRecommendDirectionMethod(b *testing.B) {
+	rng := rand.New(rand.NewSource(4)) //nolint:gosec // deterministic for testing
+	n := 2000
+	X := make([]float64, n) //nolint:gocritic // X is standard mathematical notation
+	Y := make([]float64, n) //nolint:gocritic // Y is standard mathematical notation
+	for i := range X {
+		X[i] = rng.Float64() * 10
+		Y[i] = 2*X[i] + rng.NormFloat64()
+	}
+
+	config := DefaultConfig()
+	config.BootstrapN = 50
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RecommendDirectionMethod(Y, X, config)
+	}
+}
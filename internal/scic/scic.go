@@ -17,8 +17,11 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"sort"
+	"runtime"
+	"sync"
 
+	"github.com/causalgo/causalgo/internal/histogram"
+	"github.com/causalgo/causalgo/internal/stats"
 	"github.com/causalgo/causalgo/surd"
 )
 
@@ -34,6 +37,17 @@ const (
 
 	// GradientMethod estimates direction via local gradient (for smooth relationships).
 	GradientMethod
+
+	// BinnedConditionalMeanMethod uses the trend of E[target | source bin] over the
+	// same histogram bins SURD uses, tying the sign to SURD's discretization.
+	BinnedConditionalMeanMethod
+
+	// RankMethod uses the Spearman rank correlation: robust to outliers and
+	// invariant to monotone transformations of either variable.
+	RankMethod
+
+	// SpearmanMethod is an alias of RankMethod.
+	SpearmanMethod = RankMethod
 )
 
 // Config contains parameters for SCIC analysis.
@@ -54,8 +68,50 @@ type Config struct {
 	// MinSamplesPerQuartile is the minimum samples required in each quartile
 	// for reliable direction estimation.
 	MinSamplesPerQuartile int
+
+	// Detrend removes the least-squares linear fit of the target on each source
+	// before computing its direction. The direction then describes the residual
+	// (nonlinear) structure instead of the dominant linear trend.
+	// SURD magnitudes are not affected.
+	Detrend bool
+
+	// WeightByMI weights each variable's direction by its single-variable
+	// mutual information with the target (SURD MutualInfo) when aggregating
+	// pair directions, instead of a plain mean. A pair then leans toward the
+	// direction of its more informative member.
+	WeightByMI bool
+
+	// BootstrapCI additionally reports the central 95% percentile interval
+	// of each variable's bootstrap directions in Result.DirectionCI.
+	// Requires BootstrapN > 0.
+	BootstrapCI bool
+
+	// Workers is the number of goroutines running bootstrap iterations.
+	// <= 0 means runtime.GOMAXPROCS(0). Results do not depend on Workers.
+	Workers int
+
+	// DispersionEpsilon is the relative threshold below which the combined
+	// dispersion of the two groups compared by the quartile and median-split
+	// methods is treated as zero: the threshold is DispersionEpsilon times the
+	// dispersion of all Y values, so the degenerate branch behaves the same at
+	// any data scale. 0 uses DefaultDispersionEpsilon.
+	DispersionEpsilon float64
+
+	// Circular marks circular (angular) variables in radians, e.g. phases, in
+	// the same layout as Bins: index 0 is the target, i+1 is source i. nil
+	// means none; otherwise len must be len(X)+1. Circular variables are
+	// binned with wrap-around for SURD. The direction of a source is the
+	// circular correlation (stats.CircularCorrelation) when both it and the
+	// target are circular; a circular source of a linear target, or the
+	// reverse, has no direction (reported as 0), since "increase" is not
+	// defined on a circle.
+	Circular []bool
 }
 
+// DefaultDispersionEpsilon is the relative zero-dispersion threshold used when
+// Config.DispersionEpsilon is 0.
+const DefaultDispersionEpsilon = 1e-10
+
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() Config {
 	return Config{
@@ -86,14 +142,67 @@ type Result struct {
 	// 1 = no conflict (same direction)
 	Conflicts map[string]float64
 
+	// SignAgreement and MagnitudeSimilarity split each pair's conflict into
+	// its sign and magnitude parts (see ConflictComponents).
+	// Key format: "0,1" for pair of variables 0 and 1.
+	SignAgreement       map[string]float64
+	MagnitudeSimilarity map[string]float64
+
 	// Confidence maps variable keys to statistical confidence [0, 1].
 	// Only populated if BootstrapN > 0 in config.
+	// NaN means no bootstrap resample gave a valid direction for the variable
+	// (insufficient data), as opposed to a low value (unstable sign).
 	Confidence map[string]float64
 
+	// BootstrapDirections maps variable keys to the median and spread of the
+	// direction across bootstrap resamples. The median is a robust alternative
+	// to the point estimate in Directions.
+	// Only populated if BootstrapN > 0 in config; variables without any valid
+	// resample are omitted.
+	BootstrapDirections map[string]BootstrapDirection
+
+	// ConflictConfidence maps variable pair keys to the central 95% bootstrap
+	// interval of the conflict index. A low, narrow interval means the sources
+	// robustly oppose each other; an interval reaching 1 means the conflict
+	// may be a sampling artifact.
+	// Only populated if BootstrapN > 0 in config.
+	ConflictConfidence map[string]ConflictInterval
+
+	// DirectionCI maps single-variable keys to the 2.5th and 97.5th
+	// percentiles of the bootstrap directions, a 95% confidence interval
+	// for Directions. Only populated if BootstrapCI is set and BootstrapN > 0;
+	// variables without any valid resample are omitted.
+	DirectionCI map[string][2]float64
+
 	// NumVariables is the number of source variables analyzed.
 	NumVariables int
 }
 
+// BootstrapDirection summarizes the bootstrap distribution of a direction.
+type BootstrapDirection struct {
+	// Median is the median direction over valid bootstrap resamples.
+	Median float64
+
+	// MAD is the median absolute deviation of the bootstrap directions,
+	// scaled to be consistent with the standard deviation (see mad).
+	MAD float64
+
+	// N is the number of resamples with a valid direction.
+	N int
+}
+
+// ConflictInterval is a bootstrap percentile interval of a conflict index.
+type ConflictInterval struct {
+	// Lower and Upper are the 2.5th and 97.5th percentiles of the conflict
+	// index across bootstrap resamples.
+	Lower, Upper float64
+}
+
+// Width returns Upper - Lower.
+func (c ConflictInterval) Width() float64 {
+	return c.Upper - c.Lower
+}
+
 // DirectionResult contains the output of direction computation for a single variable.
 type DirectionResult struct {
 	// Direction is the estimated directional influence [-1, +1].
@@ -143,18 +252,23 @@ func Decompose(Y []float64, X [][]float64, config Config) (*Result, error) { //n
 		return nil, fmt.Errorf("bins length (%d) must be 1 or %d (target + sources)", len(bins), p+1)
 	}
 
+	if config.Circular != nil && len(config.Circular) != p+1 {
+		return nil, fmt.Errorf("circular length (%d) must be %d (target + sources)", len(config.Circular), p+1)
+	}
+
 	// Step 1: Compute SURD decomposition
-	data := formatDataForSURD(Y, X)
-	surdResult, err := surd.DecomposeFromData(data, bins)
+	surdResult, err := decomposeSURD(formatDataForSURD(Y, X), bins, config.Circular)
 	if err != nil {
 		return nil, fmt.Errorf("SURD decomposition failed: %w", err)
 	}
 
-	// Step 2: Compute directions for each source variable
+	// Step 2: Compute directions for each source variable. Each column is
+	// sorted once here and reused by the direction methods and the bootstrap.
+	cols := newSortedColumns(X)
 	directions := make(map[string]float64)
 	for i := 0; i < p; i++ {
 		key := fmt.Sprintf("%d", i)
-		dirResult := ComputeDirection(Y, X[i], config.DirectionMethod, config) //nolint:gosec // G602: i is bounded by p=len(X)
+		dirResult := computeSourceDirection(Y, X[i], cols.values(i, X[i]), i, config) //nolint:gosec // G602: i is bounded by p=len(X)
 		if dirResult.Valid {
 			directions[key] = dirResult.Direction
 		} else {
@@ -166,29 +280,54 @@ func Decompose(Y []float64, X [][]float64, config Config) (*Result, error) { //n
 	for i := 0; i < p; i++ {
 		for j := i + 1; j < p; j++ {
 			key := fmt.Sprintf("%d,%d", i, j)
-			aggDir := aggregateDirections(directions[fmt.Sprintf("%d", i)], directions[fmt.Sprintf("%d", j)])
-			directions[key] = aggDir
+			ki, kj := fmt.Sprintf("%d", i), fmt.Sprintf("%d", j)
+			if config.WeightByMI {
+				directions[key] = weightedAggregateDirections(
+					[]float64{directions[ki], directions[kj]},
+					[]float64{surdResult.MutualInfo[ki], surdResult.MutualInfo[kj]})
+			} else {
+				directions[key] = aggregateDirections(directions[ki], directions[kj])
+			}
 		}
 	}
 
 	// Step 4: Compute conflicts between variable pairs
 	conflicts := ComputeConflicts(directions, p)
+	signAgreement, magnitudeSimilarity := ComputeConflictComponents(directions, p)
 
 	// Step 5: Bootstrap confidence (if enabled)
-	var confidence map[string]float64
+	var boot bootstrapSummary
 	if config.BootstrapN > 0 {
-		confidence = bootstrapConfidence(Y, X, config)
+		boot = bootstrapConfidence(Y, X, cols, config)
 	}
 
 	return &Result{
-		SURD:         surdResult,
-		Directions:   directions,
-		Conflicts:    conflicts,
-		Confidence:   confidence,
-		NumVariables: p,
+		SURD:                surdResult,
+		Directions:          directions,
+		Conflicts:           conflicts,
+		SignAgreement:       signAgreement,
+		MagnitudeSimilarity: magnitudeSimilarity,
+		Confidence:          boot.confidence,
+		BootstrapDirections: boot.directions,
+		ConflictConfidence:  boot.conflicts,
+		DirectionCI:         boot.directionCI,
+		NumVariables:        p,
 	}, nil
 }
 
+// decomposeSURD runs SURD on data, binning the circular variables with
+// wrap-around.
+func decomposeSURD(data [][]float64, bins []int, circular []bool) (*surd.Result, error) {
+	if circular == nil {
+		return surd.DecomposeFromData(data, bins)
+	}
+	hist, err := histogram.NewNDHistogramWithOptions(data, bins, histogram.Options{Circular: circular})
+	if err != nil {
+		return nil, err
+	}
+	return surd.Decompose(hist)
+}
+
 // ComputeDirection estimates the directional influence of X on Y.
 //
 // The direction quantifies whether increases in X tend to cause increases (+)
@@ -200,27 +339,161 @@ func Decompose(Y []float64, X [][]float64, config Config) (*Result, error) { //n
 //   - method: direction estimation method
 //   - config: algorithm configuration
 func ComputeDirection(Y, X []float64, method DirectionMethod, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+	return computeDirection(Y, X, nil, method, config)
+}
+
+// computeDirection is ComputeDirection with an optional sortedX, the values of
+// X in ascending order. The quartile and median split methods read their
+// thresholds from sortedX and sort X themselves when it is nil.
+func computeDirection(Y, X, sortedX []float64, method DirectionMethod, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
 	if len(Y) != len(X) {
 		return DirectionResult{Valid: false, Reason: "Y and X have different lengths"}
 	}
+	if config.Detrend {
+		Y = linearResiduals(Y, X)
+	}
 
 	switch method {
 	case QuartileMethod:
-		return computeQuartileDirection(Y, X, config)
+		return computeQuartileDirection(Y, X, sortedX, config)
 	case MedianSplitMethod:
-		return computeMedianSplitDirection(Y, X, config)
+		return computeMedianSplitDirection(Y, X, sortedX, config)
 	case GradientMethod:
 		return computeGradientDirection(Y, X, config)
+	case BinnedConditionalMeanMethod:
+		bins := defaultDirectionBins
+		if len(config.Bins) > 0 {
+			bins = config.Bins[0]
+		}
+		return ComputeBinnedDirection(Y, X, bins, bins, config)
+	case RankMethod:
+		return computeRankDirection(Y, X)
 	default:
-		return computeQuartileDirection(Y, X, config)
+		return computeQuartileDirection(Y, X, sortedX, config)
+	}
+}
+
+// defaultDirectionBins is used by BinnedConditionalMeanMethod when config.Bins is empty.
+const defaultDirectionBins = 10
+
+// computeSourceDirection computes the direction of source variable sourceIdx.
+// For BinnedConditionalMeanMethod it uses the per-variable bins from config,
+// matching the histogram used for SURD. sortedX is passed to computeDirection.
+func computeSourceDirection(Y, X, sortedX []float64, sourceIdx int, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+	if config.Circular != nil && (config.Circular[0] || config.Circular[sourceIdx+1]) {
+		return computeCircularDirection(Y, X, config.Circular[0] && config.Circular[sourceIdx+1])
+	}
+	if config.DirectionMethod == BinnedConditionalMeanMethod && len(config.Bins) > 1 && sourceIdx+1 < len(config.Bins) {
+		if config.Detrend && len(Y) == len(X) {
+			Y = linearResiduals(Y, X)
+		}
+		return ComputeBinnedDirection(Y, X, config.Bins[0], config.Bins[sourceIdx+1], config)
+	}
+	return computeDirection(Y, X, sortedX, config.DirectionMethod, config)
+}
+
+// computeCircularDirection estimates direction when the target or the source
+// is circular. Between two circular variables it is the Jammalamadaka-SenGupta
+// circular correlation: +1 when the target phase advances with the source
+// phase, -1 when it recedes. Otherwise the direction is undefined.
+func computeCircularDirection(Y, X []float64, bothCircular bool) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+	if !bothCircular {
+		return DirectionResult{Valid: false, Reason: "direction undefined between circular and linear variables"}
+	}
+	if len(Y) < 10 {
+		return DirectionResult{Valid: false, Reason: "insufficient samples for circular correlation"}
+	}
+
+	corr := stats.CircularCorrelation(X, Y)
+	if math.IsNaN(corr) {
+		return DirectionResult{Valid: false, Reason: "circular mean undefined"}
+	}
+
+	return DirectionResult{Direction: corr, Valid: true}
+}
+
+// ComputeBinnedDirection estimates direction from the conditional target means
+// per source bin of the joint histogram P(Y, X) that SURD uses.
+//
+// For each source bin with at least config.MinSamplesPerQuartile samples, the
+// conditional mean of the target bin index E[Y bin | X bin] is computed. The
+// direction is the weighted monotone trend of these means across source bins:
+//
+//	D = Σ_{a<b} w_a w_b sign(m_b - m_a) / Σ_{a<b} w_a w_b
+//
+// where w is the source bin probability. D = +1 if the means increase across
+// all bin pairs, -1 if they all decrease, and near 0 for non-monotone
+// (e.g. U-shaped) relationships.
+//
+// Parameters:
+//   - Y: target variable values
+//   - X: source variable values
+//   - targetBins, sourceBins: histogram bins (as in SURD)
+//   - config: algorithm configuration
+func ComputeBinnedDirection(Y, X []float64, targetBins, sourceBins int, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+	if len(Y) != len(X) {
+		return DirectionResult{Valid: false, Reason: "Y and X have different lengths"}
+	}
+	n := len(Y)
+	if n < 2*config.MinSamplesPerQuartile || n < 2 {
+		return DirectionResult{Valid: false, Reason: fmt.Sprintf("insufficient samples: %d", n)}
+	}
+
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = []float64{Y[i], X[i]}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{targetBins, sourceBins})
+	if err != nil {
+		return DirectionResult{Valid: false, Reason: fmt.Sprintf("histogram failed: %v", err)}
 	}
+	probs := hist.Probabilities()
+
+	// Conditional mean of the target bin index for each populated source bin.
+	// Equal-width bins make the bin index an affine function of the bin center,
+	// so the trend sign matches that of E[Y | X bin].
+	minMass := float64(max(config.MinSamplesPerQuartile, 1)) / float64(n)
+	var means, weights []float64
+	for sx := 0; sx < sourceBins; sx++ {
+		mass, moment := 0.0, 0.0
+		for ty := 0; ty < targetBins; ty++ {
+			p := probs[ty*sourceBins+sx]
+			mass += p
+			moment += float64(ty) * p
+		}
+		if mass < minMass {
+			continue
+		}
+		means = append(means, moment/mass)
+		weights = append(weights, mass)
+	}
+
+	if len(means) < 2 {
+		return DirectionResult{Valid: false, Reason: fmt.Sprintf("insufficient populated source bins: %d", len(means))}
+	}
+
+	trend, total := 0.0, 0.0
+	for a := 0; a < len(means); a++ {
+		for b := a + 1; b < len(means); b++ {
+			w := weights[a] * weights[b]
+			total += w
+			switch {
+			case means[b] > means[a]:
+				trend += w
+			case means[b] < means[a]:
+				trend -= w
+			}
+		}
+	}
+
+	return DirectionResult{Direction: clamp(trend/total, -1.0, 1.0), Valid: true}
 }
 
 // computeQuartileDirection estimates direction using 25th/75th percentile comparison.
 //
 // This is the most robust method, comparing Y values when X is in the high quartile
 // vs. low quartile. The direction is normalized by standard deviation for comparability.
-func computeQuartileDirection(Y, X []float64, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+func computeQuartileDirection(Y, X, sortedX []float64, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
 	n := len(Y)
 	if n < 4*config.MinSamplesPerQuartile {
 		return DirectionResult{
@@ -230,7 +503,10 @@ func computeQuartileDirection(Y, X []float64, config Config) DirectionResult { /
 	}
 
 	// Compute quartiles of X
-	q25, q75 := quantiles(X, 0.25, 0.75)
+	if sortedX == nil {
+		sortedX = sortedCopy(X)
+	}
+	q25, q75 := sortedQuantiles(sortedX, 0.25, 0.75)
 
 	// Extract Y values for low and high X quartiles
 	var yLow, yHigh []float64
@@ -264,9 +540,34 @@ func computeQuartileDirection(Y, X []float64, config Config) DirectionResult { /
 		sigmaHigh = stddev(yHigh)
 	}
 
+	return groupContrast(muLow, muHigh, sigmaLow, sigmaHigh, dispersionThreshold(Y, config))
+}
+
+// dispersionThreshold returns the combined group dispersion below which the
+// groups are treated as having zero spread, relative to the spread of all Y.
+func dispersionThreshold(Y []float64, config Config) float64 { //nolint:gocritic // Y is standard mathematical notation
+	eps := config.DispersionEpsilon
+	if eps <= 0 {
+		eps = DefaultDispersionEpsilon
+	}
+
+	scale := stddev(Y)
+	if config.RobustStats {
+		// MAD is 0 when most values are equal; fall back to std
+		if m := mad(Y); m > 0 {
+			scale = m
+		}
+	}
+	return eps * scale
+}
+
+// groupContrast returns the normalized difference between the high and low
+// groups, (muHigh - muLow) / (sigmaLow + sigmaHigh), clamped to [-1, +1].
+// A combined dispersion at or below threshold is treated as zero.
+func groupContrast(muLow, muHigh, sigmaLow, sigmaHigh, threshold float64) DirectionResult {
 	// Handle degenerate case
 	sigmaCombined := sigmaLow + sigmaHigh
-	if sigmaCombined < 1e-10 {
+	if sigmaCombined <= threshold {
 		// Both quartiles have zero variance - check if means differ
 		if muHigh > muLow {
 			return DirectionResult{Direction: 1.0, Valid: true}
@@ -286,7 +587,7 @@ func computeQuartileDirection(Y, X []float64, config Config) DirectionResult { /
 }
 
 // computeMedianSplitDirection estimates direction using median split.
-func computeMedianSplitDirection(Y, X []float64, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+func computeMedianSplitDirection(Y, X, sortedX []float64, config Config) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
 	n := len(Y)
 	if n < 2*config.MinSamplesPerQuartile {
 		return DirectionResult{
@@ -295,7 +596,10 @@ func computeMedianSplitDirection(Y, X []float64, config Config) DirectionResult
 		}
 	}
 
-	medX := median(X)
+	if sortedX == nil {
+		sortedX = sortedCopy(X)
+	}
+	medX := sortedMedian(sortedX)
 
 	var yLow, yHigh []float64
 	for i, x := range X {
@@ -323,20 +627,7 @@ func computeMedianSplitDirection(Y, X []float64, config Config) DirectionResult
 		sigmaHigh = stddev(yHigh)
 	}
 
-	sigmaCombined := sigmaLow + sigmaHigh
-	if sigmaCombined < 1e-10 {
-		if muHigh > muLow {
-			return DirectionResult{Direction: 1.0, Valid: true}
-		} else if muHigh < muLow {
-			return DirectionResult{Direction: -1.0, Valid: true}
-		}
-		return DirectionResult{Direction: 0.0, Valid: true}
-	}
-
-	direction := (muHigh - muLow) / sigmaCombined
-	direction = clamp(direction, -1.0, 1.0)
-
-	return DirectionResult{Direction: direction, Valid: true}
+	return groupContrast(muLow, muHigh, sigmaLow, sigmaHigh, dispersionThreshold(Y, config))
 }
 
 // computeGradientDirection estimates direction using local gradient.
@@ -356,6 +647,22 @@ func computeGradientDirection(Y, X []float64, config Config) DirectionResult { /
 	return DirectionResult{Direction: corr, Valid: true}
 }
 
+// computeRankDirection estimates direction as the Spearman rank correlation.
+// Monotone nonlinear relationships give |direction| near 1 and single outliers
+// have bounded influence.
+func computeRankDirection(Y, X []float64) DirectionResult { //nolint:gocritic // Y/X are standard mathematical notation
+	if len(Y) < 10 {
+		return DirectionResult{Valid: false, Reason: "insufficient samples for rank correlation"}
+	}
+
+	corr := pearsonCorrelation(stats.RankTransform(X, stats.TieAverage), stats.RankTransform(Y, stats.TieAverage))
+	if math.IsNaN(corr) {
+		return DirectionResult{Direction: 0, Valid: true}
+	}
+
+	return DirectionResult{Direction: corr, Valid: true}
+}
+
 // ComputeConflicts calculates conflict indices for all variable pairs.
 //
 // The conflict index measures whether two variables have opposing directional
@@ -379,6 +686,139 @@ func ComputeConflicts(directions map[string]float64, numVars int) map[string]flo
 	return conflicts
 }
 
+// ConflictComponents separates the two things the conflict index mixes:
+// whether two directions have the same sign, and whether they have similar size.
+//
+//	signAgreement       = 1 if d1 and d2 have the same sign, 0 if opposite
+//	magnitudeSimilarity = min(|d1|, |d2|) / max(|d1|, |d2|)
+//
+// A direction with no effect (|d| < 1e-10) agrees in sign with anything, as in
+// the conflict index; two such directions are fully similar in magnitude.
+//
+// Example:
+//
+//	ConflictComponents(0.9, 0.1)  // 1, 0.11: same sign, dissimilar strength
+//	ConflictComponents(0.5, -0.5) // 0, 1: opposite signs, equal strength
+func ConflictComponents(d1, d2 float64) (signAgreement float64, magnitudeSimilarity float64) {
+	a1, a2 := math.Abs(d1), math.Abs(d2)
+
+	signAgreement = 1.0
+	if a1 >= 1e-10 && a2 >= 1e-10 && (d1 > 0) != (d2 > 0) {
+		signAgreement = 0
+	}
+
+	hi := math.Max(a1, a2)
+	if hi < 1e-10 {
+		return signAgreement, 1.0
+	}
+	return signAgreement, math.Min(a1, a2) / hi
+}
+
+// ComputeConflictComponents applies ConflictComponents to all variable pairs.
+// Keys are pair keys as in ComputeConflicts.
+func ComputeConflictComponents(directions map[string]float64, numVars int) (signAgreement, magnitudeSimilarity map[string]float64) {
+	signAgreement = make(map[string]float64)
+	magnitudeSimilarity = make(map[string]float64)
+
+	for i := 0; i < numVars; i++ {
+		for j := i + 1; j < numVars; j++ {
+			keyPair := fmt.Sprintf("%d,%d", i, j)
+			signAgreement[keyPair], magnitudeSimilarity[keyPair] = ConflictComponents(
+				directions[fmt.Sprintf("%d", i)], directions[fmt.Sprintf("%d", j)])
+		}
+	}
+
+	return signAgreement, magnitudeSimilarity
+}
+
+// SystemCoherence summarizes all pairwise conflicts into a single score in [0, 1].
+//
+// The score is the average of the pair conflict indices weighted by the product
+// of the pair's direction magnitudes:
+//
+//	coherence = Σ |d_i|·|d_j|·conflict_ij / Σ |d_i|·|d_j|
+//
+// so strong-but-opposing pairs dominate while pairs involving a source with
+// no directional effect contribute little.
+//
+// Near 1 means sources mostly agree (coherent), near 0 means they mostly
+// oppose each other (conflicted). Returns 1 if there are no pairs or all
+// pair weights are zero, matching the "no conflict" convention of computeConflict.
+func SystemCoherence(result *Result) float64 {
+	if result == nil {
+		return 1.0
+	}
+
+	var weightedSum, totalWeight float64
+	for i := 0; i < result.NumVariables; i++ {
+		for j := i + 1; j < result.NumVariables; j++ {
+			dirI := result.Directions[fmt.Sprintf("%d", i)]
+			dirJ := result.Directions[fmt.Sprintf("%d", j)]
+
+			conflict, ok := result.Conflicts[fmt.Sprintf("%d,%d", i, j)]
+			if !ok {
+				conflict = computeConflict(dirI, dirJ)
+			}
+
+			weight := math.Abs(dirI) * math.Abs(dirJ)
+			weightedSum += weight * conflict
+			totalWeight += weight
+		}
+	}
+
+	if totalWeight < 1e-10 {
+		return 1.0
+	}
+	return weightedSum / totalWeight
+}
+
+// noEffectTolerance is the largest |direction| that DirectionAccuracy accepts
+// as matching a ground-truth sign of 0 ("no effect").
+const noEffectTolerance = 0.1
+
+// DirectionAccuracy returns the fraction of sources in truth whose estimated
+// direction sign matches the ground-truth sign.
+//
+// truth maps source keys ("0", "1", ...) to the true effect sign: +1
+// (facilitative), -1 (inhibitory) or 0 (no effect). A +1/-1 source matches if
+// its direction has that sign; a 0 source matches if |direction| <= 0.1.
+//
+// Returns an error if truth is empty, contains a sign other than -1, 0 or +1,
+// or names a source without an estimated direction.
+//
+// Example:
+//
+//	result, _ := Decompose(Y, X, DefaultConfig())
+//	acc, err := DirectionAccuracy(result, map[string]int{"0": +1, "1": -1})
+func DirectionAccuracy(result *Result, truth map[string]int) (float64, error) {
+	if result == nil {
+		return 0, fmt.Errorf("result is nil")
+	}
+	if len(truth) == 0 {
+		return 0, fmt.Errorf("truth is empty")
+	}
+
+	correct := 0
+	for key, sign := range truth {
+		if sign < -1 || sign > 1 {
+			return 0, fmt.Errorf("truth sign for %q must be -1, 0 or +1, got %d", key, sign)
+		}
+		direction, ok := result.Directions[key]
+		if !ok {
+			return 0, fmt.Errorf("no estimated direction for source %q", key)
+		}
+
+		switch {
+		case sign == 0 && math.Abs(direction) <= noEffectTolerance,
+			sign > 0 && direction > 0,
+			sign < 0 && direction < 0:
+			correct++
+		}
+	}
+
+	return float64(correct) / float64(len(truth)), nil
+}
+
 // computeConflict calculates the conflict index between two directions.
 //
 // Conflict = |d1 + d2| / (|d1| + |d2|)
@@ -392,7 +832,7 @@ func computeConflict(d1, d2 float64) float64 {
 }
 
 // aggregateDirections combines multiple directions into a single aggregate.
-// Uses simple averaging (could be extended to MI-weighted averaging).
+// Uses simple averaging; see weightedAggregateDirections for MI weighting.
 func aggregateDirections(directions ...float64) float64 {
 	if len(directions) == 0 {
 		return 0
@@ -404,6 +844,22 @@ func aggregateDirections(directions ...float64) float64 {
 	return sum / float64(len(directions))
 }
 
+// weightedAggregateDirections combines directions as a weighted mean, e.g.
+// with each variable's mutual information as weight. Negative weights count
+// as zero. Falls back to the plain mean when all weights are zero.
+func weightedAggregateDirections(directions, weights []float64) float64 {
+	var sum, total float64
+	for i, d := range directions {
+		w := math.Max(weights[i], 0)
+		sum += w * d
+		total += w
+	}
+	if total == 0 {
+		return aggregateDirections(directions...)
+	}
+	return sum / total
+}
+
 // bootstrapConfidence estimates confidence via bootstrap resampling.
 //
 // For each variable, the confidence is computed as the proportion of bootstrap
@@ -413,95 +869,189 @@ func aggregateDirections(directions ...float64) float64 {
 //
 // The algorithm:
 // 1. For each bootstrap iteration:
-//   - Resample (Y, X) with replacement
-//   - Recompute directions for all variables
+//   - Resample (Y, X) with replacement; indices are drawn serially from one
+//     seeded RNG stream, so results match the sequential loop
+//   - Recompute directions for all variables (in parallel, config.Workers
+//     goroutines)
 //
 // 2. For each variable:
 //   - Count how often the bootstrap direction sign matches the original
 //   - Confidence = (count of sign matches) / (total bootstrap samples)
 //
-// Returns map[variableKey]confidence where confidence is in [0, 1].
-func bootstrapConfidence(Y []float64, X [][]float64, config Config) map[string]float64 { //nolint:gocritic // Y/X are standard mathematical notation
+// Returns a bootstrapSummary: the confidence of each variable in [0, 1], or
+// NaN if no bootstrap iteration produced a valid direction for the variable,
+// the median/MAD of the valid bootstrap directions for each variable, the
+// 2.5/97.5 percentile interval of each pair's conflict index and, with
+// config.BootstrapCI, of each variable's direction. Conflicts are recomputed
+// in every iteration from that iteration's directions, with invalid
+// directions set to 0 as in Decompose.
+func bootstrapConfidence(Y []float64, X [][]float64, cols sortedColumns, config Config) bootstrapSummary { //nolint:gocritic // Y/X are standard mathematical notation
 	n := len(Y)
 	p := len(X)
 
+	summary := bootstrapSummary{
+		confidence: make(map[string]float64),
+		directions: make(map[string]BootstrapDirection),
+		conflicts:  make(map[string]ConflictInterval),
+	}
+	if config.BootstrapCI {
+		summary.directionCI = make(map[string][2]float64)
+	}
 	if config.BootstrapN <= 0 || n < 4*config.MinSamplesPerQuartile {
-		return make(map[string]float64)
+		return summary
 	}
 
 	// First compute original directions
 	originalDirs := make(map[string]float64)
 	for i := 0; i < p; i++ {
 		key := fmt.Sprintf("%d", i)
-		result := ComputeDirection(Y, X[i], config.DirectionMethod, config)
+		result := computeSourceDirection(Y, X[i], cols.values(i, X[i]), i, config)
 		if result.Valid {
 			originalDirs[key] = result.Direction
 		}
 	}
 
-	// Count sign agreements for each variable across bootstrap samples
-	signAgree := make(map[string]int)
-	validCounts := make(map[string]int)
-
 	// Create a local random source for reproducible bootstrap
 	// Use a deterministic seed based on data characteristics
 	seed := int64(n*1000 + p*100)
 	for i := 0; i < min(n, 10); i++ {
 		seed += int64(Y[i] * 1000)
 	}
-	rng := newRNG(seed)
 
-	// Bootstrap iterations
-	for b := 0; b < config.BootstrapN; b++ {
+	// Resample indices come from a single RNG stream, drawn in iteration
+	// order before each iteration is handed to a worker; each iteration then
+	// writes to its own slot, so the outcome does not depend on the number
+	// of workers or scheduling.
+	rng := newRNG(seed)
+	iterations := make([]bootstrapIteration, config.BootstrapN)
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for b := range iterations {
 		// Generate bootstrap indices (resample with replacement)
 		indices := make([]int, n)
 		for i := 0; i < n; i++ {
 			indices[i] = rng.Intn(n)
 		}
 
-		// Create resampled data
-		yBoot := make([]float64, n)
-		xBoot := make([][]float64, p)
-		for j := 0; j < p; j++ {
-			xBoot[j] = make([]float64, n)
-		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			iterations[b] = bootstrapOnce(Y, X, cols, config, indices)
+		}(b)
+	}
+	wg.Wait()
 
-		for i, idx := range indices {
-			yBoot[i] = Y[idx]
-			for j := 0; j < p; j++ {
-				xBoot[j][i] = X[j][idx]
+	// Count sign agreements for each variable across bootstrap samples
+	signAgree := make(map[string]int)
+	validCounts := make(map[string]int)
+	bootDirs := make(map[string][]float64)
+	bootConflicts := make(map[string][]float64)
+	for _, it := range iterations {
+		for key, bootDir := range it.directions {
+			validCounts[key]++
+			bootDirs[key] = append(bootDirs[key], bootDir)
+			// Check if signs agree (or both are near zero)
+			if signsAgree(originalDirs[key], bootDir) {
+				signAgree[key]++
 			}
 		}
-
-		// Compute directions on bootstrap sample
-		for i := 0; i < p; i++ {
-			key := fmt.Sprintf("%d", i)
-			bootResult := ComputeDirection(yBoot, xBoot[i], config.DirectionMethod, config)
-			if bootResult.Valid {
-				validCounts[key]++
-				// Check if signs agree (or both are near zero)
-				origDir := originalDirs[key]
-				bootDir := bootResult.Direction
-
-				if signsAgree(origDir, bootDir) {
-					signAgree[key]++
-				}
-			}
+		for key, c := range it.conflicts {
+			bootConflicts[key] = append(bootConflicts[key], c)
 		}
 	}
 
 	// Compute confidence as proportion of sign agreements
-	confidence := make(map[string]float64)
+	confidence, summaries := summary.confidence, summary.directions
 	for i := 0; i < p; i++ {
 		key := fmt.Sprintf("%d", i)
 		if validCounts[key] > 0 {
 			confidence[key] = float64(signAgree[key]) / float64(validCounts[key])
+			summaries[key] = BootstrapDirection{
+				Median: median(bootDirs[key]),
+				MAD:    mad(bootDirs[key]),
+				N:      validCounts[key],
+			}
+			if config.BootstrapCI {
+				lower, upper := quantiles(bootDirs[key], 0.025, 0.975)
+				summary.directionCI[key] = [2]float64{lower, upper}
+			}
 		} else {
-			confidence[key] = 0.0
+			// No valid resample: unreliable, not "random sign" (which is ~0.5)
+			confidence[key] = math.NaN()
+		}
+	}
+
+	for key, values := range bootConflicts {
+		lower, upper := quantiles(values, 0.025, 0.975)
+		summary.conflicts[key] = ConflictInterval{Lower: lower, Upper: upper}
+	}
+
+	return summary
+}
+
+// bootstrapSummary holds the outputs of bootstrapConfidence, one field per
+// Result field.
+type bootstrapSummary struct {
+	confidence  map[string]float64
+	directions  map[string]BootstrapDirection
+	conflicts   map[string]ConflictInterval
+	directionCI map[string][2]float64 // nil unless Config.BootstrapCI
+}
+
+// bootstrapIteration holds the outcome of one bootstrap resample.
+type bootstrapIteration struct {
+	directions map[string]float64 // valid source directions only
+	conflicts  map[string]float64 // pair conflicts, invalid directions as 0
+}
+
+// bootstrapOnce builds the resample of (Y, X) at indices and recomputes the
+// source directions and pair conflicts. The sorted resampled columns are read
+// off cols instead of being sorted again.
+func bootstrapOnce(Y []float64, X [][]float64, cols sortedColumns, config Config, indices []int) bootstrapIteration { //nolint:gocritic // Y/X are standard mathematical notation
+	n := len(Y)
+	p := len(X)
+
+	counts := make([]int, n)
+	for _, idx := range indices {
+		counts[idx]++
+	}
+
+	// Create resampled data
+	yBoot := make([]float64, n)
+	xBoot := make([][]float64, p)
+	for j := 0; j < p; j++ {
+		xBoot[j] = make([]float64, n)
+	}
+	for i, idx := range indices {
+		yBoot[i] = Y[idx]
+		for j := 0; j < p; j++ {
+			xBoot[j][i] = X[j][idx]
+		}
+	}
+
+	// Compute directions on bootstrap sample
+	valid := make(map[string]float64, p)
+	iterDirs := make(map[string]float64, p)
+	for i := 0; i < p; i++ {
+		key := fmt.Sprintf("%d", i)
+		bootResult := computeSourceDirection(yBoot, xBoot[i], cols.resampled(i, X[i], counts), i, config)
+		iterDirs[key] = 0
+		if bootResult.Valid {
+			iterDirs[key] = bootResult.Direction
+			valid[key] = bootResult.Direction
 		}
 	}
 
-	return confidence
+	return bootstrapIteration{
+		directions: valid,
+		conflicts:  ComputeConflicts(iterDirs, p),
+	}
 }
 
 // signsAgree returns true if two directions have the same sign or both are near zero.
@@ -552,14 +1102,18 @@ func formatDataForSURD(Y []float64, X [][]float64) [][]float64 { //nolint:gocrit
 
 // quantiles returns the specified percentiles of the data.
 func quantiles(data []float64, q1, q2 float64) (float64, float64) {
-	n := len(data)
-	if n == 0 {
+	if len(data) == 0 {
 		return 0, 0
 	}
+	return sortedQuantiles(sortedCopy(data), q1, q2)
+}
 
-	sorted := make([]float64, n)
-	copy(sorted, data)
-	sort.Float64s(sorted)
+// sortedQuantiles returns the specified percentiles of already sorted data.
+func sortedQuantiles(sorted []float64, q1, q2 float64) (float64, float64) {
+	n := len(sorted)
+	if n == 0 {
+		return 0, 0
+	}
 
 	idx1 := int(q1 * float64(n-1))
 	idx2 := int(q2 * float64(n-1))
@@ -569,14 +1123,18 @@ func quantiles(data []float64, q1, q2 float64) (float64, float64) {
 
 // median returns the median of the data.
 func median(data []float64) float64 {
-	n := len(data)
-	if n == 0 {
+	if len(data) == 0 {
 		return 0
 	}
+	return sortedMedian(sortedCopy(data))
+}
 
-	sorted := make([]float64, n)
-	copy(sorted, data)
-	sort.Float64s(sorted)
+// sortedMedian returns the median of already sorted data.
+func sortedMedian(sorted []float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
 
 	if n%2 == 0 {
 		return (sorted[n/2-1] + sorted[n/2]) / 2
@@ -658,6 +1216,31 @@ func pearsonCorrelation(x, y []float64) float64 {
 	return sumXY / denom
 }
 
+// linearResiduals returns Y minus its least-squares linear fit on X.
+// If X has zero variance, Y is only centered.
+func linearResiduals(Y, X []float64) []float64 { //nolint:gocritic // Y/X are standard mathematical notation
+	meanX := mean(X)
+	meanY := mean(Y)
+
+	var sumXY, sumX2 float64
+	for i := range X {
+		dx := X[i] - meanX
+		sumXY += dx * (Y[i] - meanY)
+		sumX2 += dx * dx
+	}
+
+	slope := 0.0
+	if sumX2 > 1e-10 {
+		slope = sumXY / sumX2
+	}
+
+	residuals := make([]float64, len(Y))
+	for i := range Y {
+		residuals[i] = Y[i] - meanY - slope*(X[i]-meanX)
+	}
+	return residuals
+}
+
 // clamp restricts value to the range [min, max].
 func clamp(value, min, max float64) float64 {
 	if value < min {
@@ -192,6 +192,53 @@ func TestComputeConflict_MixedDirections(t *testing.T) {
 	t.Logf("Mixed directions conflict: %f", conflict)
 }
 
+// TestConflictComponents separates sign agreement from magnitude similarity.
+func TestConflictComponents(t *testing.T) {
+	tests := []struct {
+		name           string
+		d1, d2         float64
+		wantSign       float64
+		wantSimilarity float64
+	}{
+		{"same sign, dissimilar magnitude", 0.9, 0.1, 1, 0.1 / 0.9},
+		{"same sign, equal magnitude", 0.5, 0.5, 1, 1},
+		{"opposite sign, equal magnitude", 0.5, -0.5, 0, 1},
+		{"one without effect", 0.7, 0, 1, 0},
+		{"both without effect", 0, 0, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sign, similarity := ConflictComponents(tt.d1, tt.d2)
+			if sign != tt.wantSign {
+				t.Errorf("sign agreement = %v, want %v", sign, tt.wantSign)
+			}
+			if math.Abs(similarity-tt.wantSimilarity) > 1e-12 {
+				t.Errorf("magnitude similarity = %v, want %v", similarity, tt.wantSimilarity)
+			}
+		})
+	}
+
+	// Both {+0.9, +0.1} and {+0.5, +0.5} have conflict index 1;
+	// the components tell them apart
+	directions := map[string]float64{"0": 0.9, "1": 0.1, "2": 0.5, "3": 0.5}
+	conflicts := ComputeConflicts(directions, 4)
+	signAgreement, similarity := ComputeConflictComponents(directions, 4)
+	if conflicts["0,1"] != conflicts["2,3"] {
+		t.Fatalf("expected equal conflict indices, got %v and %v", conflicts["0,1"], conflicts["2,3"])
+	}
+	if signAgreement["0,1"] != 1 || signAgreement["2,3"] != 1 {
+		t.Errorf("expected sign agreement for both pairs, got %v and %v", signAgreement["0,1"], signAgreement["2,3"])
+	}
+	if similarity["0,1"] > 0.2 || similarity["2,3"] != 1 {
+		t.Errorf("expected low similarity for {0.9, 0.1} and 1 for {0.5, 0.5}, got %v and %v",
+			similarity["0,1"], similarity["2,3"])
+	}
+	if len(signAgreement) != 6 || len(similarity) != 6 {
+		t.Errorf("expected 6 pairs, got %d and %d", len(signAgreement), len(similarity))
+	}
+}
+
 // TestMedianSplitMethod tests the median split direction method.
 func TestMedianSplitMethod(t *testing.T) {
 	n := 1000
@@ -688,6 +735,70 @@ func TestAggregateDirections(t *testing.T) {
 	}
 }
 
+// TestWeightedAggregateDirections tests MI-weighted aggregation.
+func TestWeightedAggregateDirections(t *testing.T) {
+	if agg := weightedAggregateDirections([]float64{1, -1}, []float64{3, 1}); math.Abs(agg-0.5) > 1e-12 {
+		t.Errorf("Expected 0.5 for weights (3, 1), got %f", agg)
+	}
+
+	// Zero weights fall back to the plain mean
+	if agg := weightedAggregateDirections([]float64{0.4, 0.8}, []float64{0, 0}); math.Abs(agg-0.6) > 1e-12 {
+		t.Errorf("Expected plain mean 0.6 for zero weights, got %f", agg)
+	}
+
+	// Negative weights (e.g. slightly negative MI estimates) count as zero
+	if agg := weightedAggregateDirections([]float64{1, -1}, []float64{1, -0.1}); agg != 1 {
+		t.Errorf("Expected 1 for weights (1, -0.1), got %f", agg)
+	}
+}
+
+// TestDecompose_WeightByMI tests that a dominant+weak pair leans toward the
+// dominant variable's direction when weighting by mutual information.
+func TestDecompose_WeightByMI(t *testing.T) {
+	n := 5000
+	rng := rand.New(rand.NewSource(81)) //nolint:gosec // deterministic for testing
+
+	Y := make([]float64, n)
+	X := [][]float64{make([]float64, n), make([]float64, n)}
+	for i := 0; i < n; i++ {
+		X[0][i] = rng.Float64() * 10
+		X[1][i] = rng.Float64() * 10
+		Y[i] = 3*X[0][i] - 0.5*X[1][i] + rng.NormFloat64() // X0 dominant (+), X1 weak (-)
+	}
+
+	config := DefaultConfig()
+	config.Bins = []int{8}
+	plain, err := Decompose(Y, X, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	config.WeightByMI = true
+	weighted, err := Decompose(Y, X, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	d0, d1 := weighted.Directions["0"], weighted.Directions["1"]
+	if d0 <= 0 || d1 >= 0 {
+		t.Fatalf("expected d0 > 0 > d1, got d0=%.3f d1=%.3f", d0, d1)
+	}
+	mi0, mi1 := weighted.SURD.MutualInfo["0"], weighted.SURD.MutualInfo["1"]
+	if mi0 <= mi1 {
+		t.Fatalf("expected X0 to carry more information: MI0=%.3f MI1=%.3f", mi0, mi1)
+	}
+
+	want := (mi0*d0 + mi1*d1) / (mi0 + mi1)
+	if got := weighted.Directions["0,1"]; math.Abs(got-want) > 1e-12 {
+		t.Errorf("weighted pair direction = %.4f, want %.4f", got, want)
+	}
+	if math.Abs(weighted.Directions["0,1"]-d0) >= math.Abs(plain.Directions["0,1"]-d0) {
+		t.Errorf("weighted pair %.3f should be closer to dominant %.3f than plain mean %.3f",
+			weighted.Directions["0,1"], d0, plain.Directions["0,1"])
+	}
+	t.Logf("d0=%.3f d1=%.3f MI0=%.3f MI1=%.3f plain=%.3f weighted=%.3f",
+		d0, d1, mi0, mi1, plain.Directions["0,1"], weighted.Directions["0,1"])
+}
+
 // TestSignsAgree tests the sign agreement helper.
 func TestSignsAgree(t *testing.T) {
 	tests := []struct {
@@ -751,12 +862,231 @@ func TestBootstrap_InsufficientSamples(t *testing.T) {
 	}
 
 	// Should not panic, just return empty confidence
-	confidence := bootstrapConfidence(Y, X, config)
-	if len(confidence) > 0 {
+	boot := bootstrapConfidence(Y, X, newSortedColumns(X), config)
+	if len(boot.confidence) > 0 || len(boot.directions) > 0 || len(boot.conflicts) > 0 {
 		t.Error("Expected empty confidence for insufficient samples")
 	}
 }
 
+// TestBootstrap_NoValidResamples tests that confidence is NaN when every resample is invalid.
+func TestBootstrap_NoValidResamples(t *testing.T) {
+	rng := rand.New(rand.NewSource(61)) //nolint:gosec // deterministic for testing
+	n := 100
+	Y := make([]float64, n)
+	X := [][]float64{make([]float64, n)}
+	for i := 0; i < n; i++ {
+		X[0][i] = rng.Float64() * 10
+		Y[i] = 2*X[0][i] + rng.NormFloat64()
+	}
+
+	// ~10 samples per source bin, but 25 required: no resample is ever valid
+	config := Config{
+		Bins:                  []int{10},
+		DirectionMethod:       BinnedConditionalMeanMethod,
+		BootstrapN:            20,
+		MinSamplesPerQuartile: 25,
+	}
+
+	result, err := Decompose(Y, X, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	if conf, ok := result.Confidence["0"]; !ok || !math.IsNaN(conf) {
+		t.Errorf("Confidence[0] = %v (present=%v), want NaN", conf, ok)
+	}
+
+	// Same data with a reachable minimum: confidence is a real number
+	config.MinSamplesPerQuartile = 2
+	result, err = Decompose(Y, X, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	if conf := result.Confidence["0"]; math.IsNaN(conf) || conf < 0.9 {
+		t.Errorf("Confidence[0] = %v, want high confidence", conf)
+	}
+}
+
+// TestBootstrap_ConflictConfidence tests that opposing sources give a low,
+// narrow conflict interval that widens when the effects are buried in noise.
+func TestBootstrap_ConflictConfidence(t *testing.T) {
+	conflictingSystem := func(noiseStd float64) ([]float64, [][]float64) {
+		rng := rand.New(rand.NewSource(71)) //nolint:gosec // deterministic for testing
+		n := 400
+		Y := make([]float64, n)
+		X := [][]float64{make([]float64, n), make([]float64, n)}
+		for i := 0; i < n; i++ {
+			X[0][i] = rng.Float64() * 10
+			X[1][i] = rng.Float64() * 10
+			Y[i] = X[0][i] - X[1][i] + noiseStd*rng.NormFloat64() // X0 facilitates, X1 inhibits
+		}
+		return Y, X
+	}
+
+	config := Config{
+		Bins:                  []int{8},
+		DirectionMethod:       QuartileMethod,
+		RobustStats:           true,
+		MinSamplesPerQuartile: 5,
+		BootstrapN:            100,
+	}
+
+	var intervals [2]ConflictInterval
+	for i, noiseStd := range []float64{0.3, 20} {
+		Y, X := conflictingSystem(noiseStd)
+		result, err := Decompose(Y, X, config)
+		if err != nil {
+			t.Fatalf("noise %.1f: Decompose failed: %v", noiseStd, err)
+		}
+		ci, ok := result.ConflictConfidence["0,1"]
+		if !ok {
+			t.Fatalf("noise %.1f: missing ConflictConfidence[0,1]", noiseStd)
+		}
+		t.Logf("noise %.1f: conflict %.3f, interval [%.3f, %.3f]",
+			noiseStd, result.Conflicts["0,1"], ci.Lower, ci.Upper)
+
+		if ci.Lower > ci.Upper || ci.Lower < 0 || ci.Upper > 1 {
+			t.Errorf("noise %.1f: invalid interval [%.3f, %.3f]", noiseStd, ci.Lower, ci.Upper)
+		}
+		intervals[i] = ci
+	}
+
+	clean, noisy := intervals[0], intervals[1]
+	if clean.Upper > 0.2 || clean.Width() > 0.15 {
+		t.Errorf("expected low, narrow conflict interval, got [%.3f, %.3f]", clean.Lower, clean.Upper)
+	}
+	if noisy.Width() <= clean.Width() {
+		t.Errorf("noisy interval width %.3f should exceed clean width %.3f", noisy.Width(), clean.Width())
+	}
+}
+
+// TestBootstrap_DirectionCI tests the bootstrap percentile interval of the
+// direction: it brackets the estimate and widens with noise.
+func TestBootstrap_DirectionCI(t *testing.T) {
+	system := func(noiseStd float64) ([]float64, [][]float64) {
+		rng := rand.New(rand.NewSource(73)) //nolint:gosec // deterministic for testing
+		n := 400
+		Y := make([]float64, n)
+		X := [][]float64{make([]float64, n)}
+		for i := 0; i < n; i++ {
+			X[0][i] = rng.Float64() * 10
+			Y[i] = X[0][i] + noiseStd*rng.NormFloat64()
+		}
+		return Y, X
+	}
+
+	config := DefaultConfig()
+	config.BootstrapN = 200
+	config.BootstrapCI = true
+
+	var widths [2]float64
+	for i, noiseStd := range []float64{1, 10} {
+		Y, X := system(noiseStd)
+		result, err := Decompose(Y, X, config)
+		if err != nil {
+			t.Fatalf("noise %.0f: Decompose failed: %v", noiseStd, err)
+		}
+		ci, ok := result.DirectionCI["0"]
+		if !ok {
+			t.Fatalf("noise %.0f: missing DirectionCI[0]", noiseStd)
+		}
+		d := result.Directions["0"]
+		t.Logf("noise %.0f: direction %.3f, CI [%.3f, %.3f]", noiseStd, d, ci[0], ci[1])
+
+		if ci[0] > ci[1] || ci[0] < -1 || ci[1] > 1 {
+			t.Errorf("noise %.0f: invalid interval %v", noiseStd, ci)
+		}
+		if d < ci[0]-0.05 || d > ci[1]+0.05 {
+			t.Errorf("noise %.0f: direction %.3f outside CI %v", noiseStd, d, ci)
+		}
+		widths[i] = ci[1] - ci[0]
+	}
+	if widths[1] <= widths[0] {
+		t.Errorf("noisy CI width %.3f should exceed clean width %.3f", widths[1], widths[0])
+	}
+
+	// Not populated unless requested
+	config.BootstrapCI = false
+	Y, X := system(1)
+	result, err := Decompose(Y, X, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	if result.DirectionCI != nil {
+		t.Errorf("expected nil DirectionCI without BootstrapCI, got %v", result.DirectionCI)
+	}
+}
+
+// TestBootstrap_WorkersDeterministic tests that bootstrap results do not
+// depend on the number of workers.
+func TestBootstrap_WorkersDeterministic(t *testing.T) {
+	rng := rand.New(rand.NewSource(72)) //nolint:gosec // deterministic for testing
+	n := 300
+	Y := make([]float64, n)
+	X := [][]float64{make([]float64, n), make([]float64, n)}
+	for i := 0; i < n; i++ {
+		X[0][i] = rng.Float64() * 10
+		X[1][i] = rng.Float64() * 10
+		Y[i] = X[0][i] - 0.3*X[1][i] + 3*rng.NormFloat64()
+	}
+
+	config := DefaultConfig()
+	config.BootstrapN = 50
+
+	config.Workers = 1
+	seq := bootstrapConfidence(Y, X, newSortedColumns(X), config)
+	confSeq, dirsSeq, conflictsSeq := seq.confidence, seq.directions, seq.conflicts
+	for _, workers := range []int{0, 3, 16} {
+		config.Workers = workers
+		boot := bootstrapConfidence(Y, X, newSortedColumns(X), config)
+		conf, dirs, conflicts := boot.confidence, boot.directions, boot.conflicts
+		for key, want := range confSeq {
+			if conf[key] != want {
+				t.Errorf("workers=%d: confidence[%s] = %v, want %v", workers, key, conf[key], want)
+			}
+			if dirs[key] != dirsSeq[key] {
+				t.Errorf("workers=%d: bootstrap direction[%s] = %+v, want %+v", workers, key, dirs[key], dirsSeq[key])
+			}
+		}
+		if conflicts["0,1"] != conflictsSeq["0,1"] {
+			t.Errorf("workers=%d: conflict interval = %+v, want %+v", workers, conflicts["0,1"], conflictsSeq["0,1"])
+		}
+	}
+	t.Logf("confidence %v", confSeq)
+}
+
+// TestBootstrap_MatchesSequential pins Workers=1 output to the values of the
+// sequential bootstrap, which drew every resample from one seeded RNG stream.
+func TestBootstrap_MatchesSequential(t *testing.T) {
+	rng := rand.New(rand.NewSource(72)) //nolint:gosec // deterministic for testing
+	n := 300
+	Y := make([]float64, n)
+	X := [][]float64{make([]float64, n), make([]float64, n)}
+	for i := 0; i < n; i++ {
+		X[0][i] = rng.Float64() * 10
+		X[1][i] = rng.Float64() * 10
+		Y[i] = X[0][i] - 0.3*X[1][i] + 3*rng.NormFloat64()
+	}
+
+	config := DefaultConfig()
+	config.BootstrapN = 50
+	config.Workers = 1
+	result, err := Decompose(Y, X, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	wantConfidence := map[string]float64{"0": 1, "1": 0.96}
+	for key, want := range wantConfidence {
+		if got := result.Confidence[key]; got != want {
+			t.Errorf("Confidence[%s] = %v, want %v", key, got, want)
+		}
+	}
+	wantConflict := ConflictInterval{Lower: 0.4453761387990091, Upper: 0.9944403023570934}
+	if got := result.ConflictConfidence["0,1"]; got != wantConflict {
+		t.Errorf("ConflictConfidence[0,1] = %+v, want %+v", got, wantConflict)
+	}
+}
+
 // TestDecompose_MultipleVariables tests decomposition with multiple predictors.
 func TestDecompose_MultipleVariables(t *testing.T) {
 	n := 500
@@ -821,3 +1151,192 @@ func TestDecompose_MultipleVariables(t *testing.T) {
 		t.Error("Expected conflict for pair 0,1")
 	}
 }
+
+func TestSystemCoherence_Weighting(t *testing.T) {
+	// Strong opposing pair (0,1) and weak agreeing pairs with variable 2
+	result := &Result{
+		Directions:   map[string]float64{"0": 0.9, "1": -0.9, "2": 0.05},
+		NumVariables: 3,
+	}
+	result.Conflicts = ComputeConflicts(result.Directions, result.NumVariables)
+
+	got := SystemCoherence(result)
+	if got > 0.2 {
+		t.Errorf("SystemCoherence() = %.4f, want < 0.2 (strong opposing pair dominates)", got)
+	}
+
+	// No directional effect anywhere → no conflict
+	empty := &Result{Directions: map[string]float64{"0": 0, "1": 0}, NumVariables: 2}
+	if got := SystemCoherence(empty); got != 1.0 {
+		t.Errorf("SystemCoherence() with zero directions = %.4f, want 1.0", got)
+	}
+
+	if got := SystemCoherence(nil); got != 1.0 {
+		t.Errorf("SystemCoherence(nil) = %.4f, want 1.0", got)
+	}
+}
+
+// TestComputeDirection_Detrend tests that Detrend removes the linear trend of
+// Y = 2X + 0.3·sin(2πX) and exposes the residual oscillation.
+//
+// After removing the linear fit, the residual decreases through the middle of
+// the X range, so the upper X half has lower residuals than the lower half.
+func TestComputeDirection_Detrend(t *testing.T) {
+	n := 2000
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic for testing
+
+	X := make([]float64, n)
+	Y := make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		X[i] = rng.Float64()
+		noise := rng.NormFloat64() * 0.05
+		Y[i] = 2.0*X[i] + 0.3*math.Sin(2*math.Pi*X[i]) + noise
+	}
+
+	config := DefaultConfig()
+	detrended := config
+	detrended.Detrend = true
+
+	// Raw directions just report the linear trend
+	rawQuartile := ComputeDirection(Y, X, QuartileMethod, config)
+	rawSplit := ComputeDirection(Y, X, MedianSplitMethod, config)
+	if rawQuartile.Direction < 0.9 || rawSplit.Direction < 0.5 {
+		t.Errorf("raw directions should be strongly positive, got quartile=%.4f split=%.4f",
+			rawQuartile.Direction, rawSplit.Direction)
+	}
+
+	// Residual: inhibitory across the median split
+	split := ComputeDirection(Y, X, MedianSplitMethod, detrended)
+	if !split.Valid {
+		t.Fatalf("detrended direction failed: %s", split.Reason)
+	}
+	if split.Direction > -0.5 {
+		t.Errorf("detrended median-split direction should be negative (< -0.5), got %.4f", split.Direction)
+	}
+
+	// The residual is uncorrelated with X by construction
+	gradient := ComputeDirection(Y, X, GradientMethod, detrended)
+	if math.Abs(gradient.Direction) > 1e-9 {
+		t.Errorf("detrended gradient direction should be 0, got %.4f", gradient.Direction)
+	}
+
+	// The quartile extremes of the residual nearly balance
+	quartile := ComputeDirection(Y, X, QuartileMethod, detrended)
+	if math.Abs(quartile.Direction) > 0.3 {
+		t.Errorf("detrended quartile direction should be near 0, got %.4f", quartile.Direction)
+	}
+
+	t.Logf("Raw: quartile=%.4f split=%.4f; detrended: quartile=%.4f split=%.4f gradient=%.4f",
+		rawQuartile.Direction, rawSplit.Direction, quartile.Direction, split.Direction, gradient.Direction)
+}
+
+// TestComputeDirection_ScaleInvariant tests that tiny-magnitude data does not
+// fall into the zero-dispersion branch: scaling Y by 1e-8 must not change the
+// direction.
+func TestComputeDirection_ScaleInvariant(t *testing.T) {
+	n := 2000
+	rng := rand.New(rand.NewSource(44)) //nolint:gosec // deterministic for testing
+
+	X := make([]float64, n)
+	Y := make([]float64, n)
+	scaled := make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		X[i] = rng.Float64() * 10
+		Y[i] = -0.0002*X[i] + rng.NormFloat64()*0.002 // Weak negative effect, dispersion ~1e-3
+		scaled[i] = Y[i] * 1e-8
+	}
+
+	for _, method := range []DirectionMethod{QuartileMethod, MedianSplitMethod} {
+		for _, robust := range []bool{true, false} {
+			config := DefaultConfig()
+			config.RobustStats = robust
+
+			raw := ComputeDirection(Y, X, method, config)
+			tiny := ComputeDirection(scaled, X, method, config)
+			if !raw.Valid || !tiny.Valid {
+				t.Fatalf("method=%d robust=%v: invalid result", method, robust)
+			}
+
+			if raw.Direction >= 0 || raw.Direction <= -0.99 {
+				t.Errorf("method=%d robust=%v: expected moderate negative direction, got %.4f", method, robust, raw.Direction)
+			}
+			if math.Abs(tiny.Direction-raw.Direction) > 1e-9 {
+				t.Errorf("method=%d robust=%v: scaled direction %.6f differs from unscaled %.6f",
+					method, robust, tiny.Direction, raw.Direction)
+			}
+		}
+	}
+
+	// Zero within-group dispersion still takes the degenerate branch
+	step := make([]float64, n)
+	for i, x := range X {
+		if x > 5 {
+			step[i] = 1e-8
+		}
+	}
+	if result := ComputeDirection(step, X, QuartileMethod, DefaultConfig()); result.Direction != 1 {
+		t.Errorf("step function: expected direction 1, got %.4f", result.Direction)
+	}
+}
+
+// TestDecompose_Circular tests phase-coupled pairs whose phases wrap at 2π
+// (the driving phase is recorded unwrapped, over several turns): with
+// Config.Circular SCIC bins modulo the period and reports the circular
+// correlation as direction, where linear statistics are corrupted by the wrap.
+func TestDecompose_Circular(t *testing.T) {
+	n := 4000
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic for testing
+
+	wrap := func(a float64) float64 { return math.Mod(a+4*math.Pi, 2*math.Pi) }
+
+	Y := make([]float64, n)
+	X := [][]float64{make([]float64, n), make([]float64, n), make([]float64, n)}
+	for i := 0; i < n; i++ {
+		phase := 2 * math.Pi * rng.Float64()
+		X[0][i] = phase + 2*math.Pi*float64(rng.Intn(3)) // driving phase, unwrapped
+		X[1][i] = wrap(-phase + 0.2*rng.NormFloat64())   // counter-rotating phase
+		X[2][i] = rng.Float64()                          // linear amplitude, unrelated
+		Y[i] = wrap(phase + 1 + 0.2*rng.NormFloat64())
+	}
+
+	linear := DefaultConfig()
+	linear.DirectionMethod = GradientMethod
+	circular := linear
+	circular.Circular = []bool{true, true, true, false}
+
+	lin, err := Decompose(Y, X, linear)
+	if err != nil {
+		t.Fatalf("Decompose (linear) failed: %v", err)
+	}
+	circ, err := Decompose(Y, X, circular)
+	if err != nil {
+		t.Fatalf("Decompose (circular) failed: %v", err)
+	}
+
+	t.Logf("Directions: linear %v, circular %v", lin.Directions, circ.Directions)
+	t.Logf("MI[0]: linear %.4f, circular %.4f", lin.SURD.MutualInfo["0"], circ.SURD.MutualInfo["0"])
+
+	if circ.Directions["0"] < 0.9 {
+		t.Errorf("co-rotating phase: circular direction = %.4f, want > 0.9", circ.Directions["0"])
+	}
+	if circ.Directions["1"] > -0.9 {
+		t.Errorf("counter-rotating phase: circular direction = %.4f, want < -0.9", circ.Directions["1"])
+	}
+	if circ.Directions["2"] != 0 {
+		t.Errorf("linear source of a circular target: direction = %.4f, want 0", circ.Directions["2"])
+	}
+	if lin.Directions["0"] > 0.8 {
+		t.Errorf("linear direction across the wrap = %.4f, expected < 0.8", lin.Directions["0"])
+	}
+	if circ.SURD.MutualInfo["0"] <= lin.SURD.MutualInfo["0"] {
+		t.Errorf("circular binning should recover more coupling: MI %.4f <= %.4f",
+			circ.SURD.MutualInfo["0"], lin.SURD.MutualInfo["0"])
+	}
+
+	circular.Circular = []bool{true, true}
+	if _, err := Decompose(Y, X, circular); err == nil {
+		t.Error("expected error for Circular of the wrong length")
+	}
+}
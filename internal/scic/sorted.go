@@ -0,0 +1,61 @@
+package scic
+
+import (
+	"math"
+	"sort"
+)
+
+// sortedColumns caches, for each source column, the sample indices in
+// ascending order of value. It is computed once per Decompose call and
+// reused by the quantile/median based direction methods, both for the
+// original data and for every bootstrap resample, so columns are sorted
+// once instead of once per direction estimate.
+type sortedColumns [][]int
+
+// newSortedColumns returns the sort order of every column of X.
+func newSortedColumns(X [][]float64) sortedColumns { //nolint:gocritic // X is standard mathematical notation
+	cols := make(sortedColumns, len(X))
+	for j, x := range X {
+		order := make([]int, len(x))
+		for i := range order {
+			order[i] = i
+		}
+		// Same ordering as sort.Float64s: NaN first, then ascending.
+		sort.SliceStable(order, func(a, b int) bool {
+			xa, xb := x[order[a]], x[order[b]]
+			return xa < xb || (math.IsNaN(xa) && !math.IsNaN(xb))
+		})
+		cols[j] = order
+	}
+	return cols
+}
+
+// values returns column j of X in ascending order.
+func (s sortedColumns) values(j int, x []float64) []float64 {
+	sorted := make([]float64, len(x))
+	for i, idx := range s[j] {
+		sorted[i] = x[idx]
+	}
+	return sorted
+}
+
+// resampled returns, in ascending order, the values of column j of X drawn by
+// a bootstrap resample, where counts[i] is how often sample i was drawn.
+// Walking the cached order makes this O(n) instead of a sort per resample.
+func (s sortedColumns) resampled(j int, x []float64, counts []int) []float64 {
+	sorted := make([]float64, 0, len(x))
+	for _, idx := range s[j] {
+		for c := 0; c < counts[idx]; c++ {
+			sorted = append(sorted, x[idx])
+		}
+	}
+	return sorted
+}
+
+// sortedCopy returns data sorted in ascending order, leaving data unchanged.
+func sortedCopy(data []float64) []float64 {
+	sorted := make([]float64, len(data))
+	copy(sorted, data)
+	sort.Float64s(sorted)
+	return sorted
+}
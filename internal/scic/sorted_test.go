@@ -0,0 +1,67 @@
+package scic
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSortedColumns_DirectionsMatchUncached(t *testing.T) {
+	rng := rand.New(rand.NewSource(3)) //nolint:gosec // deterministic for testing
+	n := 300
+	X := make([]float64, n) //nolint:gocritic // X is standard mathematical notation
+	Y := make([]float64, n) //nolint:gocritic // Y is standard mathematical notation
+	for i := range X {
+		// Rounded values give many ties at the quartiles and median
+		X[i] = float64(rng.Intn(12))
+		Y[i] = X[i] + rng.NormFloat64()
+	}
+	cols := newSortedColumns([][]float64{X})
+
+	// Bootstrap resample, with its sorted values read off the cache
+	yBoot := make([]float64, n)
+	xBoot := make([]float64, n)
+	counts := make([]int, n)
+	for i := range yBoot {
+		idx := rng.Intn(n)
+		yBoot[i], xBoot[i] = Y[idx], X[idx]
+		counts[idx]++
+	}
+
+	cases := []struct {
+		name          string
+		y, x, sortedX []float64
+	}{
+		{"original", Y, X, cols.values(0, X)},
+		{"resampled", yBoot, xBoot, cols.resampled(0, X, counts)},
+	}
+	for _, tc := range cases {
+		for _, robust := range []bool{false, true} {
+			config := DefaultConfig()
+			config.RobustStats = robust
+			for _, m := range DirectionMethods {
+				cached := computeDirection(tc.y, tc.x, tc.sortedX, m, config)
+				uncached := ComputeDirection(tc.y, tc.x, m, config)
+				if cached != uncached {
+					t.Errorf("%s, method %d, robust=%v: cached %+v != uncached %+v",
+						tc.name, m, robust, cached, uncached)
+				}
+			}
+		}
+	}
+}
+
+func TestSortedColumns_Resampled(t *testing.T) {
+	x := []float64{3, 1, 2}
+	cols := newSortedColumns([][]float64{x})
+
+	got := cols.resampled(0, x, []int{2, 0, 1})
+	want := []float64{2, 3, 3}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
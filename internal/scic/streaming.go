@@ -0,0 +1,169 @@
+package scic
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/causalgo/causalgo/internal/stats"
+)
+
+// normalIQR is the interquartile range of the standard normal distribution.
+// IQR / normalIQR is a robust, std-consistent dispersion (like the scaled MAD).
+const normalIQR = 1.3489795
+
+// StreamingDirection computes the quartile direction (QuartileMethod) over a
+// stream of samples using O(1) memory.
+//
+// Quartiles of X are estimated online with the P² algorithm, so the data is
+// read twice:
+//
+//  1. ObserveX for every sample estimates the 25th/75th percentiles of X.
+//  2. Add for every sample accumulates Y statistics in the low and high quartiles.
+//
+// With config.RobustStats the group center and spread are the P² median and
+// IQR/1.349 of Y (an online substitute for median and MAD); otherwise the
+// running mean and standard deviation are used. Results match
+// ComputeDirection with QuartileMethod up to the quantile approximation error.
+//
+// Example:
+//
+//	sd := NewStreamingDirection(DefaultConfig())
+//	for _, s := range pass1 { sd.ObserveX(s.X) }
+//	for _, s := range pass2 { sd.Add(s.Y, s.X) }
+//	result := sd.Result()
+type StreamingDirection struct {
+	config   Config
+	q25, q75 *stats.P2Quantile
+
+	// Quartile cut points, fixed on the first call to Add
+	frozen          bool
+	lowCut, highCut float64
+	total           int
+	low, high, all  *streamGroup
+}
+
+// streamGroup accumulates Y statistics for one quartile group.
+type streamGroup struct {
+	n        int
+	mean, m2 float64 // Welford running mean and sum of squared deviations
+
+	median, q25, q75 *stats.P2Quantile // robust statistics
+}
+
+// NewStreamingDirection creates a streaming quartile direction estimator.
+func NewStreamingDirection(config Config) *StreamingDirection {
+	return &StreamingDirection{
+		config: config,
+		q25:    mustP2(0.25),
+		q75:    mustP2(0.75),
+		low:    newStreamGroup(),
+		high:   newStreamGroup(),
+		all:    newStreamGroup(),
+	}
+}
+
+// ObserveX adds a source value to the quartile estimates (first pass).
+func (s *StreamingDirection) ObserveX(x float64) {
+	s.q25.Add(x)
+	s.q75.Add(x)
+}
+
+// Add accumulates a (Y, X) sample into the quartile groups (second pass).
+// The quartile cut points are fixed at the first call; later ObserveX calls
+// do not change them.
+func (s *StreamingDirection) Add(y, x float64) {
+	if !s.frozen {
+		s.lowCut, s.highCut = s.q25.Value(), s.q75.Value()
+		s.frozen = true
+	}
+	s.total++
+	s.all.add(y)
+
+	if x <= s.lowCut {
+		s.low.add(y)
+	} else if x >= s.highCut {
+		s.high.add(y)
+	}
+}
+
+// Result returns the direction estimated from the samples added so far.
+func (s *StreamingDirection) Result() DirectionResult {
+	minSamples := s.config.MinSamplesPerQuartile
+	if s.q25.Count() == 0 {
+		return DirectionResult{Valid: false, Reason: "no X values observed in the first pass"}
+	}
+	if s.total < 4*minSamples {
+		return DirectionResult{
+			Valid:  false,
+			Reason: fmt.Sprintf("insufficient samples: %d < %d", s.total, 4*minSamples),
+		}
+	}
+	if s.low.n < minSamples || s.high.n < minSamples || s.low.n == 0 || s.high.n == 0 {
+		return DirectionResult{
+			Valid:  false,
+			Reason: fmt.Sprintf("insufficient quartile samples: low=%d, high=%d", s.low.n, s.high.n),
+		}
+	}
+
+	muLow, sigmaLow := s.low.summary(s.config.RobustStats)
+	muHigh, sigmaHigh := s.high.summary(s.config.RobustStats)
+	return groupContrast(muLow, muHigh, sigmaLow, sigmaHigh, s.dispersionThreshold())
+}
+
+// dispersionThreshold mirrors the batch dispersionThreshold using the
+// dispersion of all added Y values.
+func (s *StreamingDirection) dispersionThreshold() float64 {
+	eps := s.config.DispersionEpsilon
+	if eps <= 0 {
+		eps = DefaultDispersionEpsilon
+	}
+
+	_, scale := s.all.summary(false)
+	if s.config.RobustStats {
+		if _, spread := s.all.summary(true); spread > 0 {
+			scale = spread
+		}
+	}
+	return eps * scale
+}
+
+// newStreamGroup creates an empty quartile group.
+func newStreamGroup() *streamGroup {
+	return &streamGroup{
+		median: mustP2(0.5),
+		q25:    mustP2(0.25),
+		q75:    mustP2(0.75),
+	}
+}
+
+// add incorporates one target value.
+func (g *streamGroup) add(y float64) {
+	g.n++
+	delta := y - g.mean
+	g.mean += delta / float64(g.n)
+	g.m2 += delta * (y - g.mean)
+
+	g.median.Add(y)
+	g.q25.Add(y)
+	g.q75.Add(y)
+}
+
+// summary returns the group center and spread.
+func (g *streamGroup) summary(robust bool) (center, spread float64) {
+	if robust {
+		return g.median.Value(), (g.q75.Value() - g.q25.Value()) / normalIQR
+	}
+	if g.n < 2 {
+		return g.mean, 0
+	}
+	return g.mean, math.Sqrt(g.m2 / float64(g.n-1))
+}
+
+// mustP2 creates a P² estimator for a constant, valid quantile.
+func mustP2(p float64) *stats.P2Quantile {
+	e, err := stats.NewP2Quantile(p)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
@@ -0,0 +1,65 @@
+package scic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestStreamingDirection_MatchesBatch compares the two-pass streaming quartile
+// direction with the in-memory QuartileMethod on the same data.
+func TestStreamingDirection_MatchesBatch(t *testing.T) {
+	n := 100000
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic for testing
+
+	X := make([]float64, n)
+	Y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		X[i] = rng.Float64() * 10
+		Y[i] = -0.3*X[i] + rng.NormFloat64()*2 // Weak negative effect, so the direction is not clamped
+	}
+
+	for _, robust := range []bool{true, false} {
+		config := DefaultConfig()
+		config.RobustStats = robust
+
+		sd := NewStreamingDirection(config)
+		for _, x := range X {
+			sd.ObserveX(x)
+		}
+		for i := range X {
+			sd.Add(Y[i], X[i])
+		}
+		streaming := sd.Result()
+		batch := ComputeDirection(Y, X, QuartileMethod, config)
+
+		if !streaming.Valid || !batch.Valid {
+			t.Fatalf("robust=%v: invalid result: streaming=%q batch=%q", robust, streaming.Reason, batch.Reason)
+		}
+		if streaming.Direction >= 0 {
+			t.Errorf("robust=%v: expected negative direction, got %.4f", robust, streaming.Direction)
+		}
+		if diff := math.Abs(streaming.Direction - batch.Direction); diff > 0.02 {
+			t.Errorf("robust=%v: streaming %.4f differs from batch %.4f by %.4f",
+				robust, streaming.Direction, batch.Direction, diff)
+		}
+		t.Logf("robust=%v: streaming=%.4f batch=%.4f", robust, streaming.Direction, batch.Direction)
+	}
+}
+
+func TestStreamingDirection_InsufficientSamples(t *testing.T) {
+	sd := NewStreamingDirection(DefaultConfig())
+	if result := sd.Result(); result.Valid {
+		t.Error("expected invalid result without data")
+	}
+
+	for i := 0; i < 10; i++ {
+		sd.ObserveX(float64(i))
+	}
+	for i := 0; i < 10; i++ {
+		sd.Add(float64(i), float64(i))
+	}
+	if result := sd.Result(); result.Valid {
+		t.Errorf("expected invalid result for 10 samples, got %.4f", result.Direction)
+	}
+}
@@ -229,6 +229,46 @@ func TestValidation_InhibitorSystem(t *testing.T) {
 	}
 }
 
+// TestValidation_InhibitorSystem_Binned tests the SURD-consistent binned direction.
+func TestValidation_InhibitorSystem_Binned(t *testing.T) {
+	yData, xData := generateInhibitorSystem(1000, 44)
+
+	config := Config{
+		Bins:                  []int{10, 8},
+		DirectionMethod:       BinnedConditionalMeanMethod,
+		MinSamplesPerQuartile: 5,
+	}
+
+	result, err := Decompose(yData, xData, config)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	t.Logf("Inhibitor System (binned conditional means):")
+	t.Logf("  Direction[0]: %.4f", result.Directions["0"])
+
+	// Conditional means decrease across every pair of source bins
+	if result.Directions["0"] > -0.95 {
+		t.Errorf("Expected negative trend direction[0] < -0.95, got %.4f", result.Directions["0"])
+	}
+
+	// U-shaped: rising and falling halves cancel
+	yU, xU := generateUShapedSystem(1000, 45)
+	dir := ComputeBinnedDirection(yU, xU[0], 10, 10, config)
+	if !dir.Valid {
+		t.Fatalf("ComputeBinnedDirection invalid: %s", dir.Reason)
+	}
+	t.Logf("U-Shaped System (binned conditional means): %.4f", dir.Direction)
+	if math.Abs(dir.Direction) > 0.3 {
+		t.Errorf("Expected near-zero trend for U-shaped system, got %.4f", dir.Direction)
+	}
+
+	// Too few samples per source bin
+	if dir := ComputeBinnedDirection(yData[:20], xData[0][:20], 10, 10, Config{MinSamplesPerQuartile: 15}); dir.Valid {
+		t.Errorf("Expected invalid direction for sparse bins, got %.4f", dir.Direction)
+	}
+}
+
 // TestValidation_UShapedSystem tests SCIC on non-linear U-shaped relationship.
 func TestValidation_UShapedSystem(t *testing.T) {
 	yData, xData := generateUShapedSystem(1000, 45)
@@ -290,6 +330,79 @@ func TestValidation_ConflictingSystem(t *testing.T) {
 	if result.Conflicts["0,1"] > 0.4 {
 		t.Errorf("Expected low conflict (opposite directions) < 0.4, got %.4f", result.Conflicts["0,1"])
 	}
+
+	// The low conflict comes from opposite signs
+	if result.SignAgreement["0,1"] != 0 {
+		t.Errorf("Expected sign agreement 0 for opposite directions, got %.4f", result.SignAgreement["0,1"])
+	}
+}
+
+// TestValidation_DirectionAccuracy scores the conflicting system against its known signs.
+func TestValidation_DirectionAccuracy(t *testing.T) {
+	yData, xData := generateConflictingSystem(1000, 46)
+
+	result, err := Decompose(yData, xData, DefaultConfig())
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	acc, err := DirectionAccuracy(result, map[string]int{"0": +1, "1": -1})
+	if err != nil {
+		t.Fatalf("DirectionAccuracy failed: %v", err)
+	}
+	if acc != 1.0 {
+		t.Errorf("Expected accuracy 1.0, got %.2f (directions %v)", acc, result.Directions)
+	}
+
+	// Wrong truth for X2 and a "no effect" claim for X1: both mismatch
+	acc, err = DirectionAccuracy(result, map[string]int{"0": 0, "1": +1})
+	if err != nil {
+		t.Fatalf("DirectionAccuracy failed: %v", err)
+	}
+	if acc != 0 {
+		t.Errorf("Expected accuracy 0, got %.2f", acc)
+	}
+
+	for _, truth := range []map[string]int{nil, {"0": 2}, {"5": 1}} {
+		if _, err := DirectionAccuracy(result, truth); err == nil {
+			t.Errorf("Expected error for truth %v", truth)
+		}
+	}
+}
+
+// TestValidation_SystemCoherence tests that coherence separates agreeing and opposing sources.
+func TestValidation_SystemCoherence(t *testing.T) {
+	config := Config{
+		Bins:                  []int{10},
+		DirectionMethod:       QuartileMethod,
+		RobustStats:           true,
+		MinSamplesPerQuartile: 5,
+	}
+
+	yDup, xDup := generateDuplicatedSystem(1000, 43)
+	dupResult, err := Decompose(yDup, xDup, config)
+	if err != nil {
+		t.Fatalf("Decompose (duplicated) failed: %v", err)
+	}
+
+	yConf, xConf := generateConflictingSystem(1000, 46)
+	confResult, err := Decompose(yConf, xConf, config)
+	if err != nil {
+		t.Fatalf("Decompose (conflicting) failed: %v", err)
+	}
+
+	dupCoherence := SystemCoherence(dupResult)
+	confCoherence := SystemCoherence(confResult)
+
+	t.Logf("Duplicated coherence: %.4f", dupCoherence)
+	t.Logf("Conflicting coherence: %.4f", confCoherence)
+
+	if dupCoherence < 0.9 {
+		t.Errorf("Expected high coherence for duplicated system > 0.9, got %.4f", dupCoherence)
+	}
+	if confCoherence > 0.4 {
+		t.Errorf("Expected low coherence for conflicting system < 0.4, got %.4f", confCoherence)
+	}
 }
 
 // TestValidation_WithBootstrap tests that bootstrap confidence works correctly.
@@ -374,6 +487,25 @@ func TestValidation_BootstrapWithNoise(t *testing.T) {
 	if result.Confidence["0"] < 0 || result.Confidence["0"] > 1 {
 		t.Errorf("Confidence out of range [0,1]: %.4f", result.Confidence["0"])
 	}
+
+	// Bootstrap median is reported with its spread
+	boot, ok := result.BootstrapDirections["0"]
+	if !ok {
+		t.Fatal("BootstrapDirections[0] not populated")
+	}
+	t.Logf("  Bootstrap median[0]: %.4f (MAD %.4f, N=%d)", boot.Median, boot.MAD, boot.N)
+
+	if boot.N != config.BootstrapN {
+		t.Errorf("Expected %d valid resamples, got %d", config.BootstrapN, boot.N)
+	}
+	if boot.MAD <= 0 {
+		t.Errorf("Expected positive bootstrap spread for noisy data, got %.4f", boot.MAD)
+	}
+	// The median stays close to the point estimate, within the bootstrap spread
+	if diff := math.Abs(boot.Median - result.Directions["0"]); diff > boot.MAD {
+		t.Errorf("Bootstrap median %.4f differs from point estimate %.4f by more than MAD %.4f",
+			boot.Median, result.Directions["0"], boot.MAD)
+	}
 }
 
 // BenchmarkBootstrapConfidence benchmarks bootstrap computation.
@@ -0,0 +1,55 @@
+package stats
+
+import "math"
+
+// CircularMean returns the mean direction of angles (radians) in (-π, π].
+//
+// It is the angle of the mean resultant vector, so 0.1 and 2π-0.1 average to 0
+// instead of π. Returns NaN for empty input or when the resultant vanishes
+// (e.g. uniformly spread angles).
+func CircularMean(angles []float64) float64 {
+	var sumSin, sumCos float64
+	for _, a := range angles {
+		sumSin += math.Sin(a)
+		sumCos += math.Cos(a)
+	}
+	if len(angles) == 0 || math.Hypot(sumSin, sumCos) < 1e-12*float64(len(angles)) {
+		return math.NaN()
+	}
+	return math.Atan2(sumSin, sumCos)
+}
+
+// CircularCorrelation returns the Jammalamadaka–SenGupta circular correlation
+// coefficient between two angular variables (radians):
+//
+//	r = Σ sin(a_i - ā) sin(b_i - b̄) / sqrt(Σ sin²(a_i - ā) Σ sin²(b_i - b̄))
+//
+// where ā, b̄ are circular means. r is in [-1, 1] and, unlike Pearson
+// correlation, does not depend on where the angles wrap. Returns NaN if the
+// lengths differ, fewer than two samples are given or a mean is undefined, and
+// 0 if either variable has no angular spread.
+func CircularCorrelation(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) < 2 {
+		return math.NaN()
+	}
+
+	meanA, meanB := CircularMean(a), CircularMean(b)
+	if math.IsNaN(meanA) || math.IsNaN(meanB) {
+		return math.NaN()
+	}
+
+	var sumAB, sumA2, sumB2 float64
+	for i := range a {
+		sa := math.Sin(a[i] - meanA)
+		sb := math.Sin(b[i] - meanB)
+		sumAB += sa * sb
+		sumA2 += sa * sa
+		sumB2 += sb * sb
+	}
+
+	denom := math.Sqrt(sumA2 * sumB2)
+	if denom < 1e-12 {
+		return 0
+	}
+	return sumAB / denom
+}
@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCircularMean(t *testing.T) {
+	tests := []struct {
+		name   string
+		angles []float64
+		want   float64
+	}{
+		{"across wrap", []float64{0.1, 2*math.Pi - 0.1}, 0},
+		{"quarter turn", []float64{math.Pi / 4, 3 * math.Pi / 4}, math.Pi / 2},
+		{"whole turns", []float64{1, 1 + 2*math.Pi, 1 - 4*math.Pi}, 1},
+	}
+
+	for _, tt := range tests {
+		if got := CircularMean(tt.angles); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: CircularMean(%v) = %v, want %v", tt.name, tt.angles, got, tt.want)
+		}
+	}
+
+	if got := CircularMean(nil); !math.IsNaN(got) {
+		t.Errorf("CircularMean(nil) = %v, want NaN", got)
+	}
+	if got := CircularMean([]float64{0, math.Pi}); !math.IsNaN(got) {
+		t.Errorf("CircularMean(opposite) = %v, want NaN", got)
+	}
+}
+
+func TestCircularCorrelation_WrapInvariant(t *testing.T) {
+	n := 5000
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic for testing
+
+	a := make([]float64, n)
+	b := make([]float64, n)
+	wrapped := make([]float64, n)
+	for i := range a {
+		// Coupled phases centered on the 0/2π boundary
+		a[i] = 0.8 * rng.NormFloat64()
+		b[i] = a[i] + 0.2*rng.NormFloat64()
+		wrapped[i] = math.Mod(b[i]+2*math.Pi, 2*math.Pi)
+	}
+
+	r := CircularCorrelation(a, b)
+	if r < 0.8 {
+		t.Errorf("coupled phases: r = %.4f, want > 0.8", r)
+	}
+	if rw := CircularCorrelation(a, wrapped); math.Abs(rw-r) > 1e-9 {
+		t.Errorf("wrapping changed r: %.6f vs %.6f", rw, r)
+	}
+
+	if got := CircularCorrelation(a, b[:10]); !math.IsNaN(got) {
+		t.Errorf("length mismatch: r = %v, want NaN", got)
+	}
+}
@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// P2Quantile is an online estimator of a single quantile using the P² algorithm
+// of Jain and Chlamtac (1985).
+//
+// It keeps five markers (minimum, p/2, p, (1+p)/2 and maximum quantiles) whose
+// heights are adjusted with piecewise-parabolic interpolation as observations
+// arrive, so memory is O(1) regardless of the stream length. The first five
+// observations are stored exactly.
+//
+// Example:
+//
+//	q25, _ := NewP2Quantile(0.25)
+//	for _, x := range stream {
+//	    q25.Add(x)
+//	}
+//	fmt.Println(q25.Value())
+type P2Quantile struct {
+	p     float64
+	count int
+
+	q  [5]float64 // marker heights
+	n  [5]float64 // actual marker positions (1-based)
+	np [5]float64 // desired marker positions
+	dn [5]float64 // desired position increments
+}
+
+// NewP2Quantile creates an estimator of the p-quantile, 0 < p < 1.
+func NewP2Quantile(p float64) (*P2Quantile, error) {
+	if !(p > 0 && p < 1) {
+		return nil, fmt.Errorf("quantile must be in (0, 1), got %v", p)
+	}
+	return &P2Quantile{
+		p:  p,
+		dn: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}, nil
+}
+
+// Add incorporates one observation. NaN values are ignored.
+func (e *P2Quantile) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+
+	// Initialization: collect the first five observations
+	if e.count < 5 {
+		e.q[e.count] = x
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.q[:])
+			p := e.p
+			e.n = [5]float64{1, 2, 3, 4, 5}
+			e.np = [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5}
+		}
+		return
+	}
+	e.count++
+
+	// Find the cell k with q[k] <= x < q[k+1], extending the extremes if needed
+	var k int
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+		k = 0
+	case x >= e.q[4]:
+		e.q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < e.q[k+1] {
+				break
+			}
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		e.n[i]++
+	}
+	for i := range e.np {
+		e.np[i] += e.dn[i]
+	}
+
+	// Adjust the three middle markers
+	for i := 1; i <= 3; i++ {
+		d := e.np[i] - e.n[i]
+		if (d >= 1 && e.n[i+1]-e.n[i] > 1) || (d <= -1 && e.n[i-1]-e.n[i] < -1) {
+			s := math.Copysign(1, d)
+			h := e.parabolic(i, s)
+			if e.q[i-1] < h && h < e.q[i+1] {
+				e.q[i] = h
+			} else {
+				e.q[i] = e.linear(i, s)
+			}
+			e.n[i] += s
+		}
+	}
+}
+
+// parabolic returns the P² piecewise-parabolic height for marker i moved by s.
+func (e *P2Quantile) parabolic(i int, s float64) float64 {
+	return e.q[i] + s/(e.n[i+1]-e.n[i-1])*
+		((e.n[i]-e.n[i-1]+s)*(e.q[i+1]-e.q[i])/(e.n[i+1]-e.n[i])+
+			(e.n[i+1]-e.n[i]-s)*(e.q[i]-e.q[i-1])/(e.n[i]-e.n[i-1]))
+}
+
+// linear returns the linear-interpolation height for marker i moved by s.
+func (e *P2Quantile) linear(i int, s float64) float64 {
+	j := i + int(s)
+	return e.q[i] + s*(e.q[j]-e.q[i])/(e.n[j]-e.n[i])
+}
+
+// Value returns the current quantile estimate, or NaN if nothing was added.
+// With fewer than five observations the exact sample quantile is returned.
+func (e *P2Quantile) Value() float64 {
+	switch {
+	case e.count == 0:
+		return math.NaN()
+	case e.count < 5:
+		sorted := make([]float64, e.count)
+		copy(sorted, e.q[:e.count])
+		sort.Float64s(sorted)
+		return sorted[int(e.p*float64(e.count-1))]
+	default:
+		return e.q[2]
+	}
+}
+
+// Count returns the number of observations added.
+func (e *P2Quantile) Count() int {
+	return e.count
+}
@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestP2Quantile_LargeStream(t *testing.T) {
+	n := 200000
+
+	distributions := []struct {
+		name string
+		draw func(rng *rand.Rand) float64
+	}{
+		{"uniform", func(rng *rand.Rand) float64 { return rng.Float64() * 10 }},
+		{"normal", func(rng *rand.Rand) float64 { return 5 + 2*rng.NormFloat64() }},
+		{"exponential", func(rng *rand.Rand) float64 { return rng.ExpFloat64() }},
+	}
+
+	for _, dist := range distributions {
+		t.Run(dist.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic for testing
+			q25, _ := NewP2Quantile(0.25)
+			q75, _ := NewP2Quantile(0.75)
+
+			data := make([]float64, n)
+			for i := range data {
+				data[i] = dist.draw(rng)
+				q25.Add(data[i])
+				q75.Add(data[i])
+			}
+
+			sort.Float64s(data)
+			exact25 := data[int(0.25*float64(n-1))]
+			exact75 := data[int(0.75*float64(n-1))]
+
+			// Within 2% of the exact sorted quantile
+			for _, c := range []struct {
+				name       string
+				got, exact float64
+			}{
+				{"q25", q25.Value(), exact25},
+				{"q75", q75.Value(), exact75},
+			} {
+				if rel := math.Abs(c.got-c.exact) / math.Abs(c.exact); rel > 0.02 {
+					t.Errorf("%s: P² = %.4f, exact = %.4f (relative error %.2f%%)", c.name, c.got, c.exact, 100*rel)
+				}
+			}
+			t.Logf("q25: P²=%.4f exact=%.4f; q75: P²=%.4f exact=%.4f", q25.Value(), exact25, q75.Value(), exact75)
+		})
+	}
+}
+
+func TestP2Quantile_EdgeCases(t *testing.T) {
+	for _, p := range []float64{0, 1, -0.5, math.NaN()} {
+		if _, err := NewP2Quantile(p); err == nil {
+			t.Errorf("NewP2Quantile(%v) should fail", p)
+		}
+	}
+
+	e, err := NewP2Quantile(0.5)
+	if err != nil {
+		t.Fatalf("NewP2Quantile failed: %v", err)
+	}
+	if !math.IsNaN(e.Value()) {
+		t.Errorf("empty estimator: Value() = %v, want NaN", e.Value())
+	}
+
+	// Fewer than five observations: exact sample quantile
+	for _, x := range []float64{3, 1, 2} {
+		e.Add(x)
+	}
+	e.Add(math.NaN())
+	if e.Count() != 3 || e.Value() != 2 {
+		t.Errorf("after [3 1 2 NaN]: Count() = %d, Value() = %v, want 3, 2", e.Count(), e.Value())
+	}
+
+	// Constant stream
+	e, _ = NewP2Quantile(0.25)
+	for i := 0; i < 100; i++ {
+		e.Add(7)
+	}
+	if e.Value() != 7 {
+		t.Errorf("constant stream: Value() = %v, want 7", e.Value())
+	}
+}
@@ -0,0 +1,80 @@
+// Package stats provides shared statistical utilities for causal analysis.
+// It implements rank transforms used by rank-based direction methods and
+// quantile-based binning, online quantile estimation for streaming data, and
+// circular statistics for angular variables.
+package stats
+
+import "sort"
+
+// Tie handling methods for RankTransform.
+const (
+	// TieAverage assigns tied values the mean of the ranks they span.
+	// Example: [10, 20, 20, 30] → [1, 2.5, 2.5, 4]
+	TieAverage = "average"
+
+	// TieMin assigns tied values the lowest rank they span (competition ranking).
+	// Example: [10, 20, 20, 30] → [1, 2, 2, 4]
+	TieMin = "min"
+
+	// TieDense assigns tied values the same rank with no gaps between groups.
+	// Example: [10, 20, 20, 30] → [1, 2, 2, 3]
+	TieDense = "dense"
+)
+
+// RankTransform replaces each value with its 1-based rank in x.
+//
+// tieMethod controls how equal values are ranked:
+//   - "average": mean of the spanned ranks (default, used for Spearman correlation)
+//   - "min": lowest spanned rank
+//   - "dense": consecutive ranks without gaps
+//
+// Unknown methods fall back to "average". The input slice is not modified.
+//
+// Example:
+//
+//	ranks := RankTransform([]float64{3, 1, 3, 2}, TieAverage)
+//	// ranks = [3.5, 1, 3.5, 2]
+func RankTransform(x []float64, tieMethod string) []float64 {
+	n := len(x)
+	ranks := make([]float64, n)
+	if n == 0 {
+		return ranks
+	}
+
+	// Indices sorted by value (stable so equal values keep input order)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return x[order[a]] < x[order[b]]
+	})
+
+	dense := 0
+	for start := 0; start < n; {
+		// Find the end of the tie group [start, end)
+		end := start + 1
+		for end < n && x[order[end]] == x[order[start]] {
+			end++
+		}
+		dense++
+
+		var rank float64
+		switch tieMethod {
+		case TieMin:
+			rank = float64(start + 1)
+		case TieDense:
+			rank = float64(dense)
+		default:
+			// Mean of ranks start+1 .. end
+			rank = float64(start+1+end) / 2
+		}
+
+		for k := start; k < end; k++ {
+			ranks[order[k]] = rank
+		}
+		start = end
+	}
+
+	return ranks
+}
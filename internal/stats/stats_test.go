@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"testing"
+)
+
+func TestRankTransform(t *testing.T) {
+	// Repeated values: 20 appears twice, 40 three times
+	x := []float64{40, 10, 20, 40, 20, 40, 50}
+
+	tests := []struct {
+		name      string
+		tieMethod string
+		want      []float64
+	}{
+		{
+			name:      "average",
+			tieMethod: TieAverage,
+			want:      []float64{5, 1, 2.5, 5, 2.5, 5, 7},
+		},
+		{
+			name:      "min",
+			tieMethod: TieMin,
+			want:      []float64{4, 1, 2, 4, 2, 4, 7},
+		},
+		{
+			name:      "dense",
+			tieMethod: TieDense,
+			want:      []float64{3, 1, 2, 3, 2, 3, 4},
+		},
+		{
+			name:      "unknown method falls back to average",
+			tieMethod: "bogus",
+			want:      []float64{5, 1, 2.5, 5, 2.5, 5, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankTransform(x, tt.tieMethod)
+			if len(got) != len(tt.want) {
+				t.Fatalf("RankTransform() len = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("RankTransform()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRankTransform_NoTies(t *testing.T) {
+	x := []float64{0.3, -1.2, 5.0, 0.0}
+	want := []float64{3, 1, 4, 2}
+
+	for _, method := range []string{TieAverage, TieMin, TieDense} {
+		got := RankTransform(x, method)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: RankTransform()[%d] = %v, want %v", method, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestRankTransform_EdgeCases(t *testing.T) {
+	if got := RankTransform(nil, TieAverage); len(got) != 0 {
+		t.Errorf("RankTransform(nil) = %v, want empty", got)
+	}
+
+	// All values tied
+	got := RankTransform([]float64{7, 7, 7, 7}, TieAverage)
+	for i, r := range got {
+		if r != 2.5 {
+			t.Errorf("all-tied average rank[%d] = %v, want 2.5", i, r)
+		}
+	}
+
+	// Input must not be modified
+	x := []float64{3, 1, 2}
+	_ = RankTransform(x, TieMin)
+	if x[0] != 3 || x[1] != 1 || x[2] != 2 {
+		t.Errorf("RankTransform() modified input: %v", x)
+	}
+}
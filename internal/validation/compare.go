@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/causalgo/causalgo/surd"
+)
+
+// Reference holds expected SURD components exported from the Python reference.
+//
+// Keys use the Go convention: comma-separated 0-based agent indices
+// ("0", "0,1", ...), not the 1-based tuples used by the Python pickles.
+// Only the listed keys are compared, so a reference may be partial.
+//
+// JSON layout:
+//
+//	{
+//	  "system": "duplicated_input",
+//	  "redundant":   {"0,1": 0.99997},
+//	  "unique":      {"0": 0.0, "1": 0.0},
+//	  "synergistic": {"0,1": 0.0},
+//	  "info_leak":   0.0
+//	}
+type Reference struct {
+	// System is a descriptive name of the reference system.
+	System string `json:"system"`
+
+	// Redundant, Unique and Synergistic hold the expected components.
+	Redundant   map[string]float64 `json:"redundant"`
+	Unique      map[string]float64 `json:"unique"`
+	Synergistic map[string]float64 `json:"synergistic"`
+
+	// InfoLeak is the expected information leak (nil = not compared).
+	InfoLeak *float64 `json:"info_leak,omitempty"`
+}
+
+// Mismatch describes a single component that differs from the reference.
+type Mismatch struct {
+	// Component is "redundant", "unique", "synergistic", "info_leak" or
+	// "reference" (the reference file could not be loaded).
+	Component string
+
+	// Key is the agent combination, e.g. "0,1" (empty for info_leak).
+	Key string
+
+	// Expected and Actual are the reference and computed values.
+	Expected float64
+	Actual   float64
+
+	// Reason explains the mismatch ("missing" if Key is absent in the result).
+	Reason string
+}
+
+// String formats the mismatch for test logs.
+func (m Mismatch) String() string {
+	if m.Component == "reference" {
+		return fmt.Sprintf("reference: %s", m.Reason)
+	}
+	return fmt.Sprintf("%s[%s]: expected %.6f, got %.6f (%s)", m.Component, m.Key, m.Expected, m.Actual, m.Reason)
+}
+
+// LoadReference reads a reference JSON file.
+func LoadReference(path string) (*Reference, error) {
+	raw, err := os.ReadFile(path) //nolint:gosec // G304: reference path is provided by tests
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference: %w", err)
+	}
+
+	var ref Reference
+	if err := json.Unmarshal(raw, &ref); err != nil {
+		return nil, fmt.Errorf("failed to parse reference %s: %w", path, err)
+	}
+	return &ref, nil
+}
+
+// CompareToReference compares a SURD result with the reference file at refPath.
+//
+// Values match when |actual - expected| <= tol. An empty slice means the result
+// matches. A reference that cannot be loaded is reported as a single Mismatch
+// with Component "reference".
+func CompareToReference(result *surd.Result, refPath string, tol float64) []Mismatch {
+	ref, err := LoadReference(refPath)
+	if err != nil {
+		return []Mismatch{{Component: "reference", Reason: err.Error()}}
+	}
+	return ref.Compare(result, tol)
+}
+
+// Compare returns the components of result that differ from the reference.
+// Mismatches are ordered by component and key.
+func (ref *Reference) Compare(result *surd.Result, tol float64) []Mismatch {
+	if result == nil {
+		return []Mismatch{{Component: "reference", Reason: "result is nil"}}
+	}
+
+	var mismatches []Mismatch
+	mismatches = compareComponent(mismatches, "redundant", ref.Redundant, result.Redundant, tol)
+	mismatches = compareComponent(mismatches, "unique", ref.Unique, result.Unique, tol)
+	mismatches = compareComponent(mismatches, "synergistic", ref.Synergistic, result.Synergistic, tol)
+
+	if ref.InfoLeak != nil && math.Abs(result.InfoLeak-*ref.InfoLeak) > tol {
+		mismatches = append(mismatches, Mismatch{
+			Component: "info_leak",
+			Expected:  *ref.InfoLeak,
+			Actual:    result.InfoLeak,
+			Reason:    fmt.Sprintf("differs by %.6f", result.InfoLeak-*ref.InfoLeak),
+		})
+	}
+
+	return mismatches
+}
+
+// compareComponent appends mismatches for one component map in sorted key order.
+func compareComponent(mismatches []Mismatch, component string, expected, actual map[string]float64, tol float64) []Mismatch {
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		want := expected[key]
+		got, ok := actual[key]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, Mismatch{
+				Component: component, Key: key, Expected: want, Reason: "missing",
+			})
+		case math.Abs(got-want) > tol:
+			mismatches = append(mismatches, Mismatch{
+				Component: component, Key: key, Expected: want, Actual: got,
+				Reason: fmt.Sprintf("differs by %.6f", got-want),
+			})
+		}
+	}
+	return mismatches
+}
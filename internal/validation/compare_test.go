@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/causalgo/causalgo/surd"
+)
+
+// Reference fixture for GenerateDuplicatedInput(20000, 1, 42)
+const duplicatedInputRefFile = "../../testdata/results/duplicated_input.json"
+
+func TestCompareToReference(t *testing.T) {
+	data := GenerateDuplicatedInput(20000, testDT, testSeed)
+	result, err := surd.DecomposeFromData(data, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	if mismatches := CompareToReference(result, duplicatedInputRefFile, 1e-4); len(mismatches) != 0 {
+		for _, m := range mismatches {
+			t.Errorf("unexpected mismatch: %s", m)
+		}
+	}
+
+	// Injected mismatch: shift unique information of agent 0, drop synergy key
+	result.Unique["0"] += 0.25
+	delete(result.Synergistic, "0,1")
+
+	mismatches := CompareToReference(result, duplicatedInputRefFile, 1e-4)
+	if len(mismatches) != 2 {
+		t.Fatalf("got %d mismatches, want 2: %v", len(mismatches), mismatches)
+	}
+	if m := mismatches[0]; m.Component != "unique" || m.Key != "0" || m.Actual != 0.25 {
+		t.Errorf("first mismatch = %s, want unique[0] = 0.25", m)
+	}
+	if m := mismatches[1]; m.Component != "synergistic" || m.Key != "0,1" || m.Reason != "missing" {
+		t.Errorf("second mismatch = %s, want missing synergistic[0,1]", m)
+	}
+}
+
+func TestCompareToReference_BadFile(t *testing.T) {
+	result := &surd.Result{}
+
+	mismatches := CompareToReference(result, "../../testdata/results/does_not_exist.json", 1e-4)
+	if len(mismatches) != 1 || mismatches[0].Component != "reference" {
+		t.Errorf("missing reference file: got %v, want single reference mismatch", mismatches)
+	}
+}
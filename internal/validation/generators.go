@@ -2,6 +2,7 @@
 package validation
 
 import (
+	"math"
 	"math/rand"
 )
 
@@ -179,3 +180,141 @@ func GenerateXORSystem(n int, dt int, seed int64) [][]float64 {
 
 	return data
 }
+
+// GenerateMixed creates a test system whose information is split between a
+// synergistic (XOR) part and a redundant (shared) part in a tunable proportion.
+//
+// The target carries two independent bits, encoded as target = 2·z + s:
+//
+//	s ~ Bernoulli(p_s)       shared bit, seen by both agents
+//	z ~ Bernoulli(p_z)       XOR bit: a1 uniform, a2 = a1 XOR z
+//	agent1 = 2·s + a1, agent2 = 2·s + a2
+//
+// p_z and p_s are chosen so that H(z) = synergyFraction and
+// H(s) = 1 - synergyFraction bits, so the target always carries 1 bit.
+// synergyFraction is clamped to [0, 1]. Use 4 bins per variable.
+//
+// Expected SURD decomposition:
+//   - Synergistic: ~synergyFraction bits (z needs both agents)
+//   - Redundant: ~(1 - synergyFraction) bits (s is known to either agent)
+//   - Unique: ~0.0 bits
+//   - InfoLeak: ~0.0 (target fully determined by the agents)
+func GenerateMixed(n int, synergyFraction float64, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // G404: using seeded random for reproducible test data
+
+	synergyFraction = math.Max(0, math.Min(1, synergyFraction))
+	pz := inverseBinaryEntropy(synergyFraction)
+	ps := inverseBinaryEntropy(1 - synergyFraction)
+
+	data := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		a1 := rng.Intn(2)
+		z, s := 0, 0
+		if rng.Float64() < pz {
+			z = 1
+		}
+		if rng.Float64() < ps {
+			s = 1
+		}
+		a2 := a1 ^ z
+
+		data[i] = []float64{
+			float64(2*z + s), // target
+			float64(2*s + a1),
+			float64(2*s + a2),
+		}
+	}
+
+	return data
+}
+
+// Parameters of GenerateLogisticMap.
+const (
+	logisticR        = 4.0 // fully chaotic regime of the logistic map
+	logisticCoupling = 0.2 // drive strength of x on y, below synchronization (~0.5)
+	logisticBurnIn   = 100 // discarded iterations after the random initial state
+)
+
+// GenerateLogisticMap creates a deterministic chaotic test system of two
+// unidirectionally coupled logistic maps (nonlinear causality), with coupling
+// strength c = 0.2. See GenerateCoupledLogisticMap.
+func GenerateLogisticMap(samples, dt int, seed int64) [][]float64 {
+	return GenerateCoupledLogisticMap(samples, dt, logisticCoupling, seed)
+}
+
+// GenerateCoupledLogisticMap creates two logistic maps where x drives y with
+// coupling strength c in [0, 1]:
+//
+//	f(v) = 4·v·(1 - v)
+//	x[t+1] = f(x[t])
+//	y[t+1] = (1 - c)·f(y[t]) + c·f(x[t])
+//
+// Rows are [y[t+dt], x[t], y[t]]: the target is the driven map's future,
+// agent 1 the driver and agent 2 the driven map's own past (SURD keys "0"
+// and "1"). x acts on y only
+// through the coupling, so its causal share grows with c and vanishes at
+// c = 0, where y is an autonomous map. The seed only sets the initial state;
+// the dynamics are noise-free. Use 8 bins per variable.
+//
+// Expected SURD decomposition (dt = 1, 8 bins):
+//   - c = 0: Unique[agent2] ~1.56 bits, agent 1 ~0 bits (y evolves alone)
+//   - c = 0.2: Unique[agent2] ~0.86, Synergistic ~0.64, Redundant ~0.41 bits
+//   - Unique[agent1] + Synergistic: grows with c up to c ≈ 0.3
+//   - InfoLeak: ~0.3-0.45 (binning loses part of the chaotic dynamics)
+func GenerateCoupledLogisticMap(samples, dt int, coupling float64, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // G404: using seeded random for reproducible test data
+
+	c := math.Max(0, math.Min(1, coupling))
+	f := func(v float64) float64 { return logisticR * v * (1 - v) }
+	step := func(x, y float64) (float64, float64) {
+		return f(x), (1-c)*f(y) + c*f(x)
+	}
+
+	// Initial state away from the fixed points 0 and 3/4
+	x := 0.1 + 0.8*rng.Float64()
+	y := 0.1 + 0.8*rng.Float64()
+	for i := 0; i < logisticBurnIn; i++ {
+		x, y = step(x, y)
+	}
+
+	totalN := samples + dt
+	xs := make([]float64, totalN)
+	ys := make([]float64, totalN)
+	for i := 0; i < totalN; i++ {
+		xs[i], ys[i] = x, y
+		x, y = step(x, y)
+	}
+
+	data := make([][]float64, samples)
+	for i := 0; i < samples; i++ {
+		data[i] = []float64{
+			ys[i+dt], // target: driven map's future
+			xs[i],    // driver
+			ys[i],    // driven map
+		}
+	}
+
+	return data
+}
+
+// inverseBinaryEntropy returns p in [0, 0.5] with binary entropy h(p) = bits,
+// for bits in [0, 1], by bisection.
+func inverseBinaryEntropy(bits float64) float64 {
+	h := func(p float64) float64 {
+		if p <= 0 || p >= 1 {
+			return 0
+		}
+		return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+	}
+
+	lo, hi := 0.0, 0.5
+	for iter := 0; iter < 60; iter++ {
+		mid := (lo + hi) / 2
+		if h(mid) < bits {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
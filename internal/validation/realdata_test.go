@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/causalgo/causalgo/internal/histogram"
 	"github.com/causalgo/causalgo/pkg/matdata"
 	"github.com/causalgo/causalgo/surd"
 )
@@ -190,6 +191,118 @@ func assertClose(t *testing.T, name string, got, want, tolerance float64) {
 	}
 }
 
+// TestSURD_EnergyCascadeCompleteness checks that R + U + S matches the total
+// mutual information on real data, for the full estimate and under bootstrap.
+func TestSURD_EnergyCascadeCompleteness(t *testing.T) {
+	data, err := matdata.LoadMatrixTransposed(energyCascadeMATFile, "X")
+	if err != nil {
+		t.Skipf("Skipping test: cannot load MATLAB file (%v)", err)
+	}
+
+	nbins := 10
+	nlags := []int{1, 19, 11, 6}
+
+	for i, lag := range nlags {
+		Y, err := matdata.PrepareWithLag(data, i, lag)
+		if err != nil {
+			t.Fatalf("Failed to prepare data with lag: %v", err)
+		}
+		bins := make([]int, len(Y[0]))
+		for j := range bins {
+			bins[j] = nbins
+		}
+
+		result, err := surd.DecomposeFromData(Y, bins)
+		if err != nil {
+			t.Fatalf("SURD decomposition failed: %v", err)
+		}
+		residual := surd.CompletenessResidual(result)
+
+		nBoot := 2
+		boot, err := surd.BootstrapCompletenessResidual(Y, bins, surd.DefaultOptions(), nBoot, 1)
+		if err != nil {
+			t.Fatalf("bootstrap failed: %v", err)
+		}
+
+		worst := math.Abs(residual)
+		for _, r := range boot {
+			worst = math.Max(worst, math.Abs(r))
+		}
+		t.Logf("Signal %d: residual %.3g bits, worst over %d resamples %.3g bits", i+1, residual, nBoot, worst)
+
+		if worst > 1e-6 {
+			t.Errorf("Signal %d: completeness residual %.3g bits exceeds 1e-6", i+1, worst)
+		}
+	}
+}
+
+// TestSURD_EnergyCascadeDebiasedMI checks that jackknife-debiased MI on
+// subsamples is closer to the large-sample limit (plug-in MI on the full
+// series) than the plug-in MI on the same subsamples. Estimates are averaged
+// over disjoint subsamples so that bias, not sampling noise, is compared.
+func TestSURD_EnergyCascadeDebiasedMI(t *testing.T) {
+	data, err := matdata.LoadMatrixTransposed(energyCascadeMATFile, "X")
+	if err != nil {
+		t.Skipf("Skipping test: cannot load MATLAB file (%v)", err)
+	}
+
+	Y, err := matdata.PrepareWithLag(data, 0, 1)
+	if err != nil {
+		t.Fatalf("Failed to prepare data with lag: %v", err)
+	}
+	nbins := 4
+	bins := make([]int, len(Y[0]))
+	minVals := make([]float64, len(Y[0]))
+	maxVals := make([]float64, len(Y[0]))
+	for j := range bins {
+		bins[j] = nbins
+		minVals[j], maxVals[j] = math.Inf(1), math.Inf(-1)
+		for _, row := range Y {
+			minVals[j] = math.Min(minVals[j], row[j])
+			maxVals[j] = math.Max(maxVals[j], row[j])
+		}
+	}
+
+	// Same bin edges for the full series and the subsample
+	decompose := func(rows [][]float64, opts surd.Options) *surd.Result {
+		hist, err := histogram.NewNDHistogramWithRanges(rows, bins, minVals, maxVals)
+		if err != nil {
+			t.Fatalf("histogram failed: %v", err)
+		}
+		result, err := surd.DecomposeWithOptions(hist, opts)
+		if err != nil {
+			t.Fatalf("SURD decomposition failed: %v", err)
+		}
+		return result
+	}
+
+	allAgents := "0,1,2,3"
+	limit := decompose(Y, surd.DefaultOptions()).MutualInfo[allAgents]
+
+	// Disjoint subsamples: every stride-th row, one per offset
+	const stride = 20
+	var plugin, debiased float64
+	for offset := 0; offset < stride; offset++ {
+		var sub [][]float64
+		for i := offset; i < len(Y); i += stride {
+			sub = append(sub, Y[i])
+		}
+
+		opts := surd.DefaultOptions()
+		plugin += decompose(sub, opts).MutualInfo[allAgents] / stride
+		opts.DebiasedMI = true
+		opts.NSamples = len(sub)
+		debiased += decompose(sub, opts).MutualInfo[allAgents] / stride
+	}
+
+	t.Logf("I(target; all agents): limit %.4f (N=%d), mean over subsamples of N=%d: plug-in %.4f, debiased %.4f",
+		limit, len(Y), len(Y)/stride, plugin, debiased)
+
+	if errDebiased, errPlugin := math.Abs(debiased-limit), math.Abs(plugin-limit); errDebiased >= errPlugin {
+		t.Errorf("debiased MI error %.4f not smaller than plug-in error %.4f", errDebiased, errPlugin)
+	}
+}
+
 // BenchmarkSURD_EnergyCascade benchmarks SURD on real-world data.
 func BenchmarkSURD_EnergyCascade(b *testing.B) {
 	data, err := matdata.LoadMatrixTransposed(energyCascadeMATFile, "X")
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/causalgo/causalgo/surd"
@@ -41,43 +42,27 @@ func TestDuplicatedInput(t *testing.T) {
 		}
 	}
 
-	// Calculate total decomposition
-	totalRedundant := 0.0
-	for _, r := range result.Redundant {
-		totalRedundant += r
-	}
-
-	totalUnique := 0.0
-	for _, u := range result.Unique {
-		totalUnique += u
-	}
-
-	totalSynergistic := 0.0
-	for _, s := range result.Synergistic {
-		totalSynergistic += s
-	}
-
-	// Total information = R + U + S
-	totalInfo := totalRedundant + totalUnique + totalSynergistic
+	// Fractions of total information R + U + S
+	n := result.Normalized()
 
 	t.Logf("Duplicated Input System:")
 	t.Logf("  Max MI: %.4f bits", totalMI)
-	t.Logf("  Redundant: %.4f bits (%.1f%%)", totalRedundant, 100*totalRedundant/totalInfo)
-	t.Logf("  Unique: %.4f bits (%.1f%%)", totalUnique, 100*totalUnique/totalInfo)
-	t.Logf("  Synergistic: %.4f bits (%.1f%%)", totalSynergistic, 100*totalSynergistic/totalInfo)
+	t.Logf("  Redundant: %.1f%%", 100*n.TotalRedundant)
+	t.Logf("  Unique: %.1f%%", 100*n.TotalUnique)
+	t.Logf("  Synergistic: %.1f%%", 100*n.TotalSynergistic)
 	t.Logf("  InfoLeak: %.4f", result.InfoLeak)
 
 	// Assertions: Redundant should dominate
-	if totalRedundant < 0.5*totalInfo {
-		t.Errorf("Expected Redundant to dominate (>50%%), got %.1f%%", 100*totalRedundant/totalInfo)
+	if n.TotalRedundant < 0.5 {
+		t.Errorf("Expected Redundant to dominate (>50%%), got %.1f%%", 100*n.TotalRedundant)
 	}
 
-	if totalUnique > 0.2*totalInfo {
-		t.Errorf("Expected Unique to be small (<20%%), got %.1f%%", 100*totalUnique/totalInfo)
+	if n.TotalUnique > 0.2 {
+		t.Errorf("Expected Unique to be small (<20%%), got %.1f%%", 100*n.TotalUnique)
 	}
 
-	if totalSynergistic > 0.2*totalInfo {
-		t.Errorf("Expected Synergistic to be small (<20%%), got %.1f%%", 100*totalSynergistic/totalInfo)
+	if n.TotalSynergistic > 0.2 {
+		t.Errorf("Expected Synergistic to be small (<20%%), got %.1f%%", 100*n.TotalSynergistic)
 	}
 }
 
@@ -109,31 +94,19 @@ func TestIndependentInputs(t *testing.T) {
 	unique0 := result.Unique["0"] // agent1
 	unique1 := result.Unique["1"] // agent2
 
-	// Calculate totals
-	totalRedundant := 0.0
-	for _, r := range result.Redundant {
-		totalRedundant += r
-	}
-
-	totalUnique := unique0 + unique1
-
-	totalSynergistic := 0.0
-	for _, s := range result.Synergistic {
-		totalSynergistic += s
-	}
-
-	totalInfo := totalRedundant + totalUnique + totalSynergistic
+	// Fractions of total information R + U + S
+	n := result.Normalized()
 
 	t.Logf("Independent Inputs System:")
-	t.Logf("  Unique[agent1]: %.4f bits (%.1f%%)", unique0, 100*unique0/totalInfo)
-	t.Logf("  Unique[agent2]: %.4f bits (%.1f%%)", unique1, 100*unique1/totalInfo)
-	t.Logf("  Redundant: %.4f bits (%.1f%%)", totalRedundant, 100*totalRedundant/totalInfo)
-	t.Logf("  Synergistic: %.4f bits (%.1f%%)", totalSynergistic, 100*totalSynergistic/totalInfo)
+	t.Logf("  Unique[agent1]: %.4f bits (%.1f%%)", unique0, 100*n.Unique["0"])
+	t.Logf("  Unique[agent2]: %.4f bits (%.1f%%)", unique1, 100*n.Unique["1"])
+	t.Logf("  Redundant: %.1f%%", 100*n.TotalRedundant)
+	t.Logf("  Synergistic: %.1f%%", 100*n.TotalSynergistic)
 	t.Logf("  InfoLeak: %.4f", result.InfoLeak)
 
 	// Assertions: Unique[agent1] should dominate
-	if unique0 < 0.5*totalInfo {
-		t.Errorf("Expected Unique[agent1] to dominate (>50%%), got %.1f%%", 100*unique0/totalInfo)
+	if n.Unique["0"] < 0.5 {
+		t.Errorf("Expected Unique[agent1] to dominate (>50%%), got %.1f%%", 100*n.Unique["0"])
 	}
 
 	// Unique[agent1] should be much larger than Unique[agent2]
@@ -141,12 +114,12 @@ func TestIndependentInputs(t *testing.T) {
 		t.Errorf("Expected Unique[agent1] (%.4f) >> Unique[agent2] (%.4f)", unique0, unique1)
 	}
 
-	if totalRedundant > 0.2*totalInfo {
-		t.Errorf("Expected Redundant to be small (<20%%), got %.1f%%", 100*totalRedundant/totalInfo)
+	if n.TotalRedundant > 0.2 {
+		t.Errorf("Expected Redundant to be small (<20%%), got %.1f%%", 100*n.TotalRedundant)
 	}
 
-	if totalSynergistic > 0.2*totalInfo {
-		t.Errorf("Expected Synergistic to be small (<20%%), got %.1f%%", 100*totalSynergistic/totalInfo)
+	if n.TotalSynergistic > 0.2 {
+		t.Errorf("Expected Synergistic to be small (<20%%), got %.1f%%", 100*n.TotalSynergistic)
 	}
 }
 
@@ -173,41 +146,110 @@ func TestXORSystem(t *testing.T) {
 		t.Fatalf("DecomposeFromData failed: %v", err)
 	}
 
-	// Calculate totals
-	totalRedundant := 0.0
-	for _, r := range result.Redundant {
-		totalRedundant += r
+	// Fractions of total information R + U + S
+	n := result.Normalized()
+
+	t.Logf("XOR System:")
+	t.Logf("  Synergistic: %.1f%%", 100*n.TotalSynergistic)
+	t.Logf("  Unique: %.1f%%", 100*n.TotalUnique)
+	t.Logf("  Redundant: %.1f%%", 100*n.TotalRedundant)
+	t.Logf("  InfoLeak: %.4f", result.InfoLeak)
+
+	// Assertions: Synergistic should dominate
+	if n.TotalSynergistic < 0.5 {
+		t.Errorf("Expected Synergistic to dominate (>50%%), got %.1f%%", 100*n.TotalSynergistic)
 	}
 
-	totalUnique := 0.0
-	for _, u := range result.Unique {
-		totalUnique += u
+	if n.TotalUnique > 0.2 {
+		t.Errorf("Expected Unique to be small (<20%%), got %.1f%%", 100*n.TotalUnique)
 	}
 
-	totalSynergistic := 0.0
-	for _, s := range result.Synergistic {
-		totalSynergistic += s
+	if n.TotalRedundant > 0.2 {
+		t.Errorf("Expected Redundant to be small (<20%%), got %.1f%%", 100*n.TotalRedundant)
 	}
+}
 
-	totalInfo := totalRedundant + totalUnique + totalSynergistic
+// TestMixedSystem sweeps the synergy fraction of GenerateMixed and checks that
+// SURD recovers the synergy/redundancy split.
+func TestMixedSystem(t *testing.T) {
+	bins := []int{4, 4, 4}
 
-	t.Logf("XOR System:")
-	t.Logf("  Synergistic: %.4f bits (%.1f%%)", totalSynergistic, 100*totalSynergistic/totalInfo)
-	t.Logf("  Unique: %.4f bits (%.1f%%)", totalUnique, 100*totalUnique/totalInfo)
-	t.Logf("  Redundant: %.4f bits (%.1f%%)", totalRedundant, 100*totalRedundant/totalInfo)
-	t.Logf("  InfoLeak: %.4f", result.InfoLeak)
+	prev := -1.0
+	for _, fraction := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 1} {
+		data := GenerateMixed(100000, fraction, testSeed)
 
-	// Assertions: Synergistic should dominate
-	if totalSynergistic < 0.5*totalInfo {
-		t.Errorf("Expected Synergistic to dominate (>50%%), got %.1f%%", 100*totalSynergistic/totalInfo)
+		result, err := surd.DecomposeFromData(data, bins)
+		if err != nil {
+			t.Fatalf("DecomposeFromData failed: %v", err)
+		}
+
+		// Fractions of total information R + U + S
+		n := result.Normalized()
+		recovered := n.TotalSynergistic
+
+		t.Logf("fraction %.2f: S=%.1f%% R=%.1f%% U=%.1f%% of %.4f bits",
+			fraction, 100*n.TotalSynergistic, 100*n.TotalRedundant, 100*n.TotalUnique, n.TotalInformation)
+
+		if recovered <= prev {
+			t.Errorf("fraction %.2f: recovered synergy fraction %.3f did not increase (previous %.3f)",
+				fraction, recovered, prev)
+		}
+		if math.Abs(recovered-fraction) > 0.05 {
+			t.Errorf("fraction %.2f: recovered synergy fraction %.3f", fraction, recovered)
+		}
+		prev = recovered
 	}
+}
 
-	if totalUnique > 0.2*totalInfo {
-		t.Errorf("Expected Unique to be small (<20%%), got %.1f%%", 100*totalUnique/totalInfo)
+// TestLogisticMap tests SURD on coupled chaotic logistic maps (nonlinear).
+//
+// The target is the driven map's future. The driver's causal share (its
+// unique and synergistic information) must vanish without coupling and grow
+// with the coupling strength.
+func TestLogisticMap(t *testing.T) {
+	bins := []int{8, 8, 8}
+
+	prev := -1.0
+	for _, coupling := range []float64{0, 0.05, 0.1, 0.2, 0.3} {
+		data := GenerateCoupledLogisticMap(200000, testDT, coupling, testSeed)
+
+		result, err := surd.DecomposeFromData(data, bins)
+		if err != nil {
+			t.Fatalf("DecomposeFromData failed: %v", err)
+		}
+
+		// Fractions of total information R + U + S
+		n := result.Normalized()
+		share := n.Unique["0"] + n.Synergistic["0,1"]
+
+		t.Logf("coupling %.2f: U[driver]=%.4f U[driven]=%.4f S=%.4f R=%.4f → driver share %.3f, InfoLeak %.4f",
+			coupling, result.Unique["0"], result.Unique["1"], result.Synergistic["0,1"],
+			result.Redundant["0,1"], share, result.InfoLeak)
+
+		if coupling == 0 {
+			if share > 0.01 {
+				t.Errorf("coupling 0: driver share %.3f, want ~0", share)
+			}
+			if n.Unique["1"] < 0.95 {
+				t.Errorf("coupling 0: Unique[driven] %.1f%%, want ~100%%", 100*n.Unique["1"])
+			}
+		}
+		if share <= prev {
+			t.Errorf("coupling %.2f: driver share %.3f did not increase (previous %.3f)",
+				coupling, share, prev)
+		}
+		prev = share
 	}
 
-	if totalRedundant > 0.2*totalInfo {
-		t.Errorf("Expected Redundant to be small (<20%%), got %.1f%%", 100*totalRedundant/totalInfo)
+	// Same seed, same trajectory
+	data := GenerateLogisticMap(10, testDT, testSeed)
+	again := GenerateLogisticMap(10, testDT, testSeed)
+	for i := range again {
+		for j := range again[i] {
+			if again[i][j] != data[i][j] {
+				t.Fatalf("GenerateLogisticMap not reproducible at [%d][%d]", i, j)
+			}
+		}
 	}
 }
 
@@ -234,28 +276,13 @@ func TestAllSystems(t *testing.T) {
 				t.Fatalf("DecomposeFromData failed: %v", err)
 			}
 
-			// Calculate totals
-			totalR := 0.0
-			for _, r := range result.Redundant {
-				totalR += r
-			}
-
-			totalU := 0.0
-			for _, u := range result.Unique {
-				totalU += u
-			}
-
-			totalS := 0.0
-			for _, s := range result.Synergistic {
-				totalS += s
-			}
-
-			total := totalR + totalU + totalS
+			// Fractions of total information R + U + S
+			n := result.Normalized()
 
-			t.Logf("%s System:", sys.name)
-			t.Logf("  R: %.4f (%.1f%%)", totalR, 100*totalR/total)
-			t.Logf("  U: %.4f (%.1f%%)", totalU, 100*totalU/total)
-			t.Logf("  S: %.4f (%.1f%%)", totalS, 100*totalS/total)
+			t.Logf("%s System (%.4f bits):", sys.name, n.TotalInformation)
+			t.Logf("  R: %.1f%%", 100*n.TotalRedundant)
+			t.Logf("  U: %.1f%%", 100*n.TotalUnique)
+			t.Logf("  S: %.1f%%", 100*n.TotalSynergistic)
 			t.Logf("  InfoLeak: %.4f", result.InfoLeak)
 		})
 	}
@@ -0,0 +1,116 @@
+package varselect
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// chainData returns x0 -> x1 -> x2 with small noise
+func chainData(n int, noise float64, seed int64) *mat.Dense {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // G404: test data
+	data := mat.NewDense(n, 3, nil)
+	for i := 0; i < n; i++ {
+		x0 := rng.NormFloat64()
+		x1 := 0.9*x0 + noise*rng.NormFloat64()
+		x2 := 0.9*x1 + noise*rng.NormFloat64()
+		data.SetRow(i, []float64{x0, x1, x2})
+	}
+	return data
+}
+
+// TestFitStability checks the frequency tables of a low-noise chain
+func TestFitStability(t *testing.T) {
+	data := chainData(200, 0.1, 1)
+	selector := New(Config{Lambda: 0.01})
+
+	stab, err := selector.FitStability(data, 30, 7)
+	if err != nil {
+		t.Fatalf("FitStability error: %v", err)
+	}
+	if stab.NBoot != 30 {
+		t.Errorf("NBoot = %d, want 30", stab.NBoot)
+	}
+
+	full, err := selector.Fit(data)
+	if err != nil {
+		t.Fatalf("Fit error: %v", err)
+	}
+
+	for k, row := range stab.PositionFreq {
+		sum := 0.0
+		for _, f := range row {
+			if f < 0 || f > 1 {
+				t.Errorf("PositionFreq[%d] = %v, want values in [0, 1]", k, row)
+			}
+			sum += f
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("PositionFreq[%d] sums to %v, want 1", k, sum)
+		}
+
+	}
+
+	// The middle of the chain is best explained by the others and comes first
+	// in every run; the two ends are nearly interchangeable, so their
+	// positions are split between runs.
+	if f := stab.PositionFreq[0][full.Order[0]]; f < 0.9 {
+		t.Errorf("position 0: variable %d chosen in %.2f of runs, want >= 0.9", full.Order[0], f)
+	}
+	for i := range stab.EdgeFreq {
+		for j, f := range stab.EdgeFreq[i] {
+			if f < 0 || f > 1 {
+				t.Errorf("EdgeFreq[%d][%d] = %v, want [0, 1]", i, j, f)
+			}
+			if i == j && f != 0 {
+				t.Errorf("EdgeFreq[%d][%d] = %v, want 0 on the diagonal", i, j, f)
+			}
+		}
+	}
+
+	t.Logf("Full-data order: %v", full.Order)
+	t.Logf("Position frequencies: %v", stab.PositionFreq)
+	t.Logf("Edge frequencies: %v", stab.EdgeFreq)
+
+	// Same seed, same frequencies
+	again, err := selector.FitStability(data, 30, 7)
+	if err != nil {
+		t.Fatalf("FitStability error: %v", err)
+	}
+	if !mat.Equal(toDense(stab.PositionFreq), toDense(again.PositionFreq)) ||
+		!mat.Equal(toDense(stab.EdgeFreq), toDense(again.EdgeFreq)) {
+		t.Error("FitStability not reproducible for a fixed seed")
+	}
+}
+
+// TestFitStability_Errors tests invalid inputs
+func TestFitStability_Errors(t *testing.T) {
+	selector := New(Config{})
+	tests := []struct {
+		name  string
+		data  *mat.Dense
+		nboot int
+	}{
+		{"Nil matrix", nil, 10},
+		{"Single row", mat.NewDense(1, 3, []float64{1, 2, 3}), 10},
+		{"Zero runs", chainData(20, 0.1, 1), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := selector.FitStability(tt.data, tt.nboot, 1); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func toDense(rows [][]float64) *mat.Dense {
+	m := mat.NewDense(len(rows), len(rows[0]), nil)
+	for i, row := range rows {
+		m.SetRow(i, row)
+	}
+	return m
+}
@@ -42,6 +42,42 @@ type Selector struct {
 
 // New creates a new Selector with default LASSO regressor
 func New(cfg Config) *Selector {
+	cfg = cfg.withDefaults()
+
+	lassoReg := regression.NewLASSO(regression.LASSOConfig{
+		Lambda:    cfg.Lambda,
+		Tolerance: cfg.Tolerance,
+		MaxIter:   cfg.MaxIter,
+	})
+
+	return &Selector{
+		config:    cfg,
+		regressor: lassoReg,
+	}
+}
+
+// NewElasticNet creates a new Selector with an elastic-net regressor.
+// l1Ratio mixes the penalties: 1 is LASSO, 0 is ridge regression. Only the
+// regression of each variable on the remaining ones changes; Fit still
+// orders variables by residual MSE exactly as with New.
+func NewElasticNet(cfg Config, l1Ratio float64) *Selector {
+	cfg = cfg.withDefaults()
+
+	enetReg := regression.NewElasticNet(regression.ElasticNetConfig{
+		Lambda:    cfg.Lambda,
+		L1Ratio:   l1Ratio,
+		Tolerance: cfg.Tolerance,
+		MaxIter:   cfg.MaxIter,
+	})
+
+	return &Selector{
+		config:    cfg,
+		regressor: enetReg,
+	}
+}
+
+// withDefaults returns cfg with unset fields replaced by their defaults
+func (cfg Config) withDefaults() Config {
 	if cfg.Lambda <= 0 {
 		cfg.Lambda = 0.01
 	}
@@ -54,17 +90,7 @@ func New(cfg Config) *Selector {
 	if cfg.Workers <= 0 {
 		cfg.Workers = 4
 	}
-
-	lassoReg := regression.NewLASSO(regression.LASSOConfig{
-		Lambda:    cfg.Lambda,
-		Tolerance: cfg.Tolerance,
-		MaxIter:   cfg.MaxIter,
-	})
-
-	return &Selector{
-		config:    cfg,
-		regressor: lassoReg,
-	}
+	return cfg
 }
 
 // SetRegressor sets a custom regressor implementation
@@ -6,6 +6,7 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/causalgo/causalgo/regression"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -261,3 +262,28 @@ func (m *MockRegressor) Fit(x *mat.Dense, y []float64) []float64 {
 	}
 	return make([]float64, p)
 }
+
+// TestNewElasticNet verifies the elastic-net selector setup
+func TestNewElasticNet(t *testing.T) {
+	selector := NewElasticNet(Config{}, 0.3)
+
+	if _, ok := selector.regressor.(*regression.ElasticNet); !ok {
+		t.Errorf("expected *regression.ElasticNet, got %T", selector.regressor)
+	}
+	if selector.config.Lambda != 0.01 || selector.config.Workers != 4 {
+		t.Errorf("defaults not applied: %+v", selector.config)
+	}
+
+	data := mat.NewDense(50, 2, nil)
+	for i := 0; i < 50; i++ {
+		data.Set(i, 0, float64(i))
+		data.Set(i, 1, 2*float64(i)+float64(i%3))
+	}
+	result, err := selector.Fit(data)
+	if err != nil {
+		t.Fatalf("Fit error: %v", err)
+	}
+	if len(result.Order) != 2 {
+		t.Errorf("expected order of length 2, got %v", result.Order)
+	}
+}
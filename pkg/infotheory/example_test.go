@@ -0,0 +1,41 @@
+package infotheory_test
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/pkg/infotheory"
+)
+
+// ExampleMutualInformation computes I(X;Y) for two partially dependent binary variables.
+func ExampleMutualInformation() {
+	// P(X, Y): X and Y agree with probability 0.8
+	arr := &infotheory.NDArray{
+		Data:  []float64{0.4, 0.1, 0.1, 0.4},
+		Shape: []int{2, 2},
+	}
+
+	fmt.Printf("H(X)   = %.4f bits\n", infotheory.JointEntropy(arr, []int{0}))
+	fmt.Printf("H(X|Y) = %.4f bits\n", infotheory.ConditionalEntropy(arr, []int{0}, []int{1}))
+	fmt.Printf("I(X;Y) = %.4f bits\n", infotheory.MutualInformation(arr, []int{0}, []int{1}))
+
+	// Output:
+	// H(X)   = 1.0000 bits
+	// H(X|Y) = 0.7219 bits
+	// I(X;Y) = 0.2781 bits
+}
+
+// ExampleConditionalMutualInformation shows that conditioning on a common copy removes MI.
+func ExampleConditionalMutualInformation() {
+	// P(X, Y, Z) with X = Y = Z uniform binary
+	arr := &infotheory.NDArray{
+		Data:  []float64{0.5, 0, 0, 0, 0, 0, 0, 0.5},
+		Shape: []int{2, 2, 2},
+	}
+
+	fmt.Printf("I(X;Y)   = %.4f bits\n", infotheory.MutualInformation(arr, []int{0}, []int{1}))
+	fmt.Printf("I(X;Y|Z) = %.4f bits\n", infotheory.ConditionalMutualInformation(arr, []int{0}, []int{1}, []int{2}))
+
+	// Output:
+	// I(X;Y)   = 1.0000 bits
+	// I(X;Y|Z) = 0.0000 bits
+}
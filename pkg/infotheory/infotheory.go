@@ -0,0 +1,59 @@
+// Package infotheory provides Shannon entropy and mutual information measures
+// for discrete probability distributions.
+//
+// It re-exports the information-theory primitives used internally by SURD
+// (package internal/entropy) with identical signatures, so external users can
+// compute entropies, MI and CMI on joint distributions without reimplementing them.
+//
+// All measures are in bits. Joint distributions are N-dimensional arrays in
+// row-major order; axes are selected by index.
+package infotheory
+
+import (
+	"github.com/causalgo/causalgo/internal/entropy"
+)
+
+// NDArray represents an N-dimensional array for joint probability distributions.
+// Data is stored in row-major (C-contiguous) order.
+//
+// Example:
+//
+//	// P(X, Y) as a 2x2 joint distribution
+//	arr := &infotheory.NDArray{
+//	    Data:  []float64{0.4, 0.1, 0.1, 0.4},
+//	    Shape: []int{2, 2},
+//	}
+type NDArray = entropy.NDArray
+
+// Log2Safe computes the base-2 logarithm of x, returning 0 for x <= 0.
+func Log2Safe(x float64) float64 {
+	return entropy.Log2Safe(x)
+}
+
+// Entropy computes the Shannon entropy H(p) = -Σ p_i log2(p_i) of a distribution.
+func Entropy(p []float64) float64 {
+	return entropy.Entropy(p)
+}
+
+// JointEntropy computes H(X_i, X_j, ...) over the given axes of a joint distribution,
+// marginalizing over all other axes.
+func JointEntropy(arr *NDArray, indices []int) float64 {
+	return entropy.JointEntropy(arr, indices)
+}
+
+// ConditionalEntropy computes H(X|Y) where X are the target axes and Y the
+// conditioning axes.
+func ConditionalEntropy(arr *NDArray, target, conditioning []int) float64 {
+	return entropy.ConditionalEntropy(arr, target, conditioning)
+}
+
+// MutualInformation computes I(X;Y) between two sets of axes.
+func MutualInformation(arr *NDArray, set1, set2 []int) float64 {
+	return entropy.MutualInformation(arr, set1, set2)
+}
+
+// ConditionalMutualInformation computes I(X;Y|Z) between two sets of axes
+// given a third set.
+func ConditionalMutualInformation(arr *NDArray, set1, set2, conditioning []int) float64 {
+	return entropy.ConditionalMutualInformation(arr, set1, set2, conditioning)
+}
@@ -0,0 +1,141 @@
+package matdata
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions configures LoadCSVWithOptions.
+type CSVOptions struct {
+	// Delimiter separates fields. 0 means ','.
+	Delimiter rune
+
+	// HasHeader treats the first row as column names.
+	HasHeader bool
+
+	// Comment, if not 0, starts a comment line that is ignored (e.g. '#').
+	Comment rune
+}
+
+// CSVData is the result of LoadCSVWithOptions.
+type CSVData struct {
+	// Data holds the parsed rows in [samples x variables] layout.
+	Data [][]float64
+
+	// Columns holds the header names, or nil without a header.
+	Columns []string
+
+	// SkippedLines lists the 1-based line numbers of rows that were skipped
+	// because a field did not parse as a number.
+	SkippedLines []int
+}
+
+// LoadCSV loads a numeric CSV file as [samples x variables], the layout
+// SURD and SCIC expect, with column names if hasHeader is set.
+//
+// Rows with a field that does not parse as a number are skipped; use
+// LoadCSVWithOptions to find out which. Rows with a different number of
+// columns than the first row are an error.
+//
+// Example:
+//
+//	data, names, err := matdata.LoadCSV("signals.csv", true)
+//	result, err := surd.DecomposeFromData(data, []int{8, 8, 8})
+func LoadCSV(path string, hasHeader bool) ([][]float64, []string, error) {
+	res, err := LoadCSVWithOptions(path, CSVOptions{HasHeader: hasHeader})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Data, res.Columns, nil
+}
+
+// LoadCSVWithOptions loads a numeric CSV file like LoadCSV, with a
+// configurable delimiter and comment character, and reports skipped rows.
+//
+// Fields are trimmed of surrounding spaces; "NaN" and "Inf" parse as such
+// (histograms skip them). Empty or non-numeric fields make the row
+// malformed: it is skipped and its line number recorded in SkippedLines.
+//
+// Example:
+//
+//	res, err := matdata.LoadCSVWithOptions("signals.tsv", matdata.CSVOptions{Delimiter: '\t', HasHeader: true})
+//	if len(res.SkippedLines) > 0 {
+//	    log.Printf("skipped malformed lines %v", res.SkippedLines)
+//	}
+func LoadCSVWithOptions(path string, opts CSVOptions) (*CSVData, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is user-provided intentionally
+	if err != nil {
+		return nil, fmt.Errorf("matdata: failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return readCSV(f, opts)
+}
+
+// readCSV parses numeric CSV rows from r.
+func readCSV(r io.Reader, opts CSVOptions) (*CSVData, error) {
+	cr := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		cr.Comma = opts.Delimiter
+	}
+	cr.Comment = opts.Comment
+	cr.FieldsPerRecord = -1 // checked below with a clearer message
+	cr.TrimLeadingSpace = true
+
+	res := &CSVData{}
+	ncols := -1
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("matdata: failed to read CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		if ncols < 0 {
+			ncols = len(record)
+			if opts.HasHeader {
+				res.Columns = make([]string, ncols)
+				for j, name := range record {
+					res.Columns[j] = strings.TrimSpace(name)
+				}
+				continue
+			}
+		} else if len(record) != ncols {
+			return nil, fmt.Errorf("matdata: line %d has %d columns, expected %d", line, len(record), ncols)
+		}
+
+		row, ok := parseCSVRow(record)
+		if !ok {
+			res.SkippedLines = append(res.SkippedLines, line)
+			continue
+		}
+		res.Data = append(res.Data, row)
+	}
+
+	if len(res.Data) == 0 {
+		return nil, fmt.Errorf("matdata: no numeric rows in CSV (%d skipped)", len(res.SkippedLines))
+	}
+	return res, nil
+}
+
+// parseCSVRow parses every field of record as float64. ok is false if any
+// field is empty or not a number.
+func parseCSVRow(record []string) (row []float64, ok bool) {
+	row = make([]float64, len(record))
+	for j, field := range record {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, false
+		}
+		row[j] = v
+	}
+	return row, true
+}
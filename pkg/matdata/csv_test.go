@@ -0,0 +1,87 @@
+package matdata
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeCSV writes content to a temporary file and returns its path.
+func writeCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCSV(t *testing.T) {
+	path := writeCSV(t, "target, x1, x2\n1.5, 2, 3\n4,5e-1,-6\n")
+
+	data, names, err := LoadCSV(path, true)
+	if err != nil {
+		t.Fatalf("LoadCSV failed: %v", err)
+	}
+	if want := []string{"target", "x1", "x2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	if want := [][]float64{{1.5, 2, 3}, {4, 0.5, -6}}; !reflect.DeepEqual(data, want) {
+		t.Errorf("data = %v, want %v", data, want)
+	}
+
+	// Without a header every row is data
+	path = writeCSV(t, "1,2\n3,4\n")
+	data, names, err = LoadCSV(path, false)
+	if err != nil {
+		t.Fatalf("LoadCSV failed: %v", err)
+	}
+	if names != nil || len(data) != 2 {
+		t.Errorf("names = %q, rows = %d; want nil names and 2 rows", names, len(data))
+	}
+}
+
+func TestLoadCSVWithOptions(t *testing.T) {
+	content := strings.Join([]string{
+		"# exported by logger",
+		"a;b",
+		"1;2",
+		"x;3", // malformed
+		"4;",  // empty field
+		"NaN;5",
+		"6;7",
+	}, "\n")
+	path := writeCSV(t, content)
+
+	res, err := LoadCSVWithOptions(path, CSVOptions{Delimiter: ';', HasHeader: true, Comment: '#'})
+	if err != nil {
+		t.Fatalf("LoadCSVWithOptions failed: %v", err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(res.Columns, want) {
+		t.Errorf("Columns = %q, want %q", res.Columns, want)
+	}
+	if want := []int{4, 5}; !reflect.DeepEqual(res.SkippedLines, want) {
+		t.Errorf("SkippedLines = %v, want %v", res.SkippedLines, want)
+	}
+	if len(res.Data) != 3 || !math.IsNaN(res.Data[1][0]) || res.Data[2][1] != 7 {
+		t.Errorf("Data = %v, want [[1 2] [NaN 5] [6 7]]", res.Data)
+	}
+}
+
+func TestLoadCSV_Errors(t *testing.T) {
+	if _, _, err := LoadCSV("nonexistent.csv", false); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	_, _, err := LoadCSV(writeCSV(t, "1,2,3\n4,5\n"), false)
+	if err == nil || !strings.Contains(err.Error(), "line 2 has 2 columns, expected 3") {
+		t.Errorf("expected column count error naming line 2, got %v", err)
+	}
+
+	if _, _, err := LoadCSV(writeCSV(t, "a,b\nx,y\n"), true); err == nil {
+		t.Error("expected error when no row is numeric")
+	}
+}
@@ -5,13 +5,20 @@
 // Supports:
 //   - MATLAB v5 MAT-files (including compressed data elements)
 //   - MATLAB v7.3 HDF5-based MAT-files
+//   - numeric CSV files (LoadCSV), in the same [samples x variables] layout
 package matdata
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"strings"
 
 	"github.com/scigolib/matlab"
+	"github.com/scigolib/matlab/types"
 )
 
 // MatFile wraps a MATLAB file for convenient data extraction.
@@ -21,14 +28,27 @@ type MatFile struct {
 }
 
 // Open opens a MATLAB .mat file for reading.
-// Supports both v5 (MATLAB 5-7.2) and v7.3 (HDF5) formats.
+// Supports both v5 (MATLAB 5-7.2) and v7.3 (HDF5) formats, including v7.3
+// files with a userblock before the HDF5 data, as MATLAB saves them.
 func Open(path string) (*MatFile, error) {
 	f, err := os.Open(path) //nolint:gosec // G304: path is user-provided intentionally
 	if err != nil {
 		return nil, fmt.Errorf("matdata: failed to open file: %w", err)
 	}
 
-	matFile, err := matlab.Open(f)
+	// MATLAB-saved v7.3 files start with a userblock before the HDF5
+	// superblock; parse from the superblock, to which HDF5 addresses are relative
+	var r io.Reader = f
+	if offset := signatureOffset(f); offset > 0 {
+		info, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("matdata: failed to stat file: %w", err)
+		}
+		r = io.NewSectionReader(f, offset, info.Size()-offset)
+	}
+
+	matFile, err := matlab.Open(r)
 	if err != nil {
 		_ = f.Close()
 		return nil, fmt.Errorf("matdata: failed to parse MAT file: %w", err)
@@ -58,6 +78,85 @@ func (m *MatFile) HasVariable(name string) bool {
 	return m.file.HasVariable(name)
 }
 
+// FileHeader describes the format of a MAT file, as read by FileInfo.
+type FileHeader struct {
+	// Version is the format version from the header text, e.g. "5.0" for
+	// v5 files (MATLAB 5 to 7.2) or "7.3" for HDF5-based files.
+	Version string
+
+	// Compressed reports whether the first data element is zlib-compressed
+	// (miCOMPRESSED), as written by MATLAB's default save since v7.
+	// Always false for v7.3 files, whose compression is per HDF5 dataset.
+	Compressed bool
+
+	// ByteOrder is the byte order of the file's numeric data.
+	ByteOrder binary.ByteOrder
+
+	// Description is the descriptive header text (platform, creation date).
+	Description string
+}
+
+const (
+	matHeaderSize = 128 // v5 header: 116 text + 8 subsystem offset + 2 version + 2 endian
+	miCompressed  = 15  // data type of a zlib-compressed element
+)
+
+// FileInfo reads the version, compression and byte order of a MAT file
+// from its header, without parsing any variables. Useful to triage files
+// that Open or GetMatrix fail on.
+//
+// Example:
+//
+//	info, err := matdata.FileInfo("data.mat")
+//	fmt.Println(info.Version, info.Compressed, info.ByteOrder) // 5.0 true LittleEndian
+func FileInfo(path string) (FileHeader, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is user-provided intentionally
+	if err != nil {
+		return FileHeader{}, fmt.Errorf("matdata: failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Header plus the tag of the first data element
+	buf := make([]byte, matHeaderSize+8)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return FileHeader{}, fmt.Errorf("matdata: failed to read header: %w", err)
+	}
+	if n < matHeaderSize {
+		return FileHeader{}, fmt.Errorf("matdata: file too short for a MAT header (%d bytes)", n)
+	}
+
+	text := strings.TrimRight(string(buf[:116]), " \x00")
+	const prefix = "MATLAB "
+	if !strings.HasPrefix(text, prefix) {
+		return FileHeader{}, fmt.Errorf("matdata: not a MAT file (header starts with %q)", buf[:min(len(text), 16)])
+	}
+	version, description, _ := strings.Cut(strings.TrimPrefix(text, prefix), " MAT-file")
+	info := FileHeader{
+		Version:     version,
+		Description: strings.TrimLeft(description, ", "),
+	}
+
+	switch string(buf[126:128]) {
+	case "IM":
+		info.ByteOrder = binary.LittleEndian
+	case "MI":
+		info.ByteOrder = binary.BigEndian
+	default:
+		return FileHeader{}, fmt.Errorf("matdata: invalid endian indicator %q", buf[126:128])
+	}
+
+	if n >= matHeaderSize+4 && version != "7.3" {
+		dataType := info.ByteOrder.Uint32(buf[matHeaderSize:])
+		if dataType>>16 != 0 {
+			dataType &= 0xFFFF // small data element: type in the low 16 bits
+		}
+		info.Compressed = dataType == miCompressed
+	}
+
+	return info, nil
+}
+
 // GetFloat64(name) returns a variable as a []float64 slice.
 // Returns an error if the variable doesn't exist or cannot be converted.
 func (m *MatFile) GetFloat64(name string) ([]float64, error) {
@@ -74,6 +173,76 @@ func (m *MatFile) GetFloat64(name string) ([]float64, error) {
 	return data, nil
 }
 
+// GetInt64 returns a variable as a []int64 slice.
+// Useful for categorical label columns, which are often stored as integer
+// classes (int8..int64, uint8..uint64).
+//
+// Floating-point variables are accepted only if every value is integral;
+// otherwise an error is returned. uint64 values above math.MaxInt64 are
+// rejected rather than silently wrapped.
+func (m *MatFile) GetInt64(name string) ([]int64, error) {
+	v := m.file.GetVariable(name)
+	if v == nil {
+		return nil, fmt.Errorf("matdata: variable %q not found", name)
+	}
+	if v.IsComplex {
+		return nil, fmt.Errorf("matdata: cannot convert complex %q to int64", name)
+	}
+
+	switch data := v.Data.(type) {
+	case []int64:
+		result := make([]int64, len(data))
+		copy(result, data)
+		return result, nil
+	case []int32:
+		return convertInts(data), nil
+	case []int16:
+		return convertInts(data), nil
+	case []int8:
+		return convertInts(data), nil
+	case []uint32:
+		return convertInts(data), nil
+	case []uint16:
+		return convertInts(data), nil
+	case []uint8:
+		return convertInts(data), nil
+	case []uint64:
+		result := make([]int64, len(data))
+		for i, val := range data {
+			if val > math.MaxInt64 {
+				return nil, fmt.Errorf("matdata: %q element %d (%d) overflows int64", name, i, val)
+			}
+			result[i] = int64(val)
+		}
+		return result, nil
+	}
+
+	// Fall back to floating-point classes holding integral values
+	floats, err := v.GetFloat64Array()
+	if err != nil {
+		return nil, fmt.Errorf("matdata: cannot convert %q to int64: %w", name, err)
+	}
+
+	result := make([]int64, len(floats))
+	for i, val := range floats {
+		if val != math.Trunc(val) || val < math.MinInt64 || val >= math.MaxInt64 {
+			return nil, fmt.Errorf("matdata: %q element %d (%v) is not an int64 value", name, i, val)
+		}
+		result[i] = int64(val)
+	}
+
+	return result, nil
+}
+
+// convertInts widens an integer slice to []int64.
+func convertInts[T int8 | int16 | int32 | uint8 | uint16 | uint32](data []T) []int64 {
+	result := make([]int64, len(data))
+	for i, val := range data {
+		result[i] = int64(val)
+	}
+	return result
+}
+
 // GetFloat64WithDims returns a variable as []float64 along with its dimensions.
 func (m *MatFile) GetFloat64WithDims(name string) ([]float64, []int, error) {
 	v := m.file.GetVariable(name)
@@ -91,6 +260,10 @@ func (m *MatFile) GetFloat64WithDims(name string) ([]float64, []int, error) {
 
 // GetMatrix returns a 2D matrix as row-major [][]float64.
 // Assumes the MATLAB variable is a 2D array stored in column-major order.
+//
+// Integer classes (int8..int64, uint8..uint64) are converted to float64.
+// Values with magnitude above 2^53 (int64/uint64 only) lose precision;
+// use GetInt64 when exact integer values are required.
 func (m *MatFile) GetMatrix(name string) ([][]float64, error) {
 	data, dims, err := m.GetFloat64WithDims(name)
 	if err != nil {
@@ -141,6 +314,129 @@ func (m *MatFile) GetColumn(name string, col int) ([]float64, error) {
 	return column, nil
 }
 
+// GetColumnRange returns rows [startRow, endRow) of one column of a 2D matrix.
+// Column and row indices are 0-based.
+//
+// Only the requested elements are copied or converted: a column is contiguous
+// in MATLAB's column-major layout, so the slice is read directly from the
+// variable's data. The underlying library decodes each variable in full when
+// the file is opened, so this saves the per-call copy and conversion of the
+// whole matrix, not the decoding itself.
+//
+// Example:
+//
+//	// Samples 100000..199999 of column 1
+//	segment, err := mf.GetColumnRange("X", 1, 100000, 200000)
+func (m *MatFile) GetColumnRange(name string, col, startRow, endRow int) ([]float64, error) {
+	v, rows, cols, err := m.matrixVariable(name)
+	if err != nil {
+		return nil, err
+	}
+	if col < 0 || col >= cols {
+		return nil, fmt.Errorf("matdata: column %d out of range [0, %d)", col, cols)
+	}
+	if err := checkRowRange(startRow, endRow, rows); err != nil {
+		return nil, err
+	}
+
+	return elementRange(v, col*rows+startRow, endRow-startRow)
+}
+
+// GetMatrixRows returns rows [startRow, endRow) of a 2D matrix as row-major
+// [][]float64, converting only the requested elements (see GetColumnRange).
+//
+// Example:
+//
+//	// First 1000 rows of a [samples x variables] matrix
+//	window, err := mf.GetMatrixRows("data", 0, 1000)
+func (m *MatFile) GetMatrixRows(name string, startRow, endRow int) ([][]float64, error) {
+	v, rows, cols, err := m.matrixVariable(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRowRange(startRow, endRow, rows); err != nil {
+		return nil, err
+	}
+
+	n := endRow - startRow
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, cols)
+	}
+	for j := 0; j < cols; j++ {
+		column, err := elementRange(v, j*rows+startRow, n)
+		if err != nil {
+			return nil, err
+		}
+		for i, val := range column {
+			matrix[i][j] = val
+		}
+	}
+
+	return matrix, nil
+}
+
+// matrixVariable looks up a real 2D variable and returns its dimensions.
+func (m *MatFile) matrixVariable(name string) (*types.Variable, int, int, error) {
+	v := m.file.GetVariable(name)
+	if v == nil {
+		return nil, 0, 0, fmt.Errorf("matdata: variable %q not found", name)
+	}
+	if v.IsComplex {
+		return nil, 0, 0, fmt.Errorf("matdata: cannot convert complex %q to float64", name)
+	}
+	if len(v.Dimensions) != 2 {
+		return nil, 0, 0, fmt.Errorf("matdata: %q is not a 2D matrix (dims=%v)", name, v.Dimensions)
+	}
+	return v, v.Dimensions[0], v.Dimensions[1], nil
+}
+
+// checkRowRange validates the half-open row range [start, end) for a matrix
+// with the given number of rows.
+func checkRowRange(start, end, rows int) error {
+	if start < 0 || end > rows || start >= end {
+		return fmt.Errorf("matdata: row range [%d, %d) invalid for %d rows", start, end, rows)
+	}
+	return nil
+}
+
+// elementRange converts n elements of the variable's flat data starting at offset.
+func elementRange(v *types.Variable, offset, n int) ([]float64, error) {
+	switch data := v.Data.(type) {
+	case []float64:
+		return convertRange(data, offset, n), nil
+	case []float32:
+		return convertRange(data, offset, n), nil
+	case []int64:
+		return convertRange(data, offset, n), nil
+	case []int32:
+		return convertRange(data, offset, n), nil
+	case []int16:
+		return convertRange(data, offset, n), nil
+	case []int8:
+		return convertRange(data, offset, n), nil
+	case []uint64:
+		return convertRange(data, offset, n), nil
+	case []uint32:
+		return convertRange(data, offset, n), nil
+	case []uint16:
+		return convertRange(data, offset, n), nil
+	case []uint8:
+		return convertRange(data, offset, n), nil
+	default:
+		return nil, fmt.Errorf("matdata: cannot convert %q (%T) to float64", v.Name, v.Data)
+	}
+}
+
+// convertRange copies data[offset:offset+n] into a new []float64.
+func convertRange[T float32 | float64 | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64](data []T, offset, n int) []float64 {
+	result := make([]float64, n)
+	for i, val := range data[offset : offset+n] {
+		result[i] = float64(val)
+	}
+	return result
+}
+
 // LoadSignals loads multiple named variables as columns for SURD analysis.
 // Returns data in the format [][]float64 where each row is a sample
 // and each column corresponds to a variable in the order specified.
@@ -264,3 +560,99 @@ func PrepareWithLag(data [][]float64, targetIdx int, lag int) ([][]float64, erro
 
 	return result, nil
 }
+
+// DefaultMinSamplesPerBin is the average number of samples per histogram cell
+// below which entropy estimates are considered unreliable.
+const DefaultMinSamplesPerBin = 5
+
+// MinSamplesForBins returns the sample count needed for an average of perBin
+// samples per cell of a joint histogram with the given bins per column.
+//
+// Example:
+//
+//	// target + 3 agents, 8 bins each: 5 * 8^4 = 20480 samples
+//	minSamples := MinSamplesForBins([]int{8, 8, 8, 8}, DefaultMinSamplesPerBin)
+func MinSamplesForBins(bins []int, perBin float64) int {
+	cells := 1.0
+	for _, b := range bins {
+		cells *= float64(b)
+	}
+	return int(math.Ceil(perBin * cells))
+}
+
+// LagSweep holds the usable lags of a lag sweep. The lagged datasets are
+// built on demand by Data, one lag at a time, so a sweep over a long
+// recording does not hold maxLag copies of it.
+type LagSweep struct {
+	// Lags are the usable lags, in increasing order.
+	Lags []int
+
+	// Dropped are the lags that leave fewer than the minimum number of samples.
+	Dropped []int
+
+	data      [][]float64
+	targetIdx int
+}
+
+// Data returns the PrepareWithLag result for Lags[i]. Each call builds a new
+// lagged copy; drop it before moving to the next lag to keep memory at O(N).
+func (s *LagSweep) Data(i int) ([][]float64, error) {
+	if i < 0 || i >= len(s.Lags) {
+		return nil, fmt.Errorf("matdata: lag index %d out of range [0, %d)", i, len(s.Lags))
+	}
+	return PrepareWithLag(s.data, s.targetIdx, s.Lags[i])
+}
+
+// PrepareLagSweep selects the lags in 1..maxLag that leave enough samples for
+// reliable histograms. The lagged data of each usable lag is built by
+// LagSweep.Data; surd.DecomposeMultiLag decomposes all of them directly.
+//
+// data: [samples x variables] matrix
+// targetIdx: index of target variable (0-based)
+// maxLag: largest lag to consider (> 0)
+// minSamples: minimum number of samples each lag must leave (see MinSamplesForBins)
+//
+// A lag is usable if len(data) - lag >= minSamples. Unusable lags are listed
+// in Dropped. Returns an error if no lag is usable.
+//
+// Example:
+//
+//	minSamples := MinSamplesForBins([]int{8, 8, 8, 8}, DefaultMinSamplesPerBin)
+//	sweep, err := PrepareLagSweep(data, 0, 50, minSamples)
+//	if len(sweep.Dropped) > 0 {
+//	    log.Printf("lags %v leave fewer than %d samples", sweep.Dropped, minSamples)
+//	}
+//	for i, lag := range sweep.Lags {
+//	    lagged, err := sweep.Data(i)
+//	    ...
+//	}
+func PrepareLagSweep(data [][]float64, targetIdx int, maxLag int, minSamples int) (*LagSweep, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("matdata: data is empty")
+	}
+	if targetIdx < 0 || targetIdx >= len(data[0]) {
+		return nil, fmt.Errorf("matdata: targetIdx (%d) out of range [0, %d)", targetIdx, len(data[0]))
+	}
+	if maxLag <= 0 {
+		return nil, fmt.Errorf("matdata: maxLag must be positive, got %d", maxLag)
+	}
+	if minSamples < 1 {
+		return nil, fmt.Errorf("matdata: minSamples must be positive, got %d", minSamples)
+	}
+
+	sweep := &LagSweep{data: data, targetIdx: targetIdx}
+	for lag := 1; lag <= maxLag; lag++ {
+		if len(data)-lag < minSamples {
+			sweep.Dropped = append(sweep.Dropped, lag)
+			continue
+		}
+		sweep.Lags = append(sweep.Lags, lag)
+	}
+
+	if len(sweep.Lags) == 0 {
+		return nil, fmt.Errorf("matdata: no usable lag in [1, %d]: %d samples, need at least %d after lagging",
+			maxLag, len(data), minSamples)
+	}
+
+	return sweep, nil
+}
@@ -1,8 +1,14 @@
 package matdata
 
 import (
+	"encoding/binary"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/scigolib/matlab"
+	"github.com/scigolib/matlab/types"
 )
 
 const testMATFile = "../../testdata/matlab/energy_cascade_signals.mat"
@@ -258,3 +264,387 @@ func TestMultipleSignals(t *testing.T) {
 		}
 	}
 }
+
+// writeIntegerFixture writes a v5 MAT-file containing integer-class variables.
+// Skips the test if the writer does not support integer classes.
+func writeIntegerFixture(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "integers.mat")
+	w, err := matlab.Create(path, matlab.Version5)
+	if err != nil {
+		t.Skipf("MAT writer unavailable: %v", err)
+	}
+
+	vars := []*types.Variable{
+		{Name: "labels", Dimensions: []int{4, 1}, DataType: types.Int32, Data: []int32{-1, 0, 2, 7}},
+		{Name: "classes", Dimensions: []int{2, 2}, DataType: types.Uint8, Data: []uint8{1, 2, 3, 4}},
+		{Name: "real", Dimensions: []int{2, 1}, DataType: types.Double, Data: []float64{1.5, 2}},
+	}
+	for _, v := range vars {
+		if err := w.WriteVariable(v); err != nil {
+			_ = w.Close()
+			t.Skipf("integer class %s unsupported by writer: %v", v.DataType, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close fixture: %v", err)
+	}
+
+	return path
+}
+
+func TestGetInt64(t *testing.T) {
+	path := writeIntegerFixture(t)
+
+	mf, err := Open(path)
+	if err != nil {
+		t.Skipf("integer-class fixture unreadable: %v", err)
+	}
+	defer func() { _ = mf.Close() }()
+
+	labels, err := mf.GetInt64("labels")
+	if err != nil {
+		t.Fatalf("GetInt64(labels) failed: %v", err)
+	}
+	want := []int64{-1, 0, 2, 7}
+	if len(labels) != len(want) {
+		t.Fatalf("GetInt64(labels) len = %d, want %d", len(labels), len(want))
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Errorf("labels[%d] = %d, want %d", i, labels[i], want[i])
+		}
+	}
+
+	// Non-integral doubles must be rejected
+	if _, err := mf.GetInt64("real"); err == nil {
+		t.Error("GetInt64(real) expected error for non-integral values")
+	}
+
+	if _, err := mf.GetInt64("missing"); err == nil {
+		t.Error("GetInt64(missing) expected error")
+	}
+}
+
+func TestGetMatrix_IntegerClass(t *testing.T) {
+	path := writeIntegerFixture(t)
+
+	mf, err := Open(path)
+	if err != nil {
+		t.Skipf("integer-class fixture unreadable: %v", err)
+	}
+	defer func() { _ = mf.Close() }()
+
+	// uint8 [2x2] stored column-major: [1 3; 2 4]
+	matrix, err := mf.GetMatrix("classes")
+	if err != nil {
+		t.Fatalf("GetMatrix(classes) failed: %v", err)
+	}
+	want := [][]float64{{1, 3}, {2, 4}}
+	for i := range want {
+		for j := range want[i] {
+			if matrix[i][j] != want[i][j] {
+				t.Errorf("matrix[%d][%d] = %v, want %v", i, j, matrix[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestMinSamplesForBins(t *testing.T) {
+	if got := MinSamplesForBins([]int{8, 8, 8, 8}, DefaultMinSamplesPerBin); got != 20480 {
+		t.Errorf("MinSamplesForBins = %d, want 20480", got)
+	}
+	if got := MinSamplesForBins([]int{3, 3}, 2.5); got != 23 {
+		t.Errorf("MinSamplesForBins = %d, want 23 (rounded up)", got)
+	}
+}
+
+func TestPrepareLagSweep_DropsShortLags(t *testing.T) {
+	// Short series: 60 samples, target + 1 agent with 3 bins each needs 45 samples
+	data := make([][]float64, 60)
+	for i := range data {
+		data[i] = []float64{float64(i), float64(i % 7)}
+	}
+	minSamples := MinSamplesForBins([]int{3, 3}, DefaultMinSamplesPerBin)
+
+	sweep, err := PrepareLagSweep(data, 0, 30, minSamples)
+	if err != nil {
+		t.Fatalf("PrepareLagSweep failed: %v", err)
+	}
+
+	// Lags 1..15 leave >= 45 samples; 16..30 do not
+	if len(sweep.Lags) != 15 || sweep.Lags[0] != 1 || sweep.Lags[14] != 15 {
+		t.Errorf("usable lags = %v, want 1..15", sweep.Lags)
+	}
+	if len(sweep.Dropped) != 15 || sweep.Dropped[0] != 16 || sweep.Dropped[14] != 30 {
+		t.Errorf("dropped lags = %v, want 16..30", sweep.Dropped)
+	}
+	for i, lag := range sweep.Lags {
+		lagged, err := sweep.Data(i)
+		if err != nil {
+			t.Fatalf("Data(%d) failed: %v", i, err)
+		}
+		if len(lagged) != len(data)-lag {
+			t.Errorf("lag %d: %d samples, want %d", lag, len(lagged), len(data)-lag)
+		}
+		if lagged[0][0] != data[lag][0] {
+			t.Errorf("lag %d: target not shifted", lag)
+		}
+	}
+	if _, err := sweep.Data(len(sweep.Lags)); err == nil {
+		t.Error("expected error for lag index out of range")
+	}
+}
+
+func TestPrepareLagSweep_NoUsableLag(t *testing.T) {
+	data := make([][]float64, 20)
+	for i := range data {
+		data[i] = []float64{float64(i), float64(i)}
+	}
+
+	if _, err := PrepareLagSweep(data, 0, 5, 100); err == nil {
+		t.Error("expected error when every lag leaves too few samples")
+	}
+	if _, err := PrepareLagSweep(data, 0, 0, 10); err == nil {
+		t.Error("expected error for maxLag = 0")
+	}
+	if _, err := PrepareLagSweep(data, 0, 5, 0); err == nil {
+		t.Error("expected error for minSamples = 0")
+	}
+	if _, err := PrepareLagSweep(data, 2, 5, 10); err == nil {
+		t.Error("expected error for targetIdx out of range")
+	}
+}
+
+func TestGetColumnRange_MatchesFullLoad(t *testing.T) {
+	if _, err := os.Stat(testMATFile); os.IsNotExist(err) {
+		t.Skip("MATLAB test file not available")
+	}
+
+	mf, err := Open(testMATFile)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = mf.Close() }()
+
+	// X is [4 x 21760]
+	full, err := mf.GetMatrix("X")
+	if err != nil {
+		t.Fatalf("GetMatrix failed: %v", err)
+	}
+
+	// Middle slice of one column: rows 1..2 of column 10000
+	segment, err := mf.GetColumnRange("X", 10000, 1, 3)
+	if err != nil {
+		t.Fatalf("GetColumnRange failed: %v", err)
+	}
+	if len(segment) != 2 {
+		t.Fatalf("GetColumnRange len = %d, want 2", len(segment))
+	}
+	for i, val := range segment {
+		if val != full[1+i][10000] {
+			t.Errorf("segment[%d] = %v, want %v", i, val, full[1+i][10000])
+		}
+	}
+
+	// Middle rows of the matrix
+	rows, err := mf.GetMatrixRows("X", 1, 3)
+	if err != nil {
+		t.Fatalf("GetMatrixRows failed: %v", err)
+	}
+	if len(rows) != 2 || len(rows[0]) != len(full[0]) {
+		t.Fatalf("GetMatrixRows shape = %dx%d, want 2x%d", len(rows), len(rows[0]), len(full[0]))
+	}
+	for i := range rows {
+		for j := range rows[i] {
+			if rows[i][j] != full[1+i][j] {
+				t.Fatalf("rows[%d][%d] = %v, want %v", i, j, rows[i][j], full[1+i][j])
+			}
+		}
+	}
+}
+
+func TestGetColumnRange_InvalidRange(t *testing.T) {
+	path := writeIntegerFixture(t)
+
+	mf, err := Open(path)
+	if err != nil {
+		t.Skipf("integer-class fixture unreadable: %v", err)
+	}
+	defer func() { _ = mf.Close() }()
+
+	// uint8 [2x2] stored column-major: [1 3; 2 4]
+	column, err := mf.GetColumnRange("classes", 1, 1, 2)
+	if err != nil {
+		t.Fatalf("GetColumnRange(classes) failed: %v", err)
+	}
+	if len(column) != 1 || column[0] != 4 {
+		t.Errorf("GetColumnRange(classes, 1, 1, 2) = %v, want [4]", column)
+	}
+
+	for _, r := range [][2]int{{-1, 1}, {0, 3}, {1, 1}} {
+		if _, err := mf.GetColumnRange("classes", 0, r[0], r[1]); err == nil {
+			t.Errorf("GetColumnRange rows [%d, %d) expected error", r[0], r[1])
+		}
+		if _, err := mf.GetMatrixRows("classes", r[0], r[1]); err == nil {
+			t.Errorf("GetMatrixRows rows [%d, %d) expected error", r[0], r[1])
+		}
+	}
+	if _, err := mf.GetColumnRange("classes", 2, 0, 1); err == nil {
+		t.Error("GetColumnRange column 2 expected error")
+	}
+	if _, err := mf.GetColumnRange("missing", 0, 0, 1); err == nil {
+		t.Error("GetColumnRange(missing) expected error")
+	}
+}
+
+func TestFileInfo(t *testing.T) {
+	if _, err := os.Stat(testMATFile); os.IsNotExist(err) {
+		t.Skipf("Test file not available: %s", testMATFile)
+	}
+
+	info, err := FileInfo(testMATFile)
+	if err != nil {
+		t.Fatalf("FileInfo failed: %v", err)
+	}
+	t.Logf("version %q, compressed %v, byte order %v, description %q",
+		info.Version, info.Compressed, info.ByteOrder, info.Description)
+
+	if info.Version != "5.0" {
+		t.Errorf("Version = %q, want \"5.0\"", info.Version)
+	}
+	if !info.Compressed {
+		t.Error("expected compressed data elements")
+	}
+	if info.ByteOrder != binary.LittleEndian {
+		t.Errorf("ByteOrder = %v, want LittleEndian", info.ByteOrder)
+	}
+	if !strings.Contains(info.Description, "Platform") {
+		t.Errorf("Description = %q, expected platform information", info.Description)
+	}
+}
+
+func TestFileInfo_Errors(t *testing.T) {
+	if _, err := FileInfo("nonexistent.mat"); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	dir := t.TempDir()
+	short := filepath.Join(dir, "short.mat")
+	if err := os.WriteFile(short, []byte("MATLAB 5.0 MAT-file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FileInfo(short); err == nil {
+		t.Error("expected error for truncated header")
+	}
+
+	notMAT := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(notMAT, []byte(strings.Repeat("1,2,3\n", 50)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FileInfo(notMAT); err == nil {
+		t.Error("expected error for non-MAT file")
+	}
+}
+
+// writeRangeFixture writes a v5 file with a long [50x2] matrix of distinct
+// values and a 1x1 matrix.
+func writeRangeFixture(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ranges.mat")
+	w, err := matlab.Create(path, matlab.Version5)
+	if err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+
+	// Column-major: long(i, 0) = i, long(i, 1) = 100 + i, so a wrong row or
+	// column offset returns a different value
+	long := make([]float64, 100)
+	for i := 0; i < 50; i++ {
+		long[i] = float64(i)
+		long[50+i] = float64(100 + i)
+	}
+	vars := []*types.Variable{
+		{Name: "long", Dimensions: []int{50, 2}, DataType: types.Double, Data: long},
+		{Name: "one", Dimensions: []int{1, 1}, DataType: types.Double, Data: []float64{5}},
+	}
+	for _, v := range vars {
+		if err := w.WriteVariable(v); err != nil {
+			_ = w.Close()
+			t.Fatalf("Failed to write %s: %v", v.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close fixture: %v", err)
+	}
+
+	return path
+}
+
+func TestGetColumnRange_Cases(t *testing.T) {
+	mf, err := Open(writeRangeFixture(t))
+	if err != nil {
+		t.Fatalf("Failed to open range fixture: %v", err)
+	}
+	defer func() { _ = mf.Close() }()
+
+	tests := []struct {
+		name       string
+		v          string
+		col        int
+		start, end int
+	}{
+		{"single element", "one", 0, 0, 1},
+		{"single row of long", "long", 1, 49, 50},
+		{"whole column", "long", 0, 0, 50},
+		{"middle slice of second column", "long", 1, 7, 33},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := mf.GetMatrix(tt.v)
+			if err != nil {
+				t.Fatalf("GetMatrix(%s) failed: %v", tt.v, err)
+			}
+
+			got, err := mf.GetColumnRange(tt.v, tt.col, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("GetColumnRange failed: %v", err)
+			}
+			if len(got) != tt.end-tt.start {
+				t.Fatalf("len = %d, want %d", len(got), tt.end-tt.start)
+			}
+			for i, val := range got {
+				if w := want[tt.start+i][tt.col]; val != w {
+					t.Errorf("got[%d] = %v, want %v", i, val, w)
+				}
+			}
+
+			rows, err := mf.GetMatrixRows(tt.v, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("GetMatrixRows failed: %v", err)
+			}
+			for i := range rows {
+				for j, val := range rows[i] {
+					if w := want[tt.start+i][j]; val != w {
+						t.Errorf("rows[%d][%d] = %v, want %v", i, j, val, w)
+					}
+				}
+			}
+		})
+	}
+
+	// Empty ranges are rejected, at either end and for a 1x1 matrix
+	for _, r := range []struct {
+		v          string
+		start, end int
+	}{{"long", 0, 0}, {"long", 50, 50}, {"one", 0, 0}, {"one", 1, 1}} {
+		if _, err := mf.GetColumnRange(r.v, 0, r.start, r.end); err == nil {
+			t.Errorf("GetColumnRange(%s) rows [%d, %d) expected error", r.v, r.start, r.end)
+		}
+		if _, err := mf.GetMatrixRows(r.v, r.start, r.end); err == nil {
+			t.Errorf("GetMatrixRows(%s) rows [%d, %d) expected error", r.v, r.start, r.end)
+		}
+	}
+}
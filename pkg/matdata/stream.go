@@ -0,0 +1,206 @@
+package matdata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scigolib/hdf5"
+)
+
+// StreamChunkRows is the number of rows StreamColumn passes to its callback
+// per call (the last chunk may be shorter).
+const StreamChunkRows = 1 << 16
+
+// hdf5Signature is the superblock signature of an HDF5 file. It is found at
+// offset 0 or, after a userblock, at 512, 1024, 2048, ... bytes.
+var hdf5Signature = []byte("\x89HDF\r\n\x1a\n")
+
+// StreamColumn reads one column of the 2D matrix variable name in chunks of
+// at most StreamChunkRows rows and calls fn with each chunk in order.
+// Column index is 0-based. Each chunk is a fresh slice that fn may retain.
+//
+// For HDF5-based (v7.3) files only the requested rows are read from disk, so
+// a column of a multi-gigabyte variable can be processed in constant memory.
+// MATLAB stores a rows×cols matrix as an HDF5 dataset of shape [cols, rows]
+// (column-major), so a column is one contiguous dataset row. For v5 files,
+// which have no chunked access, the file is decoded in full by Open and the
+// chunks are sliced from memory with GetColumnRange.
+//
+// Files saved by MATLAB itself start with a 512-byte userblock, so their
+// HDF5 superblock is not at offset 0. The HDF5 reader cannot open such files
+// in place, so they take the in-memory path as well: Open skips the
+// userblock and the chunks are sliced with GetColumnRange.
+//
+// Example:
+//
+//	var sum float64
+//	err := matdata.StreamColumn("big.mat", "X", 0, func(chunk []float64) {
+//		for _, v := range chunk {
+//			sum += v
+//		}
+//	})
+func StreamColumn(path, name string, col int, fn func(chunk []float64)) error {
+	offset, err := hdf5SignatureOffset(path)
+	if err != nil {
+		return err
+	}
+	if offset == 0 {
+		return streamHDF5Column(path, name, col, fn)
+	}
+
+	mf, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = mf.Close() }()
+
+	_, rows, _, err := mf.matrixVariable(name)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < rows; start += StreamChunkRows {
+		chunk, err := mf.GetColumnRange(name, col, start, min(start+StreamChunkRows, rows))
+		if err != nil {
+			return err
+		}
+		fn(chunk)
+	}
+	return nil
+}
+
+// hdf5SignatureOffset returns the offset of the HDF5 signature, searched at
+// 0 and at every power of two from 512 within the file, or -1 if the file is
+// not HDF5.
+func hdf5SignatureOffset(path string) (int64, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is user-provided intentionally
+	if err != nil {
+		return -1, fmt.Errorf("matdata: failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return signatureOffset(f), nil
+}
+
+// signatureOffset is hdf5SignatureOffset for an open file.
+func signatureOffset(r io.ReaderAt) int64 {
+	buf := make([]byte, len(hdf5Signature))
+	for offset := int64(0); ; offset = max(512, 2*offset) {
+		if _, err := r.ReadAt(buf, offset); err != nil {
+			return -1 // past the end without a match; let Open report the format error
+		}
+		if bytes.Equal(buf, hdf5Signature) {
+			return offset
+		}
+	}
+}
+
+// streamHDF5Column reads MATLAB column col of dataset /name with ReadSlice,
+// one chunk at a time.
+func streamHDF5Column(path, name string, col int, fn func(chunk []float64)) error {
+	f, err := hdf5.Open(path)
+	if err != nil {
+		return fmt.Errorf("matdata: failed to open HDF5 file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var ds *hdf5.Dataset
+	f.Walk(func(p string, obj hdf5.Object) {
+		if d, ok := obj.(*hdf5.Dataset); ok && p == "/"+name {
+			ds = d
+		}
+	})
+	if ds == nil {
+		return fmt.Errorf("matdata: variable %q not found", name)
+	}
+
+	cols, rows, err := hdf5Dims2D(ds)
+	if err != nil {
+		return fmt.Errorf("matdata: %q: %w", name, err)
+	}
+	if col < 0 || col >= cols {
+		return fmt.Errorf("matdata: column %d out of range [0, %d)", col, cols)
+	}
+
+	for start := 0; start < rows; start += StreamChunkRows {
+		n := min(StreamChunkRows, rows-start)
+		raw, err := ds.ReadSlice([]uint64{uint64(col), uint64(start)}, []uint64{1, uint64(n)})
+		if err != nil {
+			return fmt.Errorf("matdata: failed to read %q rows [%d, %d): %w", name, start, start+n, err)
+		}
+		chunk, err := nativeToFloat64(name, raw)
+		if err != nil {
+			return err
+		}
+		fn(chunk)
+	}
+	return nil
+}
+
+// hdf5Dims2D returns the HDF5 shape [d0, d1] of a 2D dataset.
+//
+// The hdf5 package exposes no shape accessor, but ReadSlice validates the
+// selection against the dataspace before reading. An empty selection reads
+// nothing, so it is used to check the rank and to search for each dimension.
+func hdf5Dims2D(ds *hdf5.Dataset) (d0, d1 int, err error) {
+	if _, err := ds.ReadSlice([]uint64{0, 0}, []uint64{0, 0}); err != nil {
+		return 0, 0, fmt.Errorf("not a 2D matrix: %w", err)
+	}
+	d0 = hdf5DimSize(func(n uint64) bool {
+		_, err := ds.ReadSlice([]uint64{n, 0}, []uint64{0, 0})
+		return err == nil
+	})
+	d1 = hdf5DimSize(func(n uint64) bool {
+		_, err := ds.ReadSlice([]uint64{0, n}, []uint64{0, 0})
+		return err == nil
+	})
+	return d0, d1, nil
+}
+
+// hdf5DimSize returns the largest n for which inBounds(n) holds, given that
+// inBounds(0) does and inBounds is monotone.
+func hdf5DimSize(inBounds func(n uint64) bool) int {
+	hi := uint64(1)
+	for inBounds(hi) {
+		hi *= 2
+	}
+	lo := hi / 2 // inBounds(lo) holds (lo == 0 if hi == 1)
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if inBounds(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return int(lo) //nolint:gosec // G115: bounded by the dataset size
+}
+
+// nativeToFloat64 converts a slice returned by ReadSlice to []float64.
+func nativeToFloat64(name string, raw interface{}) ([]float64, error) {
+	switch data := raw.(type) {
+	case []float64:
+		return data, nil
+	case []float32:
+		return convertRange(data, 0, len(data)), nil
+	case []int64:
+		return convertRange(data, 0, len(data)), nil
+	case []int32:
+		return convertRange(data, 0, len(data)), nil
+	case []int16:
+		return convertRange(data, 0, len(data)), nil
+	case []int8:
+		return convertRange(data, 0, len(data)), nil
+	case []uint64:
+		return convertRange(data, 0, len(data)), nil
+	case []uint32:
+		return convertRange(data, 0, len(data)), nil
+	case []uint16:
+		return convertRange(data, 0, len(data)), nil
+	case []uint8:
+		return convertRange(data, 0, len(data)), nil
+	default:
+		return nil, fmt.Errorf("matdata: cannot convert %q (%T) to float64", name, raw)
+	}
+}
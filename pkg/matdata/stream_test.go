@@ -0,0 +1,215 @@
+package matdata
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+	"github.com/scigolib/hdf5"
+)
+
+// writeHDF5Fixture writes a v7.3-style file with a rows×cols double matrix
+// "X" stored as MATLAB does: HDF5 shape [cols, rows], X(i, j) = 1000*j + i.
+func writeHDF5Fixture(t *testing.T, rows, cols int) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stream.mat")
+	fw, err := hdf5.CreateForWrite(path, hdf5.CreateTruncate)
+	if err != nil {
+		t.Skipf("HDF5 writer unavailable: %v", err)
+	}
+
+	data := make([]float64, rows*cols)
+	for j := 0; j < cols; j++ {
+		for i := 0; i < rows; i++ {
+			data[j*rows+i] = float64(1000*j + i)
+		}
+	}
+
+	dw, err := fw.CreateDataset("/X", hdf5.Float64, []uint64{uint64(cols), uint64(rows)})
+	if err != nil {
+		_ = fw.Close()
+		t.Fatalf("CreateDataset failed: %v", err)
+	}
+	if err := dw.Write(data); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := dw.WriteAttribute("MATLAB_class", "double"); err != nil {
+		t.Fatalf("WriteAttribute failed: %v", err)
+	}
+	if err := dw.Close(); err != nil {
+		t.Fatalf("dataset Close failed: %v", err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("file Close failed: %v", err)
+	}
+
+	return path
+}
+
+func TestStreamColumn_HDF5(t *testing.T) {
+	rows, cols := StreamChunkRows+10, 3
+	path := writeHDF5Fixture(t, rows, cols)
+
+	var chunks []int
+	var got []float64
+	err := StreamColumn(path, "X", 2, func(chunk []float64) {
+		chunks = append(chunks, len(chunk))
+		got = append(got, chunk...)
+	})
+	if err != nil {
+		t.Fatalf("StreamColumn failed: %v", err)
+	}
+
+	if len(chunks) != 2 || chunks[0] != StreamChunkRows || chunks[1] != 10 {
+		t.Errorf("chunk sizes = %v, want [%d 10]", chunks, StreamChunkRows)
+	}
+	if len(got) != rows {
+		t.Fatalf("streamed %d values, want %d", len(got), rows)
+	}
+	for i, val := range got {
+		if want := float64(2000 + i); val != want {
+			t.Fatalf("X(%d, 2) = %v, want %v", i, val, want)
+		}
+	}
+}
+
+// TestStreamColumn_StreamBuilder feeds a streamed column into an online
+// histogram without materializing the variable.
+func TestStreamColumn_StreamBuilder(t *testing.T) {
+	rows := StreamChunkRows + 10
+	path := writeHDF5Fixture(t, rows, 2)
+
+	b, err := histogram.NewStreamBuilder([]int{2}, []float64{0}, []float64{float64(rows)})
+	if err != nil {
+		t.Fatalf("NewStreamBuilder failed: %v", err)
+	}
+	err = StreamColumn(path, "X", 0, func(chunk []float64) {
+		for _, v := range chunk {
+			if err := b.Add([]float64{v}); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("StreamColumn failed: %v", err)
+	}
+	if b.Count() != rows {
+		t.Fatalf("Count = %d, want %d", b.Count(), rows)
+	}
+
+	// Column 0 is 0..rows-1: half of the values in each bin
+	hist, err := b.Histogram()
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	if p := hist.Probabilities(); math.Abs(p[0]-0.5) > 1e-9 {
+		t.Errorf("probs = %v, want [0.5 0.5]", p)
+	}
+}
+
+func TestStreamColumn_V5Fallback(t *testing.T) {
+	if _, err := os.Stat(testMATFile); os.IsNotExist(err) {
+		t.Skip("MATLAB test file not available")
+	}
+
+	mf, err := Open(testMATFile)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	want, err := mf.GetColumn("X", 7)
+	_ = mf.Close()
+	if err != nil {
+		t.Fatalf("GetColumn failed: %v", err)
+	}
+
+	var got []float64
+	err = StreamColumn(testMATFile, "X", 7, func(chunk []float64) {
+		got = append(got, chunk...)
+	})
+	if err != nil {
+		t.Fatalf("StreamColumn failed: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("streamed %d values, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStreamColumn_Userblock(t *testing.T) {
+	rows := 5
+	raw, err := os.ReadFile(writeHDF5Fixture(t, rows, 2))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	// MATLAB prepends a 512-byte userblock, starting with its text header,
+	// to the HDF5 data
+	userblock := make([]byte, 512)
+	copy(userblock, "MATLAB 7.3 MAT-file, Platform: GLNXA64")
+	path := filepath.Join(t.TempDir(), "userblock.mat")
+	if err := os.WriteFile(path, append(userblock, raw...), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	offset, err := hdf5SignatureOffset(path)
+	if err != nil || offset != 512 {
+		t.Fatalf("hdf5SignatureOffset = %d, %v; want 512, nil", offset, err)
+	}
+
+	// Falls back to the in-memory path
+	var got []float64
+	err = StreamColumn(path, "X", 1, func(chunk []float64) {
+		got = append(got, chunk...)
+	})
+	if err != nil {
+		t.Fatalf("StreamColumn failed: %v", err)
+	}
+	if len(got) != rows {
+		t.Fatalf("streamed %d values, want %d", len(got), rows)
+	}
+	for i, val := range got {
+		if want := float64(1000 + i); val != want {
+			t.Errorf("X(%d, 1) = %v, want %v", i, val, want)
+		}
+	}
+}
+
+func TestStreamColumn_Errors(t *testing.T) {
+	hdf5Path := writeHDF5Fixture(t, 5, 2)
+	noop := func([]float64) {}
+
+	type errCase struct {
+		name string
+		path string
+		v    string
+		col  int
+	}
+	tests := []errCase{
+		{"hdf5 missing variable", hdf5Path, "Y", 0},
+		{"hdf5 column out of range", hdf5Path, "X", 2},
+		{"hdf5 negative column", hdf5Path, "X", -1},
+		{"missing file", filepath.Join(t.TempDir(), "none.mat"), "X", 0},
+	}
+	if _, err := os.Stat(testMATFile); err == nil {
+		tests = append(tests,
+			errCase{"v5 missing variable", testMATFile, "nope", 0},
+			errCase{"v5 column out of range", testMATFile, "X", 1 << 20},
+		)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := StreamColumn(tt.path, tt.v, tt.col, noop); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package systems_test
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/pkg/systems"
+)
+
+// ExampleGenerateMixed builds a system with an even synergy/redundancy split.
+func ExampleGenerateMixed() {
+	data := systems.GenerateMixed(1000, 0.5, 42)
+
+	fmt.Println("samples:", len(data))
+	fmt.Println("columns:", len(data[0]))
+
+	// Output:
+	// samples: 1000
+	// columns: 3
+}
@@ -0,0 +1,21 @@
+// Package systems provides synthetic test systems with a known SURD
+// decomposition.
+//
+// It re-exports generators from the internal validation suite (package
+// internal/validation) with identical signatures, so external users can
+// check SURD against systems whose information split is known in advance.
+package systems
+
+import (
+	"github.com/causalgo/causalgo/internal/validation"
+)
+
+// GenerateMixed creates a test system whose information is split between a
+// synergistic (XOR) part and a redundant (shared) part in a tunable proportion.
+//
+// Each row is [target, agent1, agent2]; use 4 bins per variable. The target
+// carries 1 bit: SURD recovers ~synergyFraction bits of synergy and
+// ~(1 - synergyFraction) bits of redundancy. synergyFraction is clamped to [0, 1].
+func GenerateMixed(n int, synergyFraction float64, seed int64) [][]float64 {
+	return validation.GenerateMixed(n, synergyFraction, seed)
+}
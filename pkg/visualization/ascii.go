@@ -0,0 +1,121 @@
+package visualization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/causalgo/causalgo/surd"
+)
+
+// defaultASCIIWidth is the bar width used when ASCIIReport receives a non-positive width.
+const defaultASCIIWidth = 40
+
+// ASCIIReport renders SURD results as a multi-line ASCII bar chart.
+//
+// The report contains:
+//   - Components: total Redundant, Unique, and Synergistic bars
+//   - Information Leak bar
+//   - Per-key breakdowns of non-zero Unique, Redundant, and Synergistic terms
+//   - Summary with absolute values (bits) and percentages
+//
+// Bars and percentages come from result.Normalized(), so component bars are
+// fractions of the total R+U+S information; the leak bar is already
+// normalized to [0, 1]. Breakdown keys are sorted for stable output.
+//
+// width is the number of characters used for each bar (default: 40 if <= 0).
+//
+// Example:
+//
+//	result, _ := surd.DecomposeFromData(data, bins)
+//	fmt.Print(visualization.ASCIIReport(result, 40))
+func ASCIIReport(result *surd.Result, width int) string {
+	if result == nil {
+		return ""
+	}
+	if width <= 0 {
+		width = defaultASCIIWidth
+	}
+
+	n := result.Normalized()
+
+	var sb strings.Builder
+
+	sb.WriteString("Components:\n")
+	writeBar(&sb, "Redundant", n.TotalRedundant, width)
+	writeBar(&sb, "Unique", n.TotalUnique, width)
+	writeBar(&sb, "Synergistic", n.TotalSynergistic, width)
+	sb.WriteString("\n")
+
+	sb.WriteString("Information Leak:\n")
+	writeBar(&sb, "InfoLeak", result.InfoLeak, width)
+	sb.WriteString("\n")
+
+	if n.TotalUnique > 0 {
+		sb.WriteString("Unique Breakdown:\n")
+		writeBreakdown(&sb, n.Unique, "  Agent[%s]", width)
+		sb.WriteString("\n")
+	}
+
+	if n.TotalRedundant > 0 {
+		sb.WriteString("Redundant Combinations:\n")
+		writeBreakdown(&sb, n.Redundant, "  {%s}", width)
+		sb.WriteString("\n")
+	}
+
+	if n.TotalSynergistic > 0 {
+		sb.WriteString("Synergistic Combinations:\n")
+		writeBreakdown(&sb, n.Synergistic, "  {%s}", width)
+		sb.WriteString("\n")
+	}
+
+	total := n.TotalInformation
+	sb.WriteString("Summary:\n")
+	fmt.Fprintf(&sb, "  Total Information: %.4f bits\n", total)
+	fmt.Fprintf(&sb, "  Redundant: %.4f bits (%.1f%%)\n", n.TotalRedundant*total, 100*n.TotalRedundant)
+	fmt.Fprintf(&sb, "  Unique: %.4f bits (%.1f%%)\n", n.TotalUnique*total, 100*n.TotalUnique)
+	fmt.Fprintf(&sb, "  Synergistic: %.4f bits (%.1f%%)\n", n.TotalSynergistic*total, 100*n.TotalSynergistic)
+	fmt.Fprintf(&sb, "  InfoLeak: %.4f (%.1f%%)\n", result.InfoLeak, 100*result.InfoLeak)
+
+	return sb.String()
+}
+
+// writeBreakdown writes one bar per positive entry of fractions, in sorted key order.
+func writeBreakdown(sb *strings.Builder, fractions map[string]float64, labelFormat string, width int) {
+	keys := make([]string, 0, len(fractions))
+	for key := range fractions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if frac := fractions[key]; frac > 0 {
+			writeBar(sb, fmt.Sprintf(labelFormat, key), frac, width)
+		}
+	}
+}
+
+// writeBar writes an ASCII bar chart line for a fraction in [0, 1].
+func writeBar(sb *strings.Builder, label string, fraction float64, width int) {
+	percentage := fraction
+	if percentage < 0 {
+		percentage = 0
+	}
+	if percentage > 1 {
+		percentage = 1
+	}
+
+	// Calculate bar length
+	barLen := int(percentage * float64(width))
+	if barLen < 0 {
+		barLen = 0
+	}
+	if barLen > width {
+		barLen = width
+	}
+
+	// Create bar with filled and empty parts
+	bar := strings.Repeat("█", barLen) + strings.Repeat("░", width-barLen)
+
+	fmt.Fprintf(sb, "%-20s %s %.1f%%\n", label+":", bar, 100*percentage)
+}
@@ -0,0 +1,92 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/causalgo/causalgo/surd"
+)
+
+func TestASCIIReport(t *testing.T) {
+	result := createTestResult()
+
+	report := ASCIIReport(result, 40)
+
+	// Totals: R=0.2, U=0.4, S=0.35 → total 0.95
+	wantLines := []string{
+		"Components:",
+		"Redundant:",
+		"Unique:",
+		"Synergistic:",
+		"InfoLeak:",
+		"Unique Breakdown:",
+		"Agent[0]:",
+		"Redundant Combinations:",
+		"Synergistic Combinations:",
+		"Summary:",
+		"Total Information: 0.9500 bits",
+		"Redundant: 0.2000 bits (21.1%)",
+		"Unique: 0.4000 bits (42.1%)",
+		"Synergistic: 0.3500 bits (36.8%)",
+		"InfoLeak: 0.0500 (5.0%)",
+	}
+
+	for _, want := range wantLines {
+		if !strings.Contains(report, want) {
+			t.Errorf("ASCIIReport() missing %q\nreport:\n%s", want, report)
+		}
+	}
+}
+
+func TestASCIIReport_BarWidth(t *testing.T) {
+	result := createTestResult()
+
+	for _, width := range []int{10, 40} {
+		report := ASCIIReport(result, width)
+		for _, line := range strings.Split(report, "\n") {
+			if !strings.HasPrefix(line, "Redundant:") {
+				continue
+			}
+			cells := strings.Count(line, "█") + strings.Count(line, "░")
+			if cells != width {
+				t.Errorf("width=%d: bar has %d cells, want %d", width, cells, width)
+			}
+		}
+	}
+}
+
+func TestASCIIReport_EdgeCases(t *testing.T) {
+	if got := ASCIIReport(nil, 40); got != "" {
+		t.Errorf("ASCIIReport(nil) = %q, want empty string", got)
+	}
+
+	// Non-positive width falls back to default
+	report := ASCIIReport(createTestResult(), 0)
+	firstBar := strings.Split(report, "\n")[1]
+	cells := strings.Count(firstBar, "█") + strings.Count(firstBar, "░")
+	if cells != defaultASCIIWidth {
+		t.Errorf("width=0: bar has %d cells, want default %d", cells, defaultASCIIWidth)
+	}
+
+	// Zero total reports the real total, not a placeholder divisor
+	zero := &surd.Result{
+		Redundant:   map[string]float64{"0,1": 0},
+		Unique:      map[string]float64{"0": 0, "1": 0},
+		Synergistic: map[string]float64{"0,1": 0},
+		InfoLeak:    1,
+	}
+	report = ASCIIReport(zero, 40)
+	for _, want := range []string{
+		"Total Information: 0.0000 bits",
+		"Redundant: 0.0000 bits (0.0%)",
+		"Unique: 0.0000 bits (0.0%)",
+		"Synergistic: 0.0000 bits (0.0%)",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("zero total: ASCIIReport() missing %q\nreport:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Unique Breakdown:") {
+		t.Errorf("zero total: ASCIIReport() should omit empty breakdowns\nreport:\n%s", report)
+	}
+}
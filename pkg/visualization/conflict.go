@@ -0,0 +1,136 @@
+package visualization
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/causalgo/causalgo/internal/scic"
+	"github.com/causalgo/causalgo/surd"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// conflictPaletteSize is the number of colors in the conflict heatmap palette.
+const conflictPaletteSize = 101
+
+// PlotConflictMatrix creates an N×N heatmap of SCIC conflict indices.
+//
+// Cell (i, j) is colored by the conflict index of pair "i,j": red (the unique
+// color) for 0, i.e. opposing directions, through white to blue (the
+// redundant color) for 1, i.e. consistent directions. The matrix is
+// symmetric; the diagonal and pairs missing from result.Conflicts are left
+// blank. N is inferred from the pair keys. Variables are labeled X1..XN with
+// X1 in the top row; with opts.ShowLabels each cell also shows its value.
+// The title is opts.Title, or "Conflict Index" if it is empty.
+//
+// Example:
+//
+//	result, _ := scic.Decompose(Y, X, scic.DefaultConfig())
+//	opts := DefaultPlotOptions()
+//	opts.Title = "Sensor Conflicts"
+//	p, err := PlotConflictMatrix(result, opts)
+//	err = SavePNG(p, "conflicts.png", 6, 6)
+func PlotConflictMatrix(result *scic.Result, opts PlotOptions) (*plot.Plot, error) {
+	if result == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+
+	n := 0
+	for key := range result.Conflicts {
+		idx := surd.KeyToIndices(key)
+		if len(idx) != 2 || idx[0] == idx[1] {
+			return nil, fmt.Errorf("invalid conflict pair key %q", key)
+		}
+		n = max(n, idx[0]+1, idx[1]+1)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("no conflicts to plot")
+	}
+
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		for j := range matrix[i] {
+			matrix[i][j] = math.NaN()
+		}
+	}
+	for key, c := range result.Conflicts {
+		idx := surd.KeyToIndices(key)
+		matrix[idx[0]][idx[1]] = c
+		matrix[idx[1]][idx[0]] = c
+	}
+
+	p := plot.New()
+	p.Title.Text = opts.Title
+	if p.Title.Text == "" {
+		p.Title.Text = "Conflict Index"
+	}
+
+	heat := plotter.NewHeatMap(conflictGrid(matrix), conflictPalette(conflictPaletteSize))
+	heat.Min, heat.Max = 0, 1
+	heat.NaN = color.Transparent
+	p.Add(heat)
+
+	if opts.ShowLabels {
+		var cells plotter.XYLabels
+		for i, row := range matrix {
+			for j, c := range row {
+				if !math.IsNaN(c) {
+					cells.XYs = append(cells.XYs, plotter.XY{X: float64(j), Y: float64(n - 1 - i)})
+					cells.Labels = append(cells.Labels, fmt.Sprintf("%.2f", c))
+				}
+			}
+		}
+		labels, err := plotter.NewLabels(cells)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create labels: %w", err)
+		}
+		for i := range labels.TextStyle {
+			labels.TextStyle[i].XAlign = draw.XCenter
+			labels.TextStyle[i].YAlign = draw.YCenter
+		}
+		p.Add(labels)
+	}
+
+	xTicks := make([]plot.Tick, n)
+	yTicks := make([]plot.Tick, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("X%d", i+1)
+		xTicks[i] = plot.Tick{Value: float64(i), Label: name}
+		yTicks[i] = plot.Tick{Value: float64(n - 1 - i), Label: name}
+	}
+	p.X.Tick.Marker = plot.ConstantTicks(xTicks)
+	p.Y.Tick.Marker = plot.ConstantTicks(yTicks)
+
+	return p, nil
+}
+
+// conflictGrid adapts a square conflict matrix to plotter.GridXYZ, with row 0
+// of the matrix drawn at the top.
+type conflictGrid [][]float64
+
+func (g conflictGrid) Dims() (c, r int)   { return len(g), len(g) }
+func (g conflictGrid) Z(c, r int) float64 { return g[len(g)-1-r][c] }
+func (g conflictGrid) X(c int) float64    { return float64(c) }
+func (g conflictGrid) Y(r int) float64    { return float64(r) }
+
+// conflictPalette runs from the unique color (red) through white to the
+// redundant color (blue). It implements palette.Palette.
+type conflictPalette int
+
+// Colors implements palette.Palette.
+func (n conflictPalette) Colors() []color.Color {
+	low, high := GetColor("unique"), GetColor("redundant")
+	colors := make([]color.Color, n)
+	for i := range colors {
+		t := float64(i) / float64(n-1)
+		if t < 0.5 {
+			colors[i] = LightenColor(low, 2*t)
+		} else {
+			colors[i] = LightenColor(high, 2*(1-t))
+		}
+	}
+	return colors
+}
@@ -0,0 +1,89 @@
+package visualization
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/scic"
+)
+
+func TestPlotConflictMatrix(t *testing.T) {
+	result := &scic.Result{
+		Conflicts: map[string]float64{"0,1": 0.1, "0,2": 0.9, "1,2": 0.5},
+	}
+
+	tests := []struct {
+		name    string
+		result  *scic.Result
+		wantErr bool
+	}{
+		{"valid result", result, false},
+		{"nil result", nil, true},
+		{"no conflicts", &scic.Result{Conflicts: map[string]float64{}}, true},
+		{"single variable key", &scic.Result{Conflicts: map[string]float64{"0": 1}}, true},
+		{"self pair", &scic.Result{Conflicts: map[string]float64{"1,1": 1}}, true},
+		{"missing pairs", &scic.Result{Conflicts: map[string]float64{"0,3": 0.2}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := PlotConflictMatrix(tt.result, DefaultPlotOptions())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PlotConflictMatrix() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && p == nil {
+				t.Error("PlotConflictMatrix() returned nil plot without error")
+			}
+		})
+	}
+
+	p, err := PlotConflictMatrix(result, DefaultPlotOptions())
+	if err != nil {
+		t.Fatalf("PlotConflictMatrix failed: %v", err)
+	}
+	if err := SavePNG(p, filepath.Join(t.TempDir(), "conflicts.png"), 6, 6); err != nil {
+		t.Errorf("SavePNG failed: %v", err)
+	}
+
+	// opts.Title is used; an empty title falls back to "Conflict Index"
+	for _, tt := range []struct{ title, want string }{
+		{"Sensor Conflicts", "Sensor Conflicts"},
+		{"", "Conflict Index"},
+	} {
+		opts := DefaultPlotOptions()
+		opts.Title = tt.title
+		p, err := PlotConflictMatrix(result, opts)
+		if err != nil {
+			t.Fatalf("PlotConflictMatrix failed: %v", err)
+		}
+		if p.Title.Text != tt.want {
+			t.Errorf("Title = %q, want %q", p.Title.Text, tt.want)
+		}
+	}
+}
+
+func TestConflictGrid(t *testing.T) {
+	g := conflictGrid{{0, 1}, {2, 3}}
+
+	// Row 0 of the matrix is the top row of the grid
+	if z := g.Z(0, 1); z != 0 {
+		t.Errorf("Z(0, 1): expected 0, got %v", z)
+	}
+	if z := g.Z(1, 0); z != 3 {
+		t.Errorf("Z(1, 0): expected 3, got %v", z)
+	}
+}
+
+func TestConflictPalette(t *testing.T) {
+	colors := conflictPalette(conflictPaletteSize).Colors()
+	if len(colors) != conflictPaletteSize {
+		t.Fatalf("expected %d colors, got %d", conflictPaletteSize, len(colors))
+	}
+	if colors[0] != GetColor("unique") {
+		t.Errorf("expected unique color at 0, got %v", colors[0])
+	}
+	if colors[len(colors)-1] != GetColor("redundant") {
+		t.Errorf("expected redundant color at 1, got %v", colors[len(colors)-1])
+	}
+}
@@ -1,13 +1,18 @@
 package visualization
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
+
+	"github.com/causalgo/causalgo/surd"
 )
 
 // ExportFormat defines supported export formats.
@@ -166,3 +171,46 @@ func SavePlotWithOptions(p *plot.Plot, filename string, opts ExportOptions) erro
 		return fmt.Errorf("unsupported format: %s", opts.Format)
 	}
 }
+
+// ToHTML renders the SURD plot to an in-memory PNG and returns a self-contained
+// HTML snippet for notebooks and web dashboards:
+//
+//	<figure class="surd"><img src="data:image/png;base64,..." alt="Title"></figure>
+//
+// With opts.HTMLSummary the ASCIIReport is appended in a <pre> block inside
+// the figure. No files are written.
+//
+// Example:
+//
+//	html, err := ToHTML(result, DefaultPlotOptions())
+//	fmt.Fprint(w, html) // http.ResponseWriter
+func ToHTML(result *surd.Result, opts PlotOptions) (string, error) {
+	if opts.Width <= 0 || opts.Height <= 0 {
+		return "", fmt.Errorf("invalid dimensions: width=%f, height=%f", opts.Width, opts.Height)
+	}
+
+	p, err := PlotSURD(result, opts)
+	if err != nil {
+		return "", err
+	}
+
+	writer, err := p.WriterTo(vg.Length(opts.Width)*vg.Inch, vg.Length(opts.Height)*vg.Inch, "png")
+	if err != nil {
+		return "", fmt.Errorf("failed to render PNG: %w", err)
+	}
+	var png bytes.Buffer
+	if _, err := writer.WriteTo(&png); err != nil {
+		return "", fmt.Errorf("failed to render PNG: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<figure class="surd">`)
+	fmt.Fprintf(&sb, `<img src="data:image/png;base64,%s" alt="%s">`,
+		base64.StdEncoding.EncodeToString(png.Bytes()), html.EscapeString(opts.Title))
+	if opts.HTMLSummary {
+		fmt.Fprintf(&sb, "<pre>%s</pre>", html.EscapeString(ASCIIReport(result, defaultASCIIWidth)))
+	}
+	sb.WriteString(`</figure>`)
+
+	return sb.String(), nil
+}
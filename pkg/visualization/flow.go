@@ -0,0 +1,261 @@
+package visualization
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/causalgo/causalgo/surd"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// flowCategories lists the right-hand nodes of a flow diagram, top to bottom.
+var flowCategories = []string{"redundant", "unique", "synergistic"}
+
+// Layout of a flow diagram in data coordinates: nodes span [0, flowNodeWidth]
+// and [1-flowNodeWidth, 1], labels go in the margin outside [0, 1].
+const (
+	flowNodeWidth   = 0.03
+	flowNodeGap     = 0.04
+	flowLabelMargin = 0.15
+	flowCurveSteps  = 32
+)
+
+// flowRibbon is the share of one SURD category attributed to one source.
+type flowRibbon struct {
+	source   int
+	category string
+	value    float64
+}
+
+// PlotSURDFlow creates a flow (Sankey-style) diagram of SURD decomposition
+// results.
+//
+// Each source variable (left) is connected to the redundant, unique and
+// synergistic categories (right) by a ribbon whose width is proportional to
+// the information it contributes. A redundant or synergistic component of k
+// variables contributes value/k through each of its members, so the ribbons
+// of every category add up to its total. Ribbons use the GetColor colors of
+// their category, lightened.
+//
+// Values are normalized so their sum equals 1.0; ribbons below opts.Threshold
+// are omitted. With opts.ShowLabels, nodes are labeled with their share, e.g.
+// "X1 60%" and "U 40%".
+//
+// Example:
+//
+//	p, err := PlotSURDFlow(result, DefaultPlotOptions())
+//	err = SavePNG(p, "flow.png", 10, 6)
+func PlotSURDFlow(result *surd.Result, opts PlotOptions) (*plot.Plot, error) {
+	if result == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+
+	nvars, ribbons := collectFlows(result)
+	if len(ribbons) == 0 {
+		return nil, fmt.Errorf("no components to plot")
+	}
+
+	total := 0.0
+	for _, r := range ribbons {
+		total += r.value
+	}
+	kept := ribbons[:0]
+	for _, r := range ribbons {
+		r.value /= total
+		if r.value >= opts.Threshold {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no components above threshold %v", opts.Threshold)
+	}
+
+	p := plot.New()
+	p.Title.Text = opts.Title
+	p.HideAxes()
+	p.Add(flowDiagram{nvars: nvars, ribbons: kept, showLabels: opts.ShowLabels})
+
+	return p, nil
+}
+
+// collectFlows splits every positive SURD component equally among its member
+// variables. Ribbons are ordered by source, then by category.
+func collectFlows(result *surd.Result) (int, []flowRibbon) {
+	values := make(map[string]map[int]float64, len(flowCategories))
+	nvars := 0
+	for _, c := range []struct {
+		category   string
+		components map[string]float64
+	}{
+		{"redundant", result.Redundant},
+		{"unique", result.Unique},
+		{"synergistic", result.Synergistic},
+	} {
+		values[c.category] = make(map[int]float64)
+		for key, v := range c.components {
+			idx := surd.KeyToIndices(key)
+			if v <= 0 || len(idx) == 0 {
+				continue
+			}
+			for _, i := range idx {
+				values[c.category][i] += v / float64(len(idx))
+				nvars = max(nvars, i+1)
+			}
+		}
+	}
+
+	var ribbons []flowRibbon
+	for i := 0; i < nvars; i++ {
+		for _, category := range flowCategories {
+			if v := values[category][i]; v > 0 {
+				ribbons = append(ribbons, flowRibbon{source: i, category: category, value: v})
+			}
+		}
+	}
+	return nvars, ribbons
+}
+
+// flowDiagram draws source and category nodes joined by ribbons.
+// It implements plot.Plotter and plot.DataRanger.
+type flowDiagram struct {
+	nvars      int
+	ribbons    []flowRibbon // normalized values
+	showLabels bool
+}
+
+// DataRange implements plot.DataRanger.
+func (f flowDiagram) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return -flowLabelMargin, 1 + flowLabelMargin, 0, 1
+}
+
+// Plot implements plot.Plotter.
+func (f flowDiagram) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	sourceTotals := make([]float64, f.nvars)
+	categoryTotals := make(map[string]float64, len(flowCategories))
+	for _, r := range f.ribbons {
+		sourceTotals[r.source] += r.value
+		categoryTotals[r.category] += r.value
+	}
+	var sources []int
+	for i, v := range sourceTotals {
+		if v > 0 {
+			sources = append(sources, i)
+		}
+	}
+	var categories []string
+	for _, category := range flowCategories {
+		if categoryTotals[category] > 0 {
+			categories = append(categories, category)
+		}
+	}
+
+	// Both sides share one scale so that a ribbon has the same width at
+	// either end; the remaining height is spent on gaps between nodes.
+	total := 0.0
+	for _, v := range sourceTotals {
+		total += v
+	}
+	scale := (1 - flowNodeGap*float64(max(len(sources), len(categories))-1)) / total
+
+	// Node tops, centered vertically within [0, 1]
+	nodeTops := func(n int, height func(i int) float64) []float64 {
+		sum := flowNodeGap * float64(n-1)
+		for i := 0; i < n; i++ {
+			sum += height(i)
+		}
+		tops := make([]float64, n)
+		y := 1 - (1-sum)/2
+		for i := range tops {
+			tops[i] = y
+			y -= height(i) + flowNodeGap
+		}
+		return tops
+	}
+	sourceTops := nodeTops(len(sources), func(i int) float64 { return sourceTotals[sources[i]] * scale })
+	categoryTops := nodeTops(len(categories), func(i int) float64 { return categoryTotals[categories[i]] * scale })
+
+	// Ribbons: ordered by category within a source node (f.ribbons order) and
+	// by source within a category node.
+	leftCursor := make(map[int]float64, len(sources))
+	for i, s := range sources {
+		leftCursor[s] = sourceTops[i]
+	}
+	rightCursor := make(map[string]float64, len(categories))
+	for i, category := range categories {
+		rightCursor[category] = categoryTops[i]
+	}
+	for _, r := range f.ribbons {
+		width := r.value * scale
+		y0, y1 := leftCursor[r.source], rightCursor[r.category]
+		leftCursor[r.source] -= width
+		rightCursor[r.category] -= width
+
+		pts := make([]vg.Point, 0, 2*(flowCurveSteps+1))
+		for k := 0; k <= flowCurveSteps; k++ {
+			x, y := flowCurve(float64(k)/flowCurveSteps, y0, y1)
+			pts = append(pts, vg.Point{X: trX(x), Y: trY(y)})
+		}
+		for k := flowCurveSteps; k >= 0; k-- {
+			x, y := flowCurve(float64(k)/flowCurveSteps, y0-width, y1-width)
+			pts = append(pts, vg.Point{X: trX(x), Y: trY(y)})
+		}
+		c.FillPolygon(LightenColor(GetColor(r.category), 0.4), pts)
+	}
+
+	// Nodes and labels
+	sty := plt.X.Tick.Label
+	sty.YAlign = draw.YCenter
+	sourceColor := LightenColor(GetColor("border"), 0.3)
+	for i, s := range sources {
+		top, height := sourceTops[i], sourceTotals[s]*scale
+		fillRect(&c, trX, trY, 0, top-height, flowNodeWidth, top, sourceColor)
+		if f.showLabels {
+			sty.XAlign = draw.XRight
+			c.FillText(sty, vg.Point{X: trX(-0.01), Y: trY(top - height/2)},
+				fmt.Sprintf("X%d %.0f%%", s+1, 100*sourceTotals[s]))
+		}
+	}
+	for i, category := range categories {
+		top, height := categoryTops[i], categoryTotals[category]*scale
+		fillRect(&c, trX, trY, 1-flowNodeWidth, top-height, 1, top, GetColor(category))
+		if f.showLabels {
+			sty.XAlign = draw.XLeft
+			c.FillText(sty, vg.Point{X: trX(1.01), Y: trY(top - height/2)},
+				fmt.Sprintf("%s %.0f%%", flowCategoryLabel(category), 100*categoryTotals[category]))
+		}
+	}
+}
+
+// flowCurve returns the point at t in [0, 1] of a ribbon edge that leaves the
+// source nodes at height y0 and enters the category nodes at height y1,
+// following a smoothstep curve.
+func flowCurve(t, y0, y1 float64) (x, y float64) {
+	s := t * t * (3 - 2*t)
+	return flowNodeWidth + (1-2*flowNodeWidth)*t, y0 + (y1-y0)*s
+}
+
+// fillRect fills the data-coordinate rectangle [x0, x1] x [y0, y1].
+func fillRect(c *draw.Canvas, trX, trY func(float64) vg.Length, x0, y0, x1, y1 float64, clr color.Color) {
+	c.FillPolygon(clr, []vg.Point{
+		{X: trX(x0), Y: trY(y0)},
+		{X: trX(x1), Y: trY(y0)},
+		{X: trX(x1), Y: trY(y1)},
+		{X: trX(x0), Y: trY(y1)},
+	})
+}
+
+// flowCategoryLabel returns the bar-label prefix of a category: "R", "U" or "S".
+func flowCategoryLabel(category string) string {
+	switch category {
+	case "redundant":
+		return "R"
+	case "unique":
+		return "U"
+	default:
+		return "S"
+	}
+}
@@ -0,0 +1,80 @@
+package visualization
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/causalgo/causalgo/surd"
+)
+
+func TestPlotSURDFlow(t *testing.T) {
+	result := createTestResult()
+
+	tests := []struct {
+		name    string
+		result  *surd.Result
+		opts    PlotOptions
+		wantErr bool
+	}{
+		{"valid result", result, DefaultPlotOptions(), false},
+		{"nil result", nil, DefaultPlotOptions(), true},
+		{
+			name: "empty result",
+			result: &surd.Result{
+				Redundant:   map[string]float64{},
+				Unique:      map[string]float64{},
+				Synergistic: map[string]float64{},
+			},
+			opts:    DefaultPlotOptions(),
+			wantErr: true,
+		},
+		{"everything below threshold", result, PlotOptions{Threshold: 0.9}, true},
+		{"some below threshold", result, PlotOptions{Threshold: 0.15}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := PlotSURDFlow(tt.result, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PlotSURDFlow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && p == nil {
+				t.Error("PlotSURDFlow() returned nil plot without error")
+			}
+		})
+	}
+}
+
+func TestPlotSURDFlow_Save(t *testing.T) {
+	p, err := PlotSURDFlow(createTestResult(), DefaultPlotOptions())
+	if err != nil {
+		t.Fatalf("PlotSURDFlow failed: %v", err)
+	}
+	if err := SavePNG(p, filepath.Join(t.TempDir(), "flow.png"), 8, 5); err != nil {
+		t.Errorf("SavePNG failed: %v", err)
+	}
+}
+
+func TestCollectFlows(t *testing.T) {
+	nvars, ribbons := collectFlows(createTestResult())
+	if nvars != 2 {
+		t.Errorf("expected 2 variables, got %d", nvars)
+	}
+
+	// R12 = 0.2 and S12 = 0.35 are split equally between both variables
+	want := []flowRibbon{
+		{0, "redundant", 0.1}, {0, "unique", 0.3}, {0, "synergistic", 0.175},
+		{1, "redundant", 0.1}, {1, "unique", 0.1}, {1, "synergistic", 0.175},
+	}
+	if len(ribbons) != len(want) {
+		t.Fatalf("expected %d ribbons, got %d: %v", len(want), len(ribbons), ribbons)
+	}
+	for i, w := range want {
+		r := ribbons[i]
+		if r.source != w.source || r.category != w.category || math.Abs(r.value-w.value) > 1e-12 {
+			t.Errorf("ribbon %d: expected %+v, got %+v", i, w, r)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package visualization
 import (
 	"fmt"
 	"image/color"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -32,6 +33,18 @@ type PlotOptions struct {
 
 	// ShowLabels controls whether to show component labels on bars (default: true)
 	ShowLabels bool
+
+	// LogScale uses a logarithmic Y axis so small components stay visible (default: false).
+	// The axis floor is the power of ten at or below the smallest plotted value;
+	// zero values are drawn at the floor.
+	LogScale bool
+
+	// RelativeToMax normalizes values by the largest component instead of the sum,
+	// so the largest bar has height 1.0 (default: false).
+	RelativeToMax bool
+
+	// HTMLSummary makes ToHTML append the ASCIIReport in a <pre> block (default: false).
+	HTMLSummary bool
 }
 
 // DefaultPlotOptions returns default plotting options.
@@ -46,6 +59,9 @@ func DefaultPlotOptions() PlotOptions {
 	}
 }
 
+// minLogFloor is the lowest Y axis floor used with PlotOptions.LogScale.
+const minLogFloor = 1e-6
+
 // componentData represents a single bar in the plot.
 type componentData struct {
 	Label      string
@@ -62,27 +78,77 @@ type componentData struct {
 //   - Synergistic components (orange bars)
 //   - InfoLeak (optional, gray bar in separate subplot)
 //
-// Values are normalized so their sum equals 1.0.
+// Values are normalized so their sum equals 1.0, or so the largest equals 1.0
+// if opts.RelativeToMax is set. Set opts.LogScale for a logarithmic Y axis.
 //
 // Returns a gonum plot.Plot that can be saved using SavePNG, SaveSVG, or SavePDF.
 func PlotSURD(result *surd.Result, opts PlotOptions) (*plot.Plot, error) {
+	p, _, _, err := newSURDPlot(result, opts)
+	return p, err
+}
+
+// PlotSURDWithErrorBars creates a SURD bar chart with uncertainty error bars.
+//
+// intervals maps component labels to [low, high] bounds in bits, e.g. from
+// bootstrap resampling. Labels match the bar labels: "U1", "R12", "S12", ...
+// (1-based variable indices). Intervals are normalized by the same total as
+// the bar values, so they stay aligned with the bars.
+//
+// Components without an interval are drawn without an error bar; intervals
+// for components that are not plotted (zero or below Threshold) are ignored.
+//
+// Example:
+//
+//	intervals := map[string][2]float64{"U1": {0.8, 1.0}, "S12": {0.05, 0.15}}
+//	p, err := PlotSURDWithErrorBars(result, intervals, DefaultPlotOptions())
+func PlotSURDWithErrorBars(result *surd.Result, intervals map[string][2]float64, opts PlotOptions) (*plot.Plot, error) {
+	p, components, totalValue, err := newSURDPlot(result, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	errBars, err := createErrorBars(components, intervals, totalValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create error bars: %w", err)
+	}
+	if errBars != nil {
+		p.Add(errBars)
+		top := maxComponentValue(components)
+		for i := 0; i < errBars.Len(); i++ {
+			_, y := errBars.XY(i)
+			_, high := errBars.YError(i)
+			top = math.Max(top, y+high)
+		}
+		applyYAxis(p, components, top, opts)
+	}
+
+	return p, nil
+}
+
+// newSURDPlot builds the SURD bar chart and returns the plotted (normalized)
+// components together with the normalization total.
+func newSURDPlot(result *surd.Result, opts PlotOptions) (*plot.Plot, []componentData, float64, error) {
 	if result == nil {
-		return nil, fmt.Errorf("result is nil")
+		return nil, nil, 0, fmt.Errorf("result is nil")
 	}
 
 	// Collect all components
 	components := collectComponents(result)
 	if len(components) == 0 {
-		return nil, fmt.Errorf("no components to plot")
+		return nil, nil, 0, fmt.Errorf("no components to plot")
 	}
 
-	// Normalize values
+	// Normalize values by the sum (or by the largest component)
 	totalValue := 0.0
 	for _, comp := range components {
-		totalValue += comp.Value
+		if opts.RelativeToMax {
+			totalValue = math.Max(totalValue, comp.Value)
+		} else {
+			totalValue += comp.Value
+		}
 	}
 	if totalValue == 0 {
-		return nil, fmt.Errorf("total value is zero")
+		return nil, nil, 0, fmt.Errorf("total value is zero")
 	}
 
 	for i := range components {
@@ -104,8 +170,9 @@ func PlotSURD(result *surd.Result, opts PlotOptions) (*plot.Plot, error) {
 	p := plot.New()
 	p.Title.Text = opts.Title
 	p.Y.Label.Text = "Normalized Information"
-	p.Y.Min = 0
-	p.Y.Max = 1.0
+	if opts.RelativeToMax {
+		p.Y.Label.Text = "Information Relative to Max"
+	}
 
 	// Create separate bar charts for each component type to support different colors
 	// Group components by type
@@ -136,7 +203,111 @@ func PlotSURD(result *surd.Result, opts PlotOptions) (*plot.Plot, error) {
 	}
 	p.NominalX(labels...)
 
-	return p, nil
+	applyYAxis(p, components, maxComponentValue(components), opts)
+
+	return p, components, totalValue, nil
+}
+
+// applyYAxis sets the Y axis limits and scale after all plotters are added.
+// The axis ends at 1, the largest normalized bar, unless top (the highest
+// plotted value, including error bars) needs more room. Adding plotters can
+// widen the limits, so this must run last.
+func applyYAxis(p *plot.Plot, components []componentData, top float64, opts PlotOptions) {
+	p.Y.Max = math.Max(1, top)
+	if !opts.LogScale {
+		p.Y.Min = 0
+		return
+	}
+
+	floor := logFloor(components)
+	p.Y.Min = floor
+	p.Y.Scale = logFloorScale{floor: floor}
+	p.Y.Tick.Marker = plot.LogTicks{Prec: -1}
+}
+
+// maxComponentValue returns the largest normalized component value.
+func maxComponentValue(components []componentData) float64 {
+	top := 0.0
+	for _, comp := range components {
+		top = math.Max(top, comp.Value)
+	}
+	return top
+}
+
+// logFloor returns the power of ten at or below the smallest positive component value.
+func logFloor(components []componentData) float64 {
+	minPositive := 1.0
+	for _, comp := range components {
+		if comp.Value > 0 && comp.Value < minPositive {
+			minPositive = comp.Value
+		}
+	}
+	floor := math.Pow(10, math.Floor(math.Log10(minPositive)))
+	if floor >= 1.0 {
+		floor = 0.1
+	}
+	return math.Max(floor, minLogFloor)
+}
+
+// logFloorScale is a logarithmic scale that clamps values below floor.
+// Bars are drawn from zero, which plot.LogScale cannot normalize.
+type logFloorScale struct {
+	floor float64
+}
+
+// Normalize returns the fractional logarithmic distance of x between min and max.
+func (s logFloorScale) Normalize(minVal, maxVal, x float64) float64 {
+	minVal = math.Max(minVal, s.floor)
+	maxVal = math.Max(maxVal, s.floor)
+	x = math.Max(x, s.floor)
+	return plot.LogScale{}.Normalize(minVal, maxVal, x)
+}
+
+// errorBarData implements plotter.XYer and plotter.YErrorer for component error bars.
+type errorBarData struct {
+	x, y      []float64
+	low, high []float64 // distances below and above y
+}
+
+func (e errorBarData) Len() int                        { return len(e.x) }
+func (e errorBarData) XY(i int) (float64, float64)     { return e.x[i], e.y[i] }
+func (e errorBarData) YError(i int) (float64, float64) { return e.low[i], e.high[i] }
+
+// createErrorBars builds error bars for every plotted component with an interval.
+// Returns nil if no component has an interval.
+func createErrorBars(components []componentData, intervals map[string][2]float64, totalValue float64) (*plotter.YErrorBars, error) {
+	var data errorBarData
+	for i, comp := range components {
+		interval, ok := intervals[comp.Label]
+		if !ok {
+			continue
+		}
+
+		lo := interval[0] / totalValue
+		hi := interval[1] / totalValue
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+
+		data.x = append(data.x, float64(i))
+		data.y = append(data.y, comp.Value)
+		data.low = append(data.low, math.Max(comp.Value-lo, 0))
+		data.high = append(data.high, math.Max(hi-comp.Value, 0))
+	}
+
+	if data.Len() == 0 {
+		return nil, nil
+	}
+
+	errBars, err := plotter.NewYErrorBars(data)
+	if err != nil {
+		return nil, err
+	}
+	errBars.LineStyle.Width = vg.Points(1.5)
+	errBars.LineStyle.Color = GetColor("border")
+	errBars.CapWidth = vg.Points(8)
+
+	return errBars, nil
 }
 
 // collectComponents extracts all components from SURD result and generates labels.
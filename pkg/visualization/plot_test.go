@@ -1,10 +1,18 @@
 package visualization
 
 import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+
 	"github.com/causalgo/causalgo/surd"
 )
 
@@ -86,6 +94,171 @@ func TestPlotSURD(t *testing.T) {
 	}
 }
 
+func TestPlotSURDWithErrorBars(t *testing.T) {
+	result := createTestResult()
+
+	// Labels: R12, U1, U2, S12 — intervals for two of them plus one unknown label
+	intervals := map[string][2]float64{
+		"U1":  {0.25, 0.35},
+		"S12": {0.30, 0.40},
+		"S99": {0.0, 1.0},
+	}
+
+	p, err := PlotSURDWithErrorBars(result, intervals, DefaultPlotOptions())
+	if err != nil {
+		t.Fatalf("PlotSURDWithErrorBars() error = %v", err)
+	}
+	if p == nil {
+		t.Fatal("PlotSURDWithErrorBars() returned nil plot")
+	}
+
+	components := collectComponents(result)
+	total := 0.0
+	for _, comp := range components {
+		total += comp.Value
+	}
+	for i := range components {
+		components[i].Value /= total
+	}
+
+	errBars, err := createErrorBars(components, intervals, total)
+	if err != nil {
+		t.Fatalf("createErrorBars() error = %v", err)
+	}
+	if errBars == nil {
+		t.Fatal("createErrorBars() returned nil")
+	}
+	if got := errBars.Len(); got != 2 {
+		t.Fatalf("error bars have %d entries, want 2 (one per component with an interval)", got)
+	}
+
+	// U1 = 0.3 bits, interval [0.25, 0.35] normalized by the same total
+	for i := 0; i < errBars.Len(); i++ {
+		x, y := errBars.XY(i)
+		comp := components[int(x)]
+		if y != comp.Value {
+			t.Errorf("error bar %d at y=%f, want bar value %f", i, y, comp.Value)
+		}
+		if comp.Label == "U1" {
+			low, high := errBars.YError(i)
+			if math.Abs(low-0.05/total) > 1e-9 || math.Abs(high-0.05/total) > 1e-9 {
+				t.Errorf("U1 error = (%f, %f), want (%f, %f)", low, high, 0.05/total, 0.05/total)
+			}
+		}
+	}
+
+	// No matching intervals → no error bars, plot still created
+	if _, err := PlotSURDWithErrorBars(result, nil, DefaultPlotOptions()); err != nil {
+		t.Errorf("PlotSURDWithErrorBars() with nil intervals error = %v", err)
+	}
+
+	if _, err := PlotSURDWithErrorBars(nil, intervals, DefaultPlotOptions()); err == nil {
+		t.Error("PlotSURDWithErrorBars(nil) expected error")
+	}
+
+	// The Y axis reaches the highest error bar, even above 1
+	wide := map[string][2]float64{"S12": {0.30, 1.2}}
+	for _, logScale := range []bool{false, true} {
+		opts := DefaultPlotOptions()
+		opts.LogScale = logScale
+		p, err := PlotSURDWithErrorBars(result, wide, opts)
+		if err != nil {
+			t.Fatalf("PlotSURDWithErrorBars(LogScale=%v) error = %v", logScale, err)
+		}
+		if want := 1.2 / total; math.Abs(p.Y.Max-want) > 1e-12 {
+			t.Errorf("LogScale=%v: Y max = %f, want top of S12 error bar %f", logScale, p.Y.Max, want)
+		}
+	}
+}
+
+func TestPlotSURD_LogScaleAndRelativeToMax(t *testing.T) {
+	result := createTestResult()
+
+	linear, err := PlotSURD(result, DefaultPlotOptions())
+	if err != nil {
+		t.Fatalf("PlotSURD() error = %v", err)
+	}
+	if _, ok := linear.Y.Scale.(plot.LinearScale); !ok {
+		t.Errorf("default Y scale = %T, want plot.LinearScale", linear.Y.Scale)
+	}
+	// Fixed [0, 1] range for normalized bars
+	if linear.Y.Min != 0 || linear.Y.Max != 1 {
+		t.Errorf("default Y limits = [%f, %f], want [0, 1]", linear.Y.Min, linear.Y.Max)
+	}
+
+	opts := DefaultPlotOptions()
+	opts.LogScale = true
+	logPlot, err := PlotSURD(result, opts)
+	if err != nil {
+		t.Fatalf("PlotSURD(LogScale) error = %v", err)
+	}
+	if _, ok := logPlot.Y.Scale.(logFloorScale); !ok {
+		t.Errorf("log Y scale = %T, want logFloorScale", logPlot.Y.Scale)
+	}
+	// Smallest bar is U2 = 0.1/0.95 ≈ 0.105 → floor 0.1
+	if math.Abs(logPlot.Y.Min-0.1) > 1e-12 || logPlot.Y.Max != 1 {
+		t.Errorf("log Y limits = [%g, %g], want [0.1, 1]", logPlot.Y.Min, logPlot.Y.Max)
+	}
+
+	// Threshold filtering out every bar keeps the [0, 1] range
+	opts = DefaultPlotOptions()
+	opts.Threshold = 2
+	empty, err := PlotSURD(result, opts)
+	if err != nil {
+		t.Fatalf("PlotSURD(Threshold=2) error = %v", err)
+	}
+	if empty.Y.Min != 0 || empty.Y.Max != 1 {
+		t.Errorf("empty plot Y limits = [%f, %f], want [0, 1]", empty.Y.Min, empty.Y.Max)
+	}
+
+	// RelativeToMax: largest component (S12 = 0.35) maps to 1.0
+	_, components, total, err := newSURDPlot(result, PlotOptions{RelativeToMax: true, ShowLeak: true})
+	if err != nil {
+		t.Fatalf("newSURDPlot(RelativeToMax) error = %v", err)
+	}
+	if total != 0.35 {
+		t.Errorf("normalization = %f, want largest component 0.35", total)
+	}
+	maxValue := 0.0
+	for _, comp := range components {
+		maxValue = math.Max(maxValue, comp.Value)
+	}
+	if math.Abs(maxValue-1) > 1e-12 {
+		t.Errorf("largest normalized component = %f, want 1", maxValue)
+	}
+}
+
+func TestPlotSURD_LogScaleWithZeros(t *testing.T) {
+	result := &surd.Result{
+		Redundant:   map[string]float64{"0,1": 0},
+		Unique:      map[string]float64{"0": 0.6, "1": 0},
+		Synergistic: map[string]float64{"0,1": 0.4},
+		InfoLeak:    0,
+	}
+
+	opts := DefaultPlotOptions()
+	opts.LogScale = true
+	opts.Threshold = 0
+
+	intervals := map[string][2]float64{"S12": {0, 0.5}}
+	p, err := PlotSURDWithErrorBars(result, intervals, opts)
+	if err != nil {
+		t.Fatalf("PlotSURDWithErrorBars(LogScale) error = %v", err)
+	}
+	if p.Y.Min <= 0 {
+		t.Errorf("log Y min = %g, want > 0", p.Y.Min)
+	}
+
+	// Rendering must not panic on zero-height bars or error bars reaching zero
+	w, err := p.WriterTo(4*vg.Inch, 3*vg.Inch, "png")
+	if err != nil {
+		t.Fatalf("WriterTo() error = %v", err)
+	}
+	if _, err := w.WriteTo(io.Discard); err != nil {
+		t.Errorf("WriteTo() error = %v", err)
+	}
+}
+
 func TestPlotInfoLeak(t *testing.T) {
 	result := createTestResult()
 
@@ -328,6 +501,55 @@ func TestSavePlot(t *testing.T) {
 	}
 }
 
+func TestToHTML(t *testing.T) {
+	result := createTestResult()
+	opts := DefaultPlotOptions()
+	opts.Title = "XOR <synergy>"
+
+	out, err := ToHTML(result, opts)
+	if err != nil {
+		t.Fatalf("ToHTML failed: %v", err)
+	}
+
+	const prefix = `<img src="data:image/png;base64,`
+	start := strings.Index(out, prefix)
+	if start < 0 {
+		t.Fatalf("missing base64 PNG image in %.100q", out)
+	}
+	encoded := out[start+len(prefix):]
+	encoded = encoded[:strings.IndexByte(encoded, '"')]
+	png, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("invalid base64: %v", err)
+	}
+	if !bytes.HasPrefix(png, []byte("\x89PNG\r\n\x1a\n")) {
+		t.Errorf("decoded image is not a PNG: % x", png[:8])
+	}
+
+	if !strings.Contains(out, `alt="XOR &lt;synergy&gt;"`) {
+		t.Error("title missing or not escaped")
+	}
+	if strings.Contains(out, "<pre>") {
+		t.Error("summary included without HTMLSummary")
+	}
+
+	opts.HTMLSummary = true
+	out, err = ToHTML(result, opts)
+	if err != nil {
+		t.Fatalf("ToHTML with summary failed: %v", err)
+	}
+	if !strings.Contains(out, "<pre>") || !strings.Contains(out, "Information Leak") {
+		t.Error("expected ASCII summary in <pre> block")
+	}
+
+	if _, err := ToHTML(nil, DefaultPlotOptions()); err == nil {
+		t.Error("expected error for nil result")
+	}
+	if _, err := ToHTML(result, PlotOptions{}); err == nil {
+		t.Error("expected error for zero dimensions")
+	}
+}
+
 func TestGetColor(t *testing.T) {
 	tests := []struct {
 		name          string
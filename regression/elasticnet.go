@@ -0,0 +1,114 @@
+package regression
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ElasticNetConfig stores configuration parameters for elastic-net regression
+type ElasticNetConfig struct {
+	Lambda    float64 // Regularization parameter (λ ≥ 0)
+	L1Ratio   float64 // Share of the L1 penalty (0 = ridge, 1 = LASSO)
+	Tolerance float64 // Convergence threshold
+	MaxIter   int     // Maximum iterations
+}
+
+// ElasticNet implements regression with a mixed L1/L2 penalty
+//
+//	½‖y - Xw‖² + λ·L1Ratio·‖w‖₁ + ½·λ·(1-L1Ratio)·‖w‖²
+//
+// Unlike LASSO, which tends to keep one of a group of correlated predictors
+// and drop the others, the L2 term spreads the weight across the group.
+type ElasticNet struct {
+	config ElasticNetConfig
+}
+
+// NewElasticNet creates a new elastic-net regressor with validated configuration
+// Defaults:
+//   - Lambda: 0.01 (if negative)
+//   - L1Ratio: 0.5 (if outside [0, 1])
+//   - Tolerance: 1e-5
+//   - MaxIter: 1000
+func NewElasticNet(cfg ElasticNetConfig) *ElasticNet {
+	// Validate and set defaults
+	if cfg.Lambda < 0 {
+		cfg.Lambda = 0.01
+	}
+	if cfg.L1Ratio < 0 || cfg.L1Ratio > 1 || math.IsNaN(cfg.L1Ratio) {
+		cfg.L1Ratio = 0.5
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = 1e-5
+	}
+	if cfg.MaxIter <= 0 {
+		cfg.MaxIter = 1000
+	}
+	return &ElasticNet{config: cfg}
+}
+
+// Fit trains the elastic-net model using coordinate descent algorithm
+// Implements Regressor interface. With L1Ratio = 1 it matches LASSO.Fit.
+func (e *ElasticNet) Fit(x *mat.Dense, y []float64) []float64 {
+	if x == nil {
+		return nil
+	}
+
+	n, p := x.Dims()
+	if p == 0 {
+		return []float64{}
+	}
+	weights := make([]float64, p)
+
+	l1 := e.config.Lambda * e.config.L1Ratio
+	l2 := e.config.Lambda * (1 - e.config.L1Ratio)
+
+	// Cache columns and their norms
+	cols := make([][]float64, p)
+	norms := make([]float64, p)
+	for j := 0; j < p; j++ {
+		col := make([]float64, n)
+		mat.Col(col, j, x)
+		cols[j] = col
+		norms[j] = floats.Dot(col, col)
+		if norms[j] < 1e-12 { // Prevent division by zero
+			norms[j] = 1e-12
+		}
+	}
+
+	// Initialize residuals
+	residual := make([]float64, n)
+	copy(residual, y)
+
+	// Coordinate descent iterations
+	for iter := 0; iter < e.config.MaxIter; iter++ {
+		maxChange := 0.0
+
+		for j := 0; j < p; j++ {
+			oldWeight := weights[j]
+
+			// Compute X_j^T * residual + current weight contribution
+			xDotR := floats.Dot(cols[j], residual) + oldWeight*norms[j]
+
+			// Soft-threshold for L1, shrink by the L2 term
+			newWeight := softThreshold(xDotR, l1) / (norms[j] + l2)
+
+			// Update weights and residuals
+			delta := newWeight - oldWeight
+			if math.Abs(delta) > 1e-12 {
+				floats.AddScaled(residual, -delta, cols[j])
+				weights[j] = newWeight
+				if math.Abs(delta) > maxChange {
+					maxChange = math.Abs(delta)
+				}
+			}
+		}
+
+		// Check convergence
+		if maxChange < e.config.Tolerance {
+			break
+		}
+	}
+	return weights
+}
@@ -0,0 +1,64 @@
+package regression
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestElasticNetFit verifies the elastic-net limits and the L2 shrinkage
+func TestElasticNetFit(t *testing.T) {
+	x := mat.NewDense(4, 2, []float64{1, 0.5, 2, 1.5, 3, 2.5, 4, 4.5})
+	y := []float64{2, 4, 6, 9}
+
+	t.Run("L1Ratio=1 matches LASSO", func(t *testing.T) {
+		want := NewLASSO(LASSOConfig{Lambda: 0.5}).Fit(x, y)
+		got := NewElasticNet(ElasticNetConfig{Lambda: 0.5, L1Ratio: 1}).Fit(x, y)
+		for j := range want {
+			if math.Abs(got[j]-want[j]) > 1e-12 {
+				t.Errorf("weight %d: got %v, want %v", j, got[j], want[j])
+			}
+		}
+	})
+
+	t.Run("L1Ratio=0 is ridge", func(t *testing.T) {
+		// One feature: w = x·y / (x·x + λ)
+		x1 := mat.NewDense(3, 1, []float64{1, 2, 3})
+		got := NewElasticNet(ElasticNetConfig{Lambda: 2, L1Ratio: 0, Tolerance: 1e-12}).Fit(x1, []float64{2, 4, 6})
+		if want := 28.0 / 16.0; math.Abs(got[0]-want) > 1e-9 {
+			t.Errorf("got %v, want %v", got[0], want)
+		}
+	})
+
+	t.Run("collinear predictors share the weight", func(t *testing.T) {
+		xc := mat.NewDense(4, 2, []float64{1, 1, 2, 2, 3, 3, 4, 4})
+		yc := []float64{2, 4, 6, 8}
+		lasso := NewLASSO(LASSOConfig{Lambda: 1}).Fit(xc, yc)
+		enet := NewElasticNet(ElasticNetConfig{Lambda: 1, L1Ratio: 0.5, Tolerance: 1e-12, MaxIter: 100000}).Fit(xc, yc)
+		if lasso[1] != 0 {
+			t.Errorf("expected LASSO to drop the second copy, got %v", lasso)
+		}
+		if math.Abs(enet[0]-enet[1]) > 1e-6 {
+			t.Errorf("expected equal elastic-net weights, got %v", enet)
+		}
+	})
+
+	t.Run("edge cases", func(t *testing.T) {
+		e := NewElasticNet(ElasticNetConfig{})
+		if e.Fit(nil, nil) != nil {
+			t.Error("expected nil weights for nil input")
+		}
+		if w := e.Fit(mat.NewDense(1, 1, []float64{0}), []float64{1}); len(w) != 1 || w[0] != 0 {
+			t.Errorf("expected zero weight for zero column, got %v", w)
+		}
+	})
+}
+
+// TestNewElasticNetDefaults verifies configuration defaults
+func TestNewElasticNetDefaults(t *testing.T) {
+	e := NewElasticNet(ElasticNetConfig{Lambda: -1, L1Ratio: 2})
+	if e.config.Lambda != 0.01 || e.config.L1Ratio != 0.5 || e.config.Tolerance != 1e-5 || e.config.MaxIter != 1000 {
+		t.Errorf("unexpected defaults: %+v", e.config)
+	}
+}
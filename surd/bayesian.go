@@ -0,0 +1,159 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// credibleLevel is the probability mass of the intervals reported by BayesianDecompose.
+const credibleLevel = 0.95
+
+// CredibleInterval summarizes the posterior distribution of one component.
+type CredibleInterval struct {
+	// Mean is the posterior mean.
+	Mean float64
+
+	// Lower and Upper bound the central 95% credible interval
+	// (2.5% and 97.5% posterior quantiles).
+	Lower, Upper float64
+}
+
+// Width returns Upper - Lower.
+func (c CredibleInterval) Width() float64 {
+	return c.Upper - c.Lower
+}
+
+// BayesianResult holds posterior summaries of the SURD components.
+// Keys are the same as in Result.
+type BayesianResult struct {
+	Redundant   map[string]CredibleInterval
+	Unique      map[string]CredibleInterval
+	Synergistic map[string]CredibleInterval
+
+	// InfoLeak summarizes the normalized leak, as Result.InfoLeak.
+	InfoLeak CredibleInterval
+
+	// NSamples is the number of posterior samples drawn.
+	NSamples int
+}
+
+// BayesianDecompose estimates SURD components with posterior uncertainty under
+// a symmetric Dirichlet prior on the bin probabilities.
+//
+// data: matrix [samples x variables], first column = target
+// bins: number of bins for each variable
+// alpha: Dirichlet concentration per bin (> 0); 1 is the Laplace (add-one)
+// prior, 0.5 the Jeffreys prior
+// nSamples: number of posterior samples (>= 2)
+// seed: random seed for reproducible sampling
+//
+// With bin counts n_i the posterior is Dirichlet(n_i + alpha). Each posterior
+// sample is a full probability grid that is decomposed with Decompose; the
+// component values across samples give the posterior mean and the central 95%
+// credible interval. Unlike the bootstrap, every bin gets nonzero mass, so
+// empty bins contribute uncertainty instead of being treated as impossible.
+//
+// For large samples the posterior concentrates on the plug-in histogram, so
+// the means approach DecomposeFromData and the intervals shrink as 1/√N.
+//
+// Example:
+//
+//	post, err := BayesianDecompose(data, []int{8, 8, 8}, 0.5, 500, 42)
+//	ci := post.Unique["1"]
+//	fmt.Printf("U1 = %.3f [%.3f, %.3f]\n", ci.Mean, ci.Lower, ci.Upper)
+func BayesianDecompose(data [][]float64, bins []int, alpha float64, nSamples int, seed int64) (*BayesianResult, error) {
+	if !(alpha > 0) || math.IsInf(alpha, 0) {
+		return nil, fmt.Errorf("alpha must be positive and finite, got %v", alpha)
+	}
+	if nSamples < 2 {
+		return nil, fmt.Errorf("nSamples must be at least 2, got %d", nSamples)
+	}
+	if len(data) < 2 {
+		return nil, fmt.Errorf("data must have at least 2 samples, got %d", len(data))
+	}
+	if len(bins) != len(data[0]) {
+		return nil, fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), len(data[0]))
+	}
+
+	hist, err := histogram.NewNDHistogram(data, bins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create histogram: %w", err)
+	}
+
+	// Posterior concentration: counts recovered from the normalized histogram
+	probs := hist.Probabilities()
+	concentration := make([]float64, len(probs))
+	for i, p := range probs {
+		concentration[i] = math.Round(p*float64(len(data))) + alpha
+	}
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // reproducible posterior sampling
+
+	samples := make([]*Result, nSamples)
+	grid := make([]float64, len(probs))
+	for s := range samples {
+		// Dirichlet draw: independent Gamma(concentration_i, 1) variates,
+		// normalized by NewNDHistogramFromProbabilities
+		for i, a := range concentration {
+			grid[i] = distuv.Gamma{Alpha: a, Beta: 1, Src: rng}.Rand()
+		}
+		h, err := histogram.NewNDHistogramFromProbabilities(grid, hist.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("posterior sample %d: %w", s, err)
+		}
+		if samples[s], err = Decompose(h); err != nil {
+			return nil, fmt.Errorf("posterior sample %d: %w", s, err)
+		}
+	}
+
+	leak := make([]float64, nSamples)
+	for s, r := range samples {
+		leak[s] = r.InfoLeak
+	}
+
+	return &BayesianResult{
+		Redundant:   summarizePosterior(samples, func(r *Result) map[string]float64 { return r.Redundant }),
+		Unique:      summarizePosterior(samples, func(r *Result) map[string]float64 { return r.Unique }),
+		Synergistic: summarizePosterior(samples, func(r *Result) map[string]float64 { return r.Synergistic }),
+		InfoLeak:    credibleInterval(leak),
+		NSamples:    nSamples,
+	}, nil
+}
+
+// summarizePosterior computes a credible interval for every key of the selected
+// component map. Keys missing from a sample count as zero.
+func summarizePosterior(samples []*Result, component func(*Result) map[string]float64) map[string]CredibleInterval {
+	values := make(map[string][]float64)
+	for s, r := range samples {
+		for key, v := range component(r) {
+			if values[key] == nil {
+				values[key] = make([]float64, len(samples))
+			}
+			values[key][s] = v
+		}
+	}
+
+	summary := make(map[string]CredibleInterval, len(values))
+	for key, v := range values {
+		summary[key] = credibleInterval(v)
+	}
+	return summary
+}
+
+// credibleInterval returns the mean and central credible interval of values.
+// values is sorted in place.
+func credibleInterval(values []float64) CredibleInterval {
+	sort.Float64s(values)
+	tail := (1 - credibleLevel) / 2
+	return CredibleInterval{
+		Mean:  stat.Mean(values, nil),
+		Lower: stat.Quantile(tail, stat.LinInterp, values, nil),
+		Upper: stat.Quantile(1-tail, stat.LinInterp, values, nil),
+	}
+}
@@ -0,0 +1,95 @@
+package surd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBayesianDecompose_MeanNearPlugin(t *testing.T) {
+	data := generateNoisyCopy(5000, 3)
+	bins := []int{2, 2, 2}
+
+	plugin, err := DecomposeFromData(data, bins)
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	post, err := BayesianDecompose(data, bins, 0.5, 200, 1)
+	if err != nil {
+		t.Fatalf("BayesianDecompose failed: %v", err)
+	}
+
+	if post.NSamples != 200 {
+		t.Errorf("NSamples = %d, want 200", post.NSamples)
+	}
+
+	ci := post.Unique["0"]
+	if math.Abs(ci.Mean-plugin.Unique["0"]) > 0.01 {
+		t.Errorf("posterior mean of U0 = %.4f, plug-in = %.4f", ci.Mean, plugin.Unique["0"])
+	}
+	if !(ci.Lower <= ci.Mean && ci.Mean <= ci.Upper) {
+		t.Errorf("mean %.4f outside credible interval [%.4f, %.4f]", ci.Mean, ci.Lower, ci.Upper)
+	}
+	if !(ci.Lower <= plugin.Unique["0"] && plugin.Unique["0"] <= ci.Upper) {
+		t.Errorf("plug-in %.4f outside credible interval [%.4f, %.4f]", plugin.Unique["0"], ci.Lower, ci.Upper)
+	}
+	if math.Abs(post.InfoLeak.Mean-plugin.InfoLeak) > 0.01 {
+		t.Errorf("posterior mean of leak = %.4f, plug-in = %.4f", post.InfoLeak.Mean, plugin.InfoLeak)
+	}
+}
+
+func TestBayesianDecompose_IntervalsShrinkWithN(t *testing.T) {
+	bins := []int{2, 2, 2}
+
+	var prev float64
+	for i, n := range []int{200, 2000, 20000} {
+		post, err := BayesianDecompose(generateNoisyCopy(n, 4), bins, 1, 200, 2)
+		if err != nil {
+			t.Fatalf("n=%d: BayesianDecompose failed: %v", n, err)
+		}
+		width := post.Unique["0"].Width()
+		t.Logf("n=%d: U0 = %.4f, width %.4f", n, post.Unique["0"].Mean, width)
+
+		if width <= 0 {
+			t.Errorf("n=%d: expected positive interval width, got %v", n, width)
+		}
+		if i > 0 && width >= prev {
+			t.Errorf("n=%d: width %.4f did not shrink (previous %.4f)", n, width, prev)
+		}
+		prev = width
+	}
+}
+
+func TestBayesianDecompose_Reproducible(t *testing.T) {
+	data := generateNoisyCopy(500, 5)
+	bins := []int{2, 2, 2}
+
+	a, err := BayesianDecompose(data, bins, 1, 50, 7)
+	if err != nil {
+		t.Fatalf("BayesianDecompose failed: %v", err)
+	}
+	b, err := BayesianDecompose(data, bins, 1, 50, 7)
+	if err != nil {
+		t.Fatalf("BayesianDecompose failed: %v", err)
+	}
+	if a.Unique["0"] != b.Unique["0"] || a.InfoLeak != b.InfoLeak {
+		t.Errorf("same seed gave different results: %+v vs %+v", a.Unique["0"], b.Unique["0"])
+	}
+}
+
+func TestBayesianDecompose_InvalidInput(t *testing.T) {
+	data := generateNoisyCopy(100, 6)
+	bins := []int{2, 2, 2}
+
+	if _, err := BayesianDecompose(data, bins, 0, 10, 1); err == nil {
+		t.Error("expected error for alpha = 0")
+	}
+	if _, err := BayesianDecompose(data, bins, 1, 1, 1); err == nil {
+		t.Error("expected error for nSamples = 1")
+	}
+	if _, err := BayesianDecompose(data, []int{2, 2}, 1, 10, 1); err == nil {
+		t.Error("expected error for bins length mismatch")
+	}
+	if _, err := BayesianDecompose(data[:1], bins, 1, 10, 1); err == nil {
+		t.Error("expected error for a single sample")
+	}
+}
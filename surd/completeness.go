@@ -0,0 +1,151 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// CompletenessResidual returns (R + U + S) - I(target; all agents) in bits,
+// where R, U and S are the sums of all redundant, unique and synergistic
+// components.
+//
+// The decomposition should satisfy R + U + S = I(target; agents), so the
+// residual is zero up to floating-point error. A residual well away from zero
+// indicates that information is lost or double-counted, e.g. by prescreening
+// or by the filter that zeroes higher-order combinations. Returns 0 for a nil
+// result.
+//
+// Example:
+//
+//	result, _ := DecomposeFromData(data, bins)
+//	if r := CompletenessResidual(result); math.Abs(r) > 1e-6 {
+//	    log.Printf("decomposition is incomplete by %.2g bits", r)
+//	}
+func CompletenessResidual(result *Result) float64 {
+	if result == nil {
+		return 0
+	}
+	return sumMap(result.Redundant) + sumMap(result.Unique) + sumMap(result.Synergistic) - result.TotalMutualInfo()
+}
+
+// Validate checks the SURD accounting identities of r and returns an error
+// naming the first one that is violated by more than tolerance:
+//
+//  1. every redundant, unique and synergistic component is >= 0;
+//  2. InfoLeak is in [0, 1] and LeakBits is >= 0;
+//  3. R + U + S = I(target; agents), i.e. CompletenessResidual is 0;
+//  4. the leak is consistent with H(target) = I(target; agents) + LeakBits:
+//     InfoLeak * H(target) = LeakBits.
+//
+// Together, 3 and 4 give H(target) = ΣR + ΣU + ΣS + LeakBits. Components are
+// checked in the order R, U, S with keys sorted, so the error is
+// deterministic.
+//
+// Results of Decompose and DecomposeFromData satisfy all checks up to
+// floating-point error. DebiasedMI can produce small negative components,
+// GrassbergerEntropy corrects MutualInfo but not R, U and S, and
+// DecomposeSubsets or PrescreenThreshold drop information, so such results
+// may legitimately fail 1 or 3.
+//
+// Example:
+//
+//	if err := result.Validate(1e-9); err != nil {
+//	    log.Printf("inconsistent decomposition: %v", err)
+//	}
+func (r *Result) Validate(tolerance float64) error {
+	if r == nil {
+		return fmt.Errorf("result is nil")
+	}
+	if !(tolerance >= 0) {
+		return fmt.Errorf("tolerance must be non-negative, got %v", tolerance)
+	}
+
+	for _, c := range []struct {
+		name   string
+		values map[string]float64
+	}{
+		{"Redundant", r.Redundant},
+		{"Unique", r.Unique},
+		{"Synergistic", r.Synergistic},
+	} {
+		keys := make([]string, 0, len(c.values))
+		for key := range c.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if v := c.values[key]; !(v >= -tolerance) {
+				return fmt.Errorf("%s[%s] = %v is negative", c.name, key, v)
+			}
+		}
+	}
+
+	if !(r.InfoLeak >= -tolerance && r.InfoLeak <= 1+tolerance) {
+		return fmt.Errorf("InfoLeak = %v is outside [0, 1]", r.InfoLeak)
+	}
+	if !(r.LeakBits >= -tolerance) {
+		return fmt.Errorf("LeakBits = %v is negative", r.LeakBits)
+	}
+
+	mi := r.TotalMutualInfo()
+	if residual := CompletenessResidual(r); !(math.Abs(residual) <= tolerance) {
+		return fmt.Errorf("R + U + S differs from I(target; agents) = %v by %v bits", mi, residual)
+	}
+
+	hTarget := mi + r.LeakBits
+	if diff := r.InfoLeak*hTarget - r.LeakBits; !(math.Abs(diff) <= tolerance) {
+		return fmt.Errorf("InfoLeak = %v is inconsistent with LeakBits = %v and H(target) = %v", r.InfoLeak, r.LeakBits, hTarget)
+	}
+
+	return nil
+}
+
+// BootstrapCompletenessResidual returns the completeness residual of nBoot
+// bootstrap resamples of data, showing how the residual is distributed for a
+// given dataset rather than for one estimate.
+//
+// data: matrix [samples x variables], first column = target
+// bins: number of bins for each variable
+// opts: decomposition options (e.g. to check a PrescreenThreshold)
+// nBoot: number of bootstrap resamples (> 0)
+// seed: random seed for reproducible resampling
+//
+// Each resample draws len(data) rows with replacement and is decomposed with
+// DecomposeWithOptions(hist, opts).
+//
+// Example:
+//
+//	residuals, err := BootstrapCompletenessResidual(data, bins, DefaultOptions(), 200, 42)
+func BootstrapCompletenessResidual(data [][]float64, bins []int, opts Options, nBoot int, seed int64) ([]float64, error) {
+	if nBoot <= 0 {
+		return nil, fmt.Errorf("nBoot must be positive, got %d", nBoot)
+	}
+	if len(data) < 2 {
+		return nil, fmt.Errorf("data must have at least 2 samples, got %d", len(data))
+	}
+
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // reproducible resampling
+	resample := make([][]float64, len(data))
+	residuals := make([]float64, nBoot)
+	for b := range residuals {
+		for i := range resample {
+			resample[i] = data[rng.Intn(len(data))]
+		}
+
+		hist, err := histogram.NewNDHistogram(resample, bins)
+		if err != nil {
+			return nil, fmt.Errorf("resample %d: failed to create histogram: %w", b, err)
+		}
+		result, err := DecomposeWithOptions(hist, opts)
+		if err != nil {
+			return nil, fmt.Errorf("resample %d: %w", b, err)
+		}
+		residuals[b] = CompletenessResidual(result)
+	}
+
+	return residuals, nil
+}
@@ -0,0 +1,149 @@
+package surd
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCompletenessResidual_Known(t *testing.T) {
+	// R + U + S = 0.3 + 0.5 + 0.15 = 0.95 against I(target; 0,1) = 0.9
+	result := &Result{
+		Redundant:   map[string]float64{"0,1": 0.3},
+		Unique:      map[string]float64{"0": 0.4, "1": 0.1},
+		Synergistic: map[string]float64{"0,1": 0.15},
+		MutualInfo:  map[string]float64{"0": 0.7, "1": 0.4, "0,1": 0.9},
+	}
+	if r := CompletenessResidual(result); math.Abs(r-0.05) > 1e-12 {
+		t.Errorf("residual = %v, want 0.05", r)
+	}
+
+	if r := CompletenessResidual(nil); r != 0 {
+		t.Errorf("nil result: residual = %v, want 0", r)
+	}
+}
+
+func TestCompletenessResidual_Decompose(t *testing.T) {
+	result, err := DecomposeFromData(generateNoisyCopy(5000, 12), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	if r := CompletenessResidual(result); math.Abs(r) > 1e-9 {
+		t.Errorf("residual = %v, want ~0", r)
+	}
+}
+
+func TestBootstrapCompletenessResidual(t *testing.T) {
+	data := generateNoisyCopy(2000, 13)
+	bins := []int{2, 2, 2}
+
+	residuals, err := BootstrapCompletenessResidual(data, bins, DefaultOptions(), 20, 1)
+	if err != nil {
+		t.Fatalf("BootstrapCompletenessResidual failed: %v", err)
+	}
+	if len(residuals) != 20 {
+		t.Fatalf("got %d residuals, want 20", len(residuals))
+	}
+	for b, r := range residuals {
+		if math.Abs(r) > 1e-9 {
+			t.Errorf("resample %d: residual = %v, want ~0", b, r)
+		}
+	}
+
+	// Prescreening the weak noise agent drops its (sub-threshold) information
+	opts := DefaultOptions()
+	opts.PrescreenThreshold = 0.01
+	pruned, err := BootstrapCompletenessResidual(data, bins, opts, 20, 1)
+	if err != nil {
+		t.Fatalf("BootstrapCompletenessResidual failed: %v", err)
+	}
+	for b, r := range pruned {
+		if r > 1e-9 || r < -0.01 {
+			t.Errorf("resample %d: prescreened residual = %v, want in [-0.01, 0]", b, r)
+		}
+	}
+
+	if _, err := BootstrapCompletenessResidual(data, bins, DefaultOptions(), 0, 1); err == nil {
+		t.Error("expected error for nBoot = 0")
+	}
+}
+
+func TestResult_Validate_Decompositions(t *testing.T) {
+	xor, err := DecomposeFromDiscreteData([][]int{{0, 0, 0}, {1, 0, 1}, {1, 1, 0}, {0, 1, 1}}, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromDiscreteData failed: %v", err)
+	}
+	noisy, err := DecomposeFromData(generateNoisyCopy(5000, 12), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	copied, err := DecomposeFromData(generateInformativeAndNoise(5000, 12), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	wide, err := Decompose(randomSystem(t, 5000, 4, 3, 12))
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	for name, result := range map[string]*Result{"xor": xor, "noisy copy": noisy, "copy": copied, "4 agents": wide} {
+		if err := result.Validate(1e-9); err != nil {
+			t.Errorf("%s: Validate failed: %v", name, err)
+		}
+	}
+}
+
+func TestResult_Validate_Violations(t *testing.T) {
+	// H(target) = 1 bit: R + U + S = 0.8 = I(target; 0,1), leak 0.2
+	valid := func() *Result {
+		return &Result{
+			Redundant:   map[string]float64{"0,1": 0.3},
+			Unique:      map[string]float64{"0": 0.3, "1": 0.1},
+			Synergistic: map[string]float64{"0,1": 0.1},
+			MutualInfo:  map[string]float64{"0": 0.6, "1": 0.4, "0,1": 0.8},
+			InfoLeak:    0.2,
+			LeakBits:    0.2,
+		}
+	}
+	if err := valid().Validate(1e-12); err != nil {
+		t.Fatalf("valid result: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *Result)
+		want   string
+	}{
+		{"negative unique", func(r *Result) { r.Unique["1"] = -0.1; r.Synergistic["0,1"] = 0.3 }, "Unique[1]"},
+		{"negative synergy", func(r *Result) { r.Synergistic["0,1"] = -0.01 }, "Synergistic[0,1]"},
+		{"leak above 1", func(r *Result) { r.InfoLeak = 1.5 }, "InfoLeak"},
+		{"negative leak bits", func(r *Result) { r.LeakBits = -0.2 }, "LeakBits"},
+		{"incomplete", func(r *Result) { r.Synergistic["0,1"] = 0.05 }, "R + U + S"},
+		{"inconsistent leak", func(r *Result) { r.InfoLeak = 0.5 }, "inconsistent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.modify(r)
+			err := r.Validate(1e-9)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want error mentioning %q", err, tt.want)
+			}
+		})
+	}
+
+	// Differences within tolerance are accepted
+	r := valid()
+	r.Synergistic["0,1"] = 0.1 + 1e-4
+	if err := r.Validate(1e-3); err != nil {
+		t.Errorf("within tolerance: %v", err)
+	}
+
+	if err := (*Result)(nil).Validate(1e-9); err == nil {
+		t.Error("expected error for nil result")
+	}
+	if err := valid().Validate(-1); err == nil {
+		t.Error("expected error for negative tolerance")
+	}
+}
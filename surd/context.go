@@ -0,0 +1,102 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// DecomposeByContext stratifies the data by a categorical context column and
+// decomposes each context level separately.
+//
+// data: matrix [samples x variables]
+// targetIdx: column index of the target
+// contextIdx: column index of the context (integer levels, e.g. regime or shift)
+// bins: number of bins for each column of data (the context entry is ignored)
+//
+// The agents are all columns other than the target and the context, in column
+// order; result keys refer to positions among them, as in DecomposeSubset.
+// Bin ranges are taken from the whole dataset, so the same value falls into the
+// same bin in every context and the results are directly comparable.
+//
+// Returns an error if a context value is not an integer or a level has fewer
+// than 2 samples.
+//
+// Example:
+//
+//	// Columns: [target, x, shift]
+//	byShift, err := DecomposeByContext(data, 0, 2, []int{8, 8, 0})
+//	fmt.Println(byShift[1].Unique["0"]) // x → target during shift 1
+func DecomposeByContext(data [][]float64, targetIdx int, contextIdx int, bins []int) (map[int]*Result, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is empty")
+	}
+	nvars := len(data[0])
+	if contextIdx < 0 || contextIdx >= nvars {
+		return nil, fmt.Errorf("contextIdx (%d) out of range [0, %d)", contextIdx, nvars)
+	}
+	if contextIdx == targetIdx {
+		return nil, fmt.Errorf("contextIdx equals targetIdx (%d)", targetIdx)
+	}
+
+	var agents []int
+	for j := 0; j < nvars; j++ {
+		if j != targetIdx && j != contextIdx {
+			agents = append(agents, j)
+		}
+	}
+	subData, subBins, err := selectColumns(data, targetIdx, agents, bins)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fixed ranges from the whole dataset
+	ncols := len(subBins)
+	colMin := make([]float64, ncols)
+	colMax := make([]float64, ncols)
+	for j := range colMin {
+		colMin[j] = math.Inf(1)
+		colMax[j] = math.Inf(-1)
+	}
+	for _, row := range subData {
+		for j, val := range row {
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				continue
+			}
+			colMin[j] = math.Min(colMin[j], val)
+			colMax[j] = math.Max(colMax[j], val)
+		}
+	}
+	for j := range colMin {
+		if math.IsInf(colMin[j], 0) {
+			return nil, fmt.Errorf("variable %d has no valid (non-NaN, non-Inf) values", j)
+		}
+	}
+
+	groups := make(map[int][][]float64)
+	for i, sample := range data {
+		c := sample[contextIdx]
+		if c != math.Trunc(c) || math.IsInf(c, 0) {
+			return nil, fmt.Errorf("sample %d: context value %v is not an integer", i, c)
+		}
+		level := int(c)
+		groups[level] = append(groups[level], subData[i])
+	}
+
+	results := make(map[int]*Result, len(groups))
+	for level, rows := range groups {
+		if len(rows) < 2 {
+			return nil, fmt.Errorf("context %d has %d samples, need at least 2", level, len(rows))
+		}
+		hist, err := histogram.NewNDHistogramWithRanges(rows, subBins, colMin, colMax)
+		if err != nil {
+			return nil, fmt.Errorf("context %d: failed to create histogram: %w", level, err)
+		}
+		if results[level], err = Decompose(hist); err != nil {
+			return nil, fmt.Errorf("context %d: %w", level, err)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,60 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDecomposeByContext(t *testing.T) {
+	// Columns: [target, x, context]; target copies x only in context 1
+	rng := rand.New(rand.NewSource(17)) //nolint:gosec // deterministic test data
+	n := 20000
+	data := make([][]float64, n)
+	for i := range data {
+		x := rng.Intn(4)
+		context := rng.Intn(2)
+		target := rng.Intn(4)
+		if context == 1 {
+			target = x
+		}
+		data[i] = []float64{float64(target), float64(x), float64(context)}
+	}
+
+	results, err := DecomposeByContext(data, 0, 2, []int{4, 4, 0})
+	if err != nil {
+		t.Fatalf("DecomposeByContext failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 context levels, got %d", len(results))
+	}
+
+	on, off := results[1], results[0]
+	t.Logf("context 1: U=%.3f leak=%.3f; context 0: U=%.3f leak=%.3f",
+		on.Unique["0"], on.InfoLeak, off.Unique["0"], off.InfoLeak)
+
+	if on.Unique["0"] < 1.9 || on.InfoLeak > 0.05 {
+		t.Errorf("context 1: expected ~2 bits from x and no leak, got U=%.3f leak=%.3f", on.Unique["0"], on.InfoLeak)
+	}
+	if off.Unique["0"] > 0.01 || off.InfoLeak < 0.99 {
+		t.Errorf("context 0: expected no causality and full leak, got U=%.3f leak=%.3f", off.Unique["0"], off.InfoLeak)
+	}
+}
+
+func TestDecomposeByContext_InvalidInput(t *testing.T) {
+	data := [][]float64{{0, 1, 0}, {1, 0, 0}, {1, 1, 0.5}}
+	if _, err := DecomposeByContext(data, 0, 2, []int{2, 2, 0}); err == nil {
+		t.Error("expected error for non-integer context")
+	}
+
+	data[2][2] = 1
+	if _, err := DecomposeByContext(data, 0, 2, []int{2, 2, 0}); err == nil {
+		t.Error("expected error for a context level with a single sample")
+	}
+
+	if _, err := DecomposeByContext(data, 0, 0, []int{2, 2, 0}); err == nil {
+		t.Error("expected error for contextIdx == targetIdx")
+	}
+	if _, err := DecomposeByContext(data, 0, 3, []int{2, 2, 0}); err == nil {
+		t.Error("expected error for contextIdx out of range")
+	}
+}
@@ -0,0 +1,94 @@
+package surd
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// generateOneWayCoupling returns [x, y] where y(t+1) = x(t) + noise and x is independent.
+func generateOneWayCoupling(n int, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	data := make([][]float64, n)
+	prevX := rng.Float64()
+	for i := 0; i < n; i++ {
+		x := rng.Float64()
+		y := prevX + 0.2*rng.NormFloat64()
+		data[i] = []float64{x, y}
+		prevX = x
+	}
+	return data
+}
+
+func TestCouplingMatrixWithSignificance_OneWay(t *testing.T) {
+	data := generateOneWayCoupling(2000, 7)
+
+	links, err := CouplingMatrixWithSignificance(data, 1, []int{8, 8}, 199, 42)
+	if err != nil {
+		t.Fatalf("CouplingMatrixWithSignificance failed: %v", err)
+	}
+	t.Logf("\n%s", links)
+
+	if !links.Significant(0, 1, 0.05) {
+		t.Errorf("true link x→y not significant: p = %.3f", links.PValue[0][1])
+	}
+	if links.Significant(1, 0, 0.05) {
+		t.Errorf("reverse link y→x significant: p = %.3f", links.PValue[1][0])
+	}
+	if links.PValue[0][1] != 0.005 {
+		t.Errorf("true link p-value = %.3f, want minimum 1/(1+199) = 0.005", links.PValue[0][1])
+	}
+
+	// Same seed → same p-values
+	again, err := CouplingMatrixWithSignificance(data, 1, []int{8, 8}, 199, 42)
+	if err != nil {
+		t.Fatalf("CouplingMatrixWithSignificance failed: %v", err)
+	}
+	if again.PValue[1][0] != links.PValue[1][0] {
+		t.Errorf("p-values not reproducible: %.3f vs %.3f", again.PValue[1][0], links.PValue[1][0])
+	}
+
+	// Row X0: the true link is marked, the diagonal is not
+	lines := strings.Split(links.String(), "\n")
+	if len(lines) < 3 || !strings.HasPrefix(lines[1], "X0") {
+		t.Fatalf("unexpected String() layout:\n%s", links)
+	}
+	if strings.Count(lines[1], "*") != 2 {
+		t.Errorf("row X0 = %q, want ** on the x→y link only", lines[1])
+	}
+	if strings.Contains(lines[2], "*") {
+		t.Errorf("row X1 = %q, want no significant links", lines[2])
+	}
+}
+
+func TestCouplingMatrixWithSignificance_ErrorCases(t *testing.T) {
+	data := generateOneWayCoupling(50, 1)
+
+	if _, err := CouplingMatrixWithSignificance(data, 1, []int{4, 4}, 0, 1); err == nil {
+		t.Error("expected error for zero surrogates")
+	}
+	if _, err := CouplingMatrixWithSignificance(data, 0, []int{4, 4}, 10, 1); err == nil {
+		t.Error("expected error for zero lag")
+	}
+	if _, err := CouplingMatrixWithSignificance(data, 1, []int{4}, 10, 1); err == nil {
+		t.Error("expected error for bins mismatch")
+	}
+}
+
+func TestSignificanceStars(t *testing.T) {
+	tests := []struct {
+		p    float64
+		want string
+	}{
+		{0.0005, "***"},
+		{0.005, "**"},
+		{0.03, "*"},
+		{0.05, ""},
+		{0.5, ""},
+	}
+	for _, tt := range tests {
+		if got := significanceStars(tt.p); got != tt.want {
+			t.Errorf("significanceStars(%v) = %q, want %q", tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package surd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+)
+
+func TestWriteComponentsCSV(t *testing.T) {
+	result := &Result{
+		Redundant:   map[string]float64{"0,1": 0.25},
+		Unique:      map[string]float64{"1": 0.5, "0": 0.125},
+		Synergistic: map[string]float64{"0,1": 1.0 / 3},
+		MutualInfo:  map[string]float64{"0,1": 1, "1": 0.75, "0": 0.375},
+		InfoLeak:    0.0625,
+	}
+
+	var buf bytes.Buffer
+	if err := WriteComponentsCSV(&buf, result); err != nil {
+		t.Fatalf("WriteComponentsCSV failed: %v", err)
+	}
+
+	want := `component_type,variable_key,value
+redundant,"0,1",0.25
+unique,0,0.125
+unique,1,0.5
+synergistic,"0,1",0.3333333333333333
+mutual_info,0,0.375
+mutual_info,"0,1",1
+mutual_info,1,0.75
+info_leak,,0.0625
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Parses back to the original values
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	for _, rec := range records[1:] {
+		v, err := strconv.ParseFloat(rec[2], 64)
+		if err != nil {
+			t.Fatalf("invalid value %q: %v", rec[2], err)
+		}
+		var orig float64
+		switch rec[0] {
+		case "redundant":
+			orig = result.Redundant[rec[1]]
+		case "unique":
+			orig = result.Unique[rec[1]]
+		case "synergistic":
+			orig = result.Synergistic[rec[1]]
+		case "mutual_info":
+			orig = result.MutualInfo[rec[1]]
+		case "info_leak":
+			orig = result.InfoLeak
+		}
+		if v != orig {
+			t.Errorf("%s[%s] = %v, want %v", rec[0], rec[1], v, orig)
+		}
+	}
+
+	if err := WriteComponentsCSV(&buf, nil); err == nil {
+		t.Error("expected error for nil result")
+	}
+}
@@ -0,0 +1,116 @@
+package surd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// DecomposeCSVStream performs SURD decomposition on CSV data read row by row,
+// without holding the rows in memory.
+//
+// r: CSV source with one sample per row and one variable per column
+// targetCol: column index of the target variable
+// bins: number of bins for each column (len(bins) == number of columns)
+// ranges: fixed [min, max] binning range for each column
+//
+// All other columns are agents, in column order; result keys refer to their
+// positions, as in DecomposeSubset. Because the data are read only once, the
+// ranges cannot be computed from them and must be supplied; values outside a
+// range fall into the first or last bin. The result equals binning the full
+// data with the same fixed ranges.
+//
+// A first row that does not parse as numbers is treated as a header and
+// skipped. Rows with NaN or Inf are skipped, as in DecomposeFromData. Any
+// other unparsable field is an error.
+//
+// Example:
+//
+//	f, _ := os.Open("huge.csv")
+//	defer f.Close()
+//	ranges := [][2]float64{{-5, 5}, {-5, 5}, {0, 100}}
+//	result, err := DecomposeCSVStream(f, 0, []int{16, 16, 16}, ranges)
+func DecomposeCSVStream(r io.Reader, targetCol int, bins []int, ranges [][2]float64) (*Result, error) {
+	ncols := len(bins)
+	if ncols < 2 {
+		return nil, fmt.Errorf("need at least 2 columns (target + agent), got %d bins", ncols)
+	}
+	if len(ranges) != ncols {
+		return nil, fmt.Errorf("ranges length (%d) must match bins length (%d)", len(ranges), ncols)
+	}
+	if targetCol < 0 || targetCol >= ncols {
+		return nil, fmt.Errorf("targetCol (%d) out of range [0, %d)", targetCol, ncols)
+	}
+
+	// Column order of the histogram: target first, then agents
+	columns := []int{targetCol}
+	for j := 0; j < ncols; j++ {
+		if j != targetCol {
+			columns = append(columns, j)
+		}
+	}
+	subBins := make([]int, ncols)
+	minVals := make([]float64, ncols)
+	maxVals := make([]float64, ncols)
+	for k, j := range columns {
+		subBins[k] = bins[j]
+		minVals[k], maxVals[k] = ranges[j][0], ranges[j][1]
+	}
+
+	builder, err := histogram.NewStreamBuilder(subBins, minVals, maxVals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create histogram: %w", err)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = ncols
+	cr.ReuseRecord = true
+	cr.TrimLeadingSpace = true
+
+	sample := make([]float64, ncols)
+	for row := 1; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		if err := parseCSVSample(record, columns, sample); err != nil {
+			if row == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if err := builder.Add(sample); err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+	}
+
+	if builder.Count() < 2 {
+		return nil, fmt.Errorf("need at least 2 valid samples, got %d", builder.Count())
+	}
+
+	hist, err := builder.Histogram()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create histogram: %w", err)
+	}
+	return Decompose(hist)
+}
+
+// parseCSVSample parses record into sample, reordered by columns.
+func parseCSVSample(record []string, columns []int, sample []float64) error {
+	for k, j := range columns {
+		v, err := strconv.ParseFloat(record[j], 64)
+		if err != nil {
+			return fmt.Errorf("column %d: %w", j, err)
+		}
+		sample[k] = v
+	}
+	return nil
+}
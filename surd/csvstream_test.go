@@ -0,0 +1,90 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// csvFixture writes a medium-size dataset with a header as CSV.
+// Columns: [x1, x2, target] with target = x1 + x2² + noise.
+func csvFixture(n int, seed int64) (string, [][]float64) {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	data := make([][]float64, n)
+	var sb strings.Builder
+	sb.WriteString("x1,x2,target\n")
+	for i := range data {
+		x1, x2 := rng.NormFloat64(), rng.Float64()*2-1
+		data[i] = []float64{x1, x2, x1 + x2*x2 + 0.3*rng.NormFloat64()}
+		for j, v := range data[i] {
+			if j > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String(), data
+}
+
+func TestDecomposeCSVStream_MatchesInMemory(t *testing.T) {
+	csvText, data := csvFixture(5000, 21)
+	bins := []int{6, 6, 6}
+
+	// Ranges equal to the data ranges reproduce the in-memory binning exactly
+	ranges := make([][2]float64, 3)
+	for j := range ranges {
+		ranges[j] = [2]float64{math.Inf(1), math.Inf(-1)}
+		for _, row := range data {
+			ranges[j][0] = math.Min(ranges[j][0], row[j])
+			ranges[j][1] = math.Max(ranges[j][1], row[j])
+		}
+	}
+
+	got, err := DecomposeCSVStream(strings.NewReader(csvText), 2, bins, ranges)
+	if err != nil {
+		t.Fatalf("DecomposeCSVStream failed: %v", err)
+	}
+	want, err := DecomposeSubset(data, 2, []int{0, 1}, bins)
+	if err != nil {
+		t.Fatalf("DecomposeSubset failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("streaming result differs from in-memory result:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDecomposeCSVStream_Errors(t *testing.T) {
+	ranges := [][2]float64{{0, 1}, {0, 1}}
+	bins := []int{2, 2}
+
+	cases := map[string]string{
+		"bad value":    "y,x\n0,1\n1,abc\n",
+		"ragged row":   "0,1\n1,0,1\n",
+		"too few rows": "y,x\n0,1\n",
+		"header only":  "y,x\n",
+		"empty":        "",
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecomposeCSVStream(strings.NewReader(input), 0, bins, ranges); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	valid := "0,1\n1,0\n1,1\n"
+	if _, err := DecomposeCSVStream(strings.NewReader(valid), 2, bins, ranges); err == nil {
+		t.Error("expected error for targetCol out of range")
+	}
+	if _, err := DecomposeCSVStream(strings.NewReader(valid), 0, bins, ranges[:1]); err == nil {
+		t.Error("expected error for ranges length mismatch")
+	}
+	if _, err := DecomposeCSVStream(strings.NewReader(valid), 0, bins, [][2]float64{{1, 0}, {0, 1}}); err == nil {
+		t.Error("expected error for inverted range")
+	}
+}
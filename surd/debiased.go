@@ -0,0 +1,101 @@
+package surd
+
+import (
+	"math"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+)
+
+// jackknifeSpecificMI returns the delete-one jackknife estimates of the
+// specific MI I_s(t; comb) for every target state t and of I(target; comb).
+//
+// Bin counts are recovered as round(p × nSamples). For an estimate θ computed
+// from N samples and θ_{-i} computed without sample i,
+//
+//	θ_JK = N θ - (N - 1) mean_i θ_{-i}
+//
+// which removes the O(1/N) bias of the plug-in estimate. Samples in the same
+// cell give the same θ_{-i}, so the mean runs over occupied cells weighted by
+// their counts, and each deletion only updates the terms of its agent state:
+// O(cells × target states) work per combination.
+func jackknifeSpecificMI(arr *entropy.NDArray, comb []int, nSamples int) ([]float64, float64) {
+	keepAxes := []int{0}
+	for _, c := range comb {
+		keepAxes = append(keepAxes, c+1)
+	}
+	pAS := marginalizeNDArray(arr, keepAxes)
+
+	// Counts on the [target x agent states] grid (row-major, target first)
+	ntarget := arr.Shape[0]
+	nAgent := len(pAS) / ntarget
+	counts := make([]float64, len(pAS))
+	nT := make([]float64, ntarget)
+	nA := make([]float64, nAgent)
+	total := 0.0
+	for idx, p := range pAS {
+		c := math.Round(p * float64(nSamples))
+		counts[idx] = c
+		nT[idx/nAgent] += c
+		nA[idx%nAgent] += c
+		total += c
+	}
+
+	specific := make([]float64, ntarget)
+	if total < 2 {
+		return specific, 0
+	}
+
+	// term = n log2(n / m), 0 for n = 0
+	term := func(n, m float64) float64 {
+		if n <= 0 {
+			return 0
+		}
+		return n * (entropy.Log2Safe(n) - entropy.Log2Safe(m))
+	}
+	// I_s(t) = S_t / n_t - log2(n_t / N), S_t = Σ_a n_ta log2(n_ta / n_a)
+	specificOf := func(s, nt, n float64) float64 {
+		if nt <= 0 {
+			return 0
+		}
+		return s/nt - (entropy.Log2Safe(nt) - entropy.Log2Safe(n))
+	}
+
+	sums := make([]float64, ntarget)
+	for idx, c := range counts {
+		sums[idx/nAgent] += term(c, nA[idx%nAgent])
+	}
+	plugin := make([]float64, ntarget)
+	pluginMI := 0.0
+	for t := range plugin {
+		plugin[t] = specificOf(sums[t], nT[t], total)
+		pluginMI += nT[t] / total * plugin[t]
+	}
+
+	// Mean of the delete-one estimates, over occupied cells weighted by count
+	meanDel := make([]float64, ntarget)
+	meanDelMI := 0.0
+	for idx, c := range counts {
+		if c <= 0 {
+			continue
+		}
+		tDel, aDel := idx/nAgent, idx%nAgent
+		w := c / total
+		for t := 0; t < ntarget; t++ {
+			nta := counts[t*nAgent+aDel]
+			ntaDel, ntDel := nta, nT[t]
+			if t == tDel {
+				ntaDel--
+				ntDel--
+			}
+			s := sums[t] - term(nta, nA[aDel]) + term(ntaDel, nA[aDel]-1)
+			del := specificOf(s, ntDel, total-1)
+			meanDel[t] += w * del
+			meanDelMI += w * ntDel / (total - 1) * del
+		}
+	}
+
+	for t := range specific {
+		specific[t] = total*plugin[t] - (total-1)*meanDel[t]
+	}
+	return specific, total*pluginMI - (total-1)*meanDelMI
+}
@@ -0,0 +1,115 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// pluginSpecificMI computes the plug-in specific MI of agent 0 from raw data.
+func pluginSpecificMI(t *testing.T, data [][]float64, bins []int) ([]float64, float64) {
+	t.Helper()
+	hist, err := histogram.NewNDHistogramWithRanges(data, bins, []float64{0, 0}, []float64{float64(bins[0] - 1), float64(bins[1] - 1)})
+	if err != nil {
+		t.Fatalf("histogram failed: %v", err)
+	}
+	arr := &entropy.NDArray{Data: hist.Probabilities(), Shape: hist.Shape()}
+	pTarget := marginalizeTo(arr, []int{0})
+	specific := computeSpecificMI(arr, []int{0}, pTarget, bins[0])
+	mi := 0.0
+	for i, s := range specific {
+		mi += pTarget[i] * s
+	}
+	return specific, mi
+}
+
+func TestJackknifeSpecificMI_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(31)) //nolint:gosec // deterministic test data
+	n := 60
+	bins := []int{3, 4}
+	data := make([][]float64, n)
+	for i := range data {
+		x := rng.Intn(4)
+		y := x % 3
+		if rng.Float64() < 0.4 {
+			y = rng.Intn(3)
+		}
+		data[i] = []float64{float64(y), float64(x)}
+	}
+
+	// Brute force: recompute without each sample
+	full, fullMI := pluginSpecificMI(t, data, bins)
+	meanDel := make([]float64, len(full))
+	meanDelMI := 0.0
+	for i := range data {
+		rest := append(append([][]float64{}, data[:i]...), data[i+1:]...)
+		del, delMI := pluginSpecificMI(t, rest, bins)
+		for k := range del {
+			meanDel[k] += del[k] / float64(n)
+		}
+		meanDelMI += delMI / float64(n)
+	}
+
+	hist, err := histogram.NewNDHistogramWithRanges(data, bins, []float64{0, 0}, []float64{2, 3})
+	if err != nil {
+		t.Fatalf("histogram failed: %v", err)
+	}
+	arr := &entropy.NDArray{Data: hist.Probabilities(), Shape: hist.Shape()}
+	specific, mi := jackknifeSpecificMI(arr, []int{0}, n)
+
+	N := float64(n)
+	for k := range specific {
+		want := N*full[k] - (N-1)*meanDel[k]
+		if math.Abs(specific[k]-want) > 1e-9 {
+			t.Errorf("specific[%d] = %.10f, brute force %.10f", k, specific[k], want)
+		}
+	}
+	if want := N*fullMI - (N-1)*meanDelMI; math.Abs(mi-want) > 1e-9 {
+		t.Errorf("MI = %.10f, brute force %.10f", mi, want)
+	}
+}
+
+func TestDecomposeWithOptions_DebiasedMI(t *testing.T) {
+	// Independent target and agents: the plug-in MI is biased upwards
+	rng := rand.New(rand.NewSource(32)) //nolint:gosec // deterministic test data
+	n := 300
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = []float64{rng.Float64(), rng.Float64(), rng.Float64()}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{4, 4, 4})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+
+	plugin, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	opts := DefaultOptions()
+	opts.DebiasedMI = true
+	opts.NSamples = n
+	debiased, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+
+	t.Logf("I(T;0,1): plug-in %.4f, debiased %.4f", plugin.MutualInfo["0,1"], debiased.MutualInfo["0,1"])
+	if math.Abs(debiased.MutualInfo["0,1"]) >= plugin.MutualInfo["0,1"]/2 {
+		t.Errorf("debiased MI %.4f not much closer to 0 than plug-in %.4f",
+			debiased.MutualInfo["0,1"], plugin.MutualInfo["0,1"])
+	}
+
+	opts.NSamples = 0
+	if _, err := DecomposeWithOptions(hist, opts); err == nil {
+		t.Error("expected error for DebiasedMI without NSamples")
+	}
+	opts.NSamples = n
+	opts.EntropyEstimator = GrassbergerEntropy
+	if _, err := DecomposeWithOptions(hist, opts); err == nil {
+		t.Error("expected error for DebiasedMI with GrassbergerEntropy")
+	}
+}
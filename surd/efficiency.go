@@ -0,0 +1,98 @@
+package surd
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// CaptureEfficiency returns the fraction of the target's information that is
+// explained by the observed agents:
+//
+//	efficiency = (R + U + S) / (R + U + S + LeakBits)
+//
+// where R, U and S are the sums of all redundant, unique and synergistic
+// components (bits). Values near 1 mean the agents explain the target; values
+// near 0 mean unobserved drivers dominate.
+//
+// Returns 0 for a nil result or when there is no information to explain
+// (constant target).
+//
+// Example:
+//
+//	result, _ := DecomposeFromData(data, []int{8, 8, 8})
+//	fmt.Printf("sources explain %.0f%% of the target\n", 100*CaptureEfficiency(result))
+func CaptureEfficiency(result *Result) float64 {
+	if result == nil {
+		return 0
+	}
+
+	captured := sumMap(result.Redundant) + sumMap(result.Unique) + sumMap(result.Synergistic)
+	total := captured + result.LeakBits
+	if total <= 0 {
+		return 0
+	}
+	return captured / total
+}
+
+// ExplainedFraction returns, for every agent combination in result.MutualInfo,
+// the fraction of the target entropy it explains:
+//
+//	fraction[c] = I(target; c) / H(target)
+//
+// hist must be the histogram result was computed from (target on axis 0); it
+// supplies H(target). Fractions lie in [0, 1] for plug-in estimates: a source
+// that determines the target explains 1, an unrelated source about 0. Keys
+// are combination keys as in Result. A constant target (H = 0) yields all
+// zeros.
+//
+// Example:
+//
+//	hist, _ := histogram.NewNDHistogram(data, []int{8, 8, 8})
+//	result, _ := Decompose(hist)
+//	fractions, err := ExplainedFraction(result, hist)
+//	fmt.Printf("agent 0 explains %.0f%%\n", 100*fractions["0"])
+func ExplainedFraction(result *Result, hist *histogram.NDHistogram) (map[string]float64, error) {
+	if result == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+	if hist == nil {
+		return nil, fmt.Errorf("histogram is nil")
+	}
+
+	shape := hist.Shape()
+	if len(shape) < 2 {
+		return nil, fmt.Errorf("histogram must have at least 2 dimensions (target + agents), got %d", len(shape))
+	}
+	nvars := len(shape) - 1
+	for key := range result.MutualInfo {
+		for _, idx := range KeyToIndices(key) {
+			if idx >= nvars {
+				return nil, fmt.Errorf("result key %q refers to agent %d, histogram has %d agents", key, idx, nvars)
+			}
+		}
+	}
+
+	arr := &entropy.NDArray{Data: hist.Probabilities(), Shape: shape}
+	hTarget := entropy.JointEntropy(arr, []int{0})
+
+	fractions := make(map[string]float64, len(result.MutualInfo))
+	for key, mi := range result.MutualInfo {
+		if hTarget > 0 {
+			fractions[key] = mi / hTarget
+		} else {
+			fractions[key] = 0
+		}
+	}
+	return fractions, nil
+}
+
+// sumMap returns the sum of the values of m.
+func sumMap(m map[string]float64) float64 {
+	total := 0.0
+	for _, v := range m {
+		total += v
+	}
+	return total
+}
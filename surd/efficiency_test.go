@@ -0,0 +1,102 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+func TestCaptureEfficiency(t *testing.T) {
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test data
+	n := 20000
+
+	// Fully observed: target = x1 XOR x2
+	observed := make([][]float64, n)
+	// Hidden dominant cause: target = h (4 states) except 10% of the time target = x; only x is observed
+	hidden := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		x1, x2 := rng.Intn(2), rng.Intn(2)
+		observed[i] = []float64{float64(x1 ^ x2), float64(x1), float64(x2)}
+
+		x, h := rng.Intn(2), rng.Intn(4)
+		target := h
+		if rng.Float64() < 0.1 {
+			target = x
+		}
+		hidden[i] = []float64{float64(target), float64(x)}
+	}
+
+	full, err := DecomposeFromData(observed, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	if eff := CaptureEfficiency(full); eff < 0.99 {
+		t.Errorf("fully observed system: efficiency = %.4f, want ~1", eff)
+	}
+
+	partial, err := DecomposeFromData(hidden, []int{4, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	eff := CaptureEfficiency(partial)
+	if eff > 0.2 {
+		t.Errorf("hidden dominant cause: efficiency = %.4f, want < 0.2", eff)
+	}
+	if partial.LeakBits <= 0 {
+		t.Errorf("hidden dominant cause: LeakBits = %.4f, want > 0", partial.LeakBits)
+	}
+
+	t.Logf("efficiency: observed=%.4f hidden=%.4f (leak %.4f bits)", CaptureEfficiency(full), eff, partial.LeakBits)
+}
+
+func TestCaptureEfficiency_Degenerate(t *testing.T) {
+	if eff := CaptureEfficiency(nil); eff != 0 {
+		t.Errorf("CaptureEfficiency(nil) = %v, want 0", eff)
+	}
+	if eff := CaptureEfficiency(&Result{}); eff != 0 {
+		t.Errorf("CaptureEfficiency(empty) = %v, want 0", eff)
+	}
+}
+
+func TestExplainedFraction(t *testing.T) {
+	rng := rand.New(rand.NewSource(8)) //nolint:gosec // deterministic test data
+	n := 20000
+
+	// target = x0 exactly, x1 is unrelated noise
+	data := make([][]float64, n)
+	for i := range data {
+		x0, x1 := rng.Intn(4), rng.Intn(4)
+		data[i] = []float64{float64(x0), float64(x0), float64(x1)}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{4, 4, 4})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	result, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	fractions, err := ExplainedFraction(result, hist)
+	if err != nil {
+		t.Fatalf("ExplainedFraction failed: %v", err)
+	}
+	if fractions["0"] < 0.999 || fractions["0,1"] < 0.999 {
+		t.Errorf("determining source: fractions %v, want ~1 for \"0\" and \"0,1\"", fractions)
+	}
+	if fractions["1"] > 0.01 {
+		t.Errorf("noise source: fraction = %.4f, want ~0", fractions["1"])
+	}
+
+	if _, err := ExplainedFraction(nil, hist); err == nil {
+		t.Error("expected error for nil result")
+	}
+	if _, err := ExplainedFraction(result, nil); err == nil {
+		t.Error("expected error for nil histogram")
+	}
+	mismatch := &Result{MutualInfo: map[string]float64{"5": 0.1}}
+	if _, err := ExplainedFraction(mismatch, hist); err == nil {
+		t.Error("expected error for agent index beyond histogram")
+	}
+}
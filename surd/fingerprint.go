@@ -0,0 +1,83 @@
+package surd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultFingerprintDecimals is the rounding precision used by Fingerprint.
+const DefaultFingerprintDecimals = 6
+
+// Fingerprint returns a stable hash of the Result for golden and equality tests.
+//
+// The hash covers all components, MutualInfo, SpecificMI, InfoLeak and
+// LeakBits. Values are rounded to DefaultFingerprintDecimals decimals and map
+// keys are sorted, so the fingerprint does not depend on map iteration order
+// or on trivial floating-point noise.
+func (r *Result) Fingerprint() string {
+	return r.FingerprintWithPrecision(DefaultFingerprintDecimals)
+}
+
+// FingerprintWithPrecision is like Fingerprint but rounds values to the given
+// number of decimals. Lower precision absorbs more numerical noise.
+func (r *Result) FingerprintWithPrecision(decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	var sb strings.Builder
+	writeFingerprintMap(&sb, "R", r.Redundant, decimals)
+	writeFingerprintMap(&sb, "U", r.Unique, decimals)
+	writeFingerprintMap(&sb, "S", r.Synergistic, decimals)
+	writeFingerprintMap(&sb, "MI", r.MutualInfo, decimals)
+	writeFingerprintSpecific(&sb, r.SpecificMI, decimals)
+	fmt.Fprintf(&sb, "leak=%s\n", formatRounded(r.InfoLeak, decimals))
+	fmt.Fprintf(&sb, "leak_bits=%s\n", formatRounded(r.LeakBits, decimals))
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFingerprintMap writes "name[key]=value" lines in sorted key order.
+func writeFingerprintMap(sb *strings.Builder, name string, values map[string]float64, decimals int) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(sb, "%s[%s]=%s\n", name, key, formatRounded(values[key], decimals))
+	}
+}
+
+// writeFingerprintSpecific writes "SMI[key][t]=value" lines in sorted key order.
+// A nil SpecificMI writes nothing, so results decomposed without
+// Options.KeepSpecificMI keep their fingerprints.
+func writeFingerprintSpecific(sb *strings.Builder, values map[string][]float64, decimals int) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for t, v := range values[key] {
+			fmt.Fprintf(sb, "SMI[%s][%d]=%s\n", key, t, formatRounded(v, decimals))
+		}
+	}
+}
+
+// formatRounded rounds v to the given decimals and formats it canonically.
+func formatRounded(v float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	rounded := math.Round(v*scale) / scale
+	if rounded == 0 {
+		rounded = 0 // normalize -0
+	}
+	return fmt.Sprintf("%.*f", decimals, rounded)
+}
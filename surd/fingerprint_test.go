@@ -0,0 +1,105 @@
+package surd
+
+import (
+	"testing"
+)
+
+func TestFingerprint_OrderIndependent(t *testing.T) {
+	a := &Result{
+		Redundant:   map[string]float64{},
+		Unique:      map[string]float64{},
+		Synergistic: map[string]float64{},
+		MutualInfo:  map[string]float64{},
+		InfoLeak:    0.1,
+	}
+	a.Unique["0"] = 0.3
+	a.Unique["1"] = 0.2
+	a.Unique["2"] = 0.0
+	a.Redundant["0,1"] = 0.15
+	a.Redundant["0,1,2"] = 0.05
+	a.Synergistic["1,2"] = 0.25
+	a.Synergistic["0,2"] = 0.01
+	a.MutualInfo["0"] = 0.5
+	a.MutualInfo["0,1"] = 0.8
+
+	// Same values, reversed insertion order
+	b := &Result{
+		Redundant:   map[string]float64{},
+		Unique:      map[string]float64{},
+		Synergistic: map[string]float64{},
+		MutualInfo:  map[string]float64{},
+		InfoLeak:    0.1,
+	}
+	b.MutualInfo["0,1"] = 0.8
+	b.MutualInfo["0"] = 0.5
+	b.Synergistic["0,2"] = 0.01
+	b.Synergistic["1,2"] = 0.25
+	b.Redundant["0,1,2"] = 0.05
+	b.Redundant["0,1"] = 0.15
+	b.Unique["2"] = 0.0
+	b.Unique["1"] = 0.2
+	b.Unique["0"] = 0.3
+
+	for i := 0; i < 10; i++ {
+		if a.Fingerprint() != b.Fingerprint() {
+			t.Fatalf("fingerprints differ: %s vs %s", a.Fingerprint(), b.Fingerprint())
+		}
+	}
+}
+
+func TestFingerprint_Precision(t *testing.T) {
+	a := &Result{Unique: map[string]float64{"0": 0.123456789}}
+	b := &Result{Unique: map[string]float64{"0": 0.123456781}}
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("fingerprints should match at default precision")
+	}
+	if a.FingerprintWithPrecision(9) == b.FingerprintWithPrecision(9) {
+		t.Error("fingerprints should differ at 9 decimals")
+	}
+
+	// Different values must produce different fingerprints
+	c := &Result{Unique: map[string]float64{"0": 0.2}}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Error("fingerprints should differ for different values")
+	}
+
+	// LeakBits is part of the fingerprint
+	f := &Result{InfoLeak: 0.5, LeakBits: 0.5}
+	g := &Result{InfoLeak: 0.5, LeakBits: 1}
+	if f.Fingerprint() == g.Fingerprint() {
+		t.Error("fingerprints should differ for different LeakBits")
+	}
+
+	// SpecificMI is part of the fingerprint
+	h := &Result{SpecificMI: map[string][]float64{"0": {0.1, 0.9}}}
+	k := &Result{SpecificMI: map[string][]float64{"0": {0.9, 0.1}}}
+	if h.Fingerprint() == k.Fingerprint() {
+		t.Error("fingerprints should differ for different SpecificMI")
+	}
+
+	// -0 and 0 are equivalent
+	d := &Result{InfoLeak: -1e-12}
+	e := &Result{InfoLeak: 0}
+	if d.Fingerprint() != e.Fingerprint() {
+		t.Error("fingerprints should match for -0 and 0 after rounding")
+	}
+}
+
+func TestFingerprint_DecomposeDeterministic(t *testing.T) {
+	data := generateInformativeAndNoise(2000, 11)
+	bins := []int{2, 2, 2}
+
+	r1, err := DecomposeFromData(data, bins)
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	r2, err := DecomposeFromData(data, bins)
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	if r1.Fingerprint() != r2.Fingerprint() {
+		t.Error("repeated decompositions should have identical fingerprints")
+	}
+}
@@ -0,0 +1,138 @@
+package surd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FlatResultVersion is the layout version written by ToFlatResult.
+// FromFlatResult rejects newer versions.
+const FlatResultVersion = 1
+
+// FlatEntry is one component value of a FlatResult.
+type FlatEntry struct {
+	// Type is ComponentRedundant, ComponentUnique, ComponentSynergistic or
+	// ComponentMutualInfo.
+	Type string
+
+	// Key is the agent combination, e.g. "0" or "0,2".
+	Key string
+
+	// Value is the component value in bits.
+	Value float64
+}
+
+// FlatResult is a map-free, versioned representation of Result, meant as the
+// wire format for RPC services (e.g. a protobuf message with a repeated entry
+// field). Unlike Result it has a deterministic layout.
+type FlatResult struct {
+	// Version is the layout version, FlatResultVersion when written by ToFlatResult.
+	Version int
+
+	// Entries lists the redundant, unique, synergistic and mutual_info values,
+	// grouped by type in that order and sorted by key within each type.
+	Entries []FlatEntry
+
+	// InfoLeak is Result.InfoLeak.
+	InfoLeak float64
+
+	// LeakBits is Result.LeakBits.
+	LeakBits float64
+
+	// TotalMutualInfo is Result.TotalMutualInfo(). It is derived from the
+	// entries and ignored by FromFlatResult.
+	TotalMutualInfo float64
+
+	// NumVariables is the number of agents: one more than the largest agent
+	// index in any key.
+	NumVariables int
+}
+
+// ToFlatResult converts result to its flat representation. It returns nil
+// for a nil result. Result.SpecificMI is not part of the flat layout and is
+// dropped, so it does not survive a round trip through FromFlatResult.
+//
+// Example:
+//
+//	flat := ToFlatResult(result)
+//	for _, e := range flat.Entries {
+//	    msg.Entries = append(msg.Entries, &pb.Entry{Type: e.Type, Key: e.Key, Value: e.Value})
+//	}
+func ToFlatResult(result *Result) *FlatResult {
+	if result == nil {
+		return nil
+	}
+
+	flat := &FlatResult{
+		Version:         FlatResultVersion,
+		InfoLeak:        result.InfoLeak,
+		LeakBits:        result.LeakBits,
+		TotalMutualInfo: result.TotalMutualInfo(),
+	}
+	for _, c := range []struct {
+		typ    string
+		values map[string]float64
+	}{
+		{ComponentRedundant, result.Redundant},
+		{ComponentUnique, result.Unique},
+		{ComponentSynergistic, result.Synergistic},
+		{ComponentMutualInfo, result.MutualInfo},
+	} {
+		keys := make([]string, 0, len(c.values))
+		for key := range c.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flat.Entries = append(flat.Entries, FlatEntry{Type: c.typ, Key: key, Value: c.values[key]})
+			for _, idx := range KeyToIndices(key) {
+				flat.NumVariables = max(flat.NumVariables, idx+1)
+			}
+		}
+	}
+	return flat
+}
+
+// FromFlatResult converts a FlatResult back to a Result. The returned
+// SpecificMI is always nil.
+//
+// It returns an error for a nil flat, a version newer than
+// FlatResultVersion, an unknown entry type, a malformed key or a key that
+// appears twice for the same type.
+func FromFlatResult(flat *FlatResult) (*Result, error) {
+	if flat == nil {
+		return nil, fmt.Errorf("flat result is nil")
+	}
+	if flat.Version < 1 || flat.Version > FlatResultVersion {
+		return nil, fmt.Errorf("unsupported flat result version %d (supported: 1..%d)", flat.Version, FlatResultVersion)
+	}
+
+	result := &Result{
+		Redundant:   make(map[string]float64),
+		Unique:      make(map[string]float64),
+		Synergistic: make(map[string]float64),
+		MutualInfo:  make(map[string]float64),
+		InfoLeak:    flat.InfoLeak,
+		LeakBits:    flat.LeakBits,
+	}
+	maps := map[string]map[string]float64{
+		ComponentRedundant:   result.Redundant,
+		ComponentUnique:      result.Unique,
+		ComponentSynergistic: result.Synergistic,
+		ComponentMutualInfo:  result.MutualInfo,
+	}
+	for i, e := range flat.Entries {
+		m, ok := maps[e.Type]
+		if !ok {
+			return nil, fmt.Errorf("entry %d: unknown type %q", i, e.Type)
+		}
+		if len(KeyToIndices(e.Key)) == 0 {
+			return nil, fmt.Errorf("entry %d: invalid key %q", i, e.Key)
+		}
+		if _, dup := m[e.Key]; dup {
+			return nil, fmt.Errorf("entry %d: duplicate %s key %q", i, e.Type, e.Key)
+		}
+		m[e.Key] = e.Value
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package surd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFlatResult_RoundTrip(t *testing.T) {
+	result, err := DecomposeFromData(generateNoisyCopy(2000, 8), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	flat := ToFlatResult(result)
+	if flat.Version != FlatResultVersion {
+		t.Errorf("expected version %d, got %d", FlatResultVersion, flat.Version)
+	}
+	if flat.NumVariables != 2 {
+		t.Errorf("expected 2 variables, got %d", flat.NumVariables)
+	}
+	if flat.TotalMutualInfo != result.TotalMutualInfo() {
+		t.Errorf("expected TotalMutualInfo %v, got %v", result.TotalMutualInfo(), flat.TotalMutualInfo)
+	}
+	want := len(result.Redundant) + len(result.Unique) + len(result.Synergistic) + len(result.MutualInfo)
+	if len(flat.Entries) != want {
+		t.Errorf("expected %d entries, got %d", want, len(flat.Entries))
+	}
+
+	decoded, err := FromFlatResult(flat)
+	if err != nil {
+		t.Fatalf("FromFlatResult failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, decoded) {
+		t.Errorf("round trip changed the result:\n got %+v\nwant %+v", decoded, result)
+	}
+}
+
+func TestFlatResult_Order(t *testing.T) {
+	result := &Result{
+		Redundant:   map[string]float64{"0,1": 0.1},
+		Unique:      map[string]float64{"2": 0.3, "0": 0.5},
+		Synergistic: map[string]float64{},
+		MutualInfo:  map[string]float64{"1": 0.4},
+	}
+
+	var got []string
+	for _, e := range ToFlatResult(result).Entries {
+		got = append(got, e.Type+":"+e.Key)
+	}
+	want := []string{"redundant:0,1", "unique:0", "unique:2", "mutual_info:1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected entries %v, got %v", want, got)
+	}
+	if n := ToFlatResult(result).NumVariables; n != 3 {
+		t.Errorf("expected 3 variables, got %d", n)
+	}
+	if ToFlatResult(nil) != nil {
+		t.Error("expected nil for nil result")
+	}
+}
+
+func TestFromFlatResult_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		flat *FlatResult
+		want string
+	}{
+		{"nil", nil, "nil"},
+		{"future version", &FlatResult{Version: FlatResultVersion + 1}, "version"},
+		{"zero version", &FlatResult{}, "version"},
+		{"unknown type", &FlatResult{Version: 1, Entries: []FlatEntry{{Type: "leak", Key: "0"}}}, "unknown type"},
+		{"bad key", &FlatResult{Version: 1, Entries: []FlatEntry{{Type: ComponentUnique, Key: "a"}}}, "invalid key"},
+		{"duplicate", &FlatResult{Version: 1, Entries: []FlatEntry{
+			{Type: ComponentUnique, Key: "0", Value: 1},
+			{Type: ComponentUnique, Key: "0", Value: 2},
+		}}, "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromFlatResult(tt.flat)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+func TestDecompose_GrassbergerEntropy(t *testing.T) {
+	// Target independent of both agents, 60 samples on a 4x4x4 grid:
+	// the plug-in MI is strongly biased upwards, the corrected MI much less so
+	rng := rand.New(rand.NewSource(3)) //nolint:gosec // deterministic test data
+	n := 60
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = []float64{float64(rng.Intn(4)), float64(rng.Intn(4)), float64(rng.Intn(4))}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{4, 4, 4})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+
+	plugin, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.EntropyEstimator = GrassbergerEntropy
+	opts.NSamples = n
+	corrected, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+
+	t.Logf("I(T; 0,1): plug-in %.3f, Grassberger %.3f bits", plugin.MutualInfo["0,1"], corrected.MutualInfo["0,1"])
+	t.Logf("InfoLeak:  plug-in %.3f, Grassberger %.3f", plugin.InfoLeak, corrected.InfoLeak)
+
+	for _, key := range []string{"0", "1", "0,1"} {
+		p, c := plugin.MutualInfo[key], corrected.MutualInfo[key]
+		if c >= p {
+			t.Errorf("%s: corrected MI %.4f not below plug-in %.4f", key, c, p)
+		}
+	}
+	// True leak is 1 (independent target); correction moves it closer
+	if corrected.InfoLeak <= plugin.InfoLeak {
+		t.Errorf("corrected leak %.4f not above plug-in %.4f", corrected.InfoLeak, plugin.InfoLeak)
+	}
+
+	// R, U, S are unchanged
+	if corrected.Synergistic["0,1"] != plugin.Synergistic["0,1"] || corrected.Unique["0"] != plugin.Unique["0"] {
+		t.Error("components changed with the entropy estimator")
+	}
+
+	opts.NSamples = 0
+	if _, err := DecomposeWithOptions(hist, opts); err == nil {
+		t.Error("expected error for GrassbergerEntropy without NSamples")
+	}
+}
@@ -0,0 +1,63 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// Data generators shared by the test files of this package.
+
+// generateNoisyCopy returns [target, x, noise] where target = x flipped with probability 0.2.
+func generateNoisyCopy(n int, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	data := make([][]float64, n)
+	for i := range data {
+		x := rng.Intn(2)
+		target := x
+		if rng.Float64() < 0.2 {
+			target = 1 - x
+		}
+		data[i] = []float64{float64(target), float64(x), float64(rng.Intn(2))}
+	}
+	return data
+}
+
+// generateInformativeAndNoise returns [target, informative, noise] binary samples
+// where target = informative.
+func generateInformativeAndNoise(n int, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	data := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		informative := float64(rng.Intn(2))
+		noise := float64(rng.Intn(2))
+		data[i] = []float64{informative, informative, noise}
+	}
+	return data
+}
+
+// randomSystem returns a histogram over a target driven by the first three of
+// nvars random agents, for the worker tests and benchmarks.
+func randomSystem(tb testing.TB, n, nvars, bins int, seed int64) *histogram.NDHistogram {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	data := make([][]float64, n)
+	for i := range data {
+		row := make([]float64, nvars+1)
+		for j := 1; j <= nvars; j++ {
+			row[j] = rng.Float64()
+		}
+		row[0] = row[1] + row[2]*row[3] + 0.2*rng.Float64()
+		data[i] = row
+	}
+	binsArray := make([]int, nvars+1)
+	for i := range binsArray {
+		binsArray[i] = bins
+	}
+	hist, err := histogram.NewNDHistogram(data, binsArray)
+	if err != nil {
+		tb.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	return hist
+}
@@ -0,0 +1,83 @@
+package surd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// resultJSON is the on-disk layout of Result.
+type resultJSON struct {
+	Redundant   map[string]float64 `json:"redundant"`
+	Unique      map[string]float64 `json:"unique"`
+	Synergistic map[string]float64 `json:"synergistic"`
+	MutualInfo  map[string]float64 `json:"mutual_info"`
+	InfoLeak    float64            `json:"info_leak"`
+	LeakBits    float64            `json:"leak_bits"`
+
+	SpecificMI map[string][]float64 `json:"specific_mi,omitempty"`
+}
+
+// MarshalJSON encodes the Result as a JSON object with the fields redundant,
+// unique, synergistic, mutual_info, info_leak and leak_bits, plus
+// specific_mi when SpecificMI is set.
+//
+// Map keys are written in sorted order and values in their shortest exact
+// form, so the output is deterministic and round-trips through UnmarshalJSON
+// without loss. NaN and ±Inf values cannot be encoded and return an error.
+//
+// Example:
+//
+//	data, _ := json.MarshalIndent(result, "", "  ")
+//	os.WriteFile("surd.json", data, 0o644)
+func (r *Result) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(resultJSON{
+		Redundant:   r.Redundant,
+		Unique:      r.Unique,
+		Synergistic: r.Synergistic,
+		MutualInfo:  r.MutualInfo,
+		InfoLeak:    r.InfoLeak,
+		LeakBits:    r.LeakBits,
+		SpecificMI:  r.SpecificMI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("surd: failed to encode result: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalJSON decodes a Result written by MarshalJSON.
+//
+// Unknown fields are rejected so that typos and files of another format are
+// reported instead of silently producing an empty Result. Missing maps decode
+// as empty maps.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var raw resultJSON
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return fmt.Errorf("surd: invalid result JSON: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("surd: invalid result JSON: unexpected data after object")
+	}
+
+	*r = Result{
+		Redundant:   nonNilMap(raw.Redundant),
+		Unique:      nonNilMap(raw.Unique),
+		Synergistic: nonNilMap(raw.Synergistic),
+		MutualInfo:  nonNilMap(raw.MutualInfo),
+		InfoLeak:    raw.InfoLeak,
+		LeakBits:    raw.LeakBits,
+		SpecificMI:  raw.SpecificMI,
+	}
+	return nil
+}
+
+// nonNilMap returns m, or an empty map if m is nil.
+func nonNilMap(m map[string]float64) map[string]float64 {
+	if m == nil {
+		return make(map[string]float64)
+	}
+	return m
+}
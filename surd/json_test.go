@@ -0,0 +1,120 @@
+package surd
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+func TestResultJSON_RoundTrip(t *testing.T) {
+	result, err := DecomposeFromData(generateNoisyCopy(2000, 8), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded Result
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(*result, decoded) {
+		t.Errorf("round trip changed the result:\n got %+v\nwant %+v", decoded, *result)
+	}
+}
+
+func TestResultJSON_RoundTripSpecificMI(t *testing.T) {
+	opts := DefaultOptions()
+	opts.KeepSpecificMI = true
+	hist, err := histogram.NewNDHistogram(generateNoisyCopy(2000, 8), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	result, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"specific_mi"`) {
+		t.Errorf("specific_mi missing from %s", data)
+	}
+
+	var decoded Result
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(*result, decoded) {
+		t.Errorf("round trip changed the result:\n got %+v\nwant %+v", decoded, *result)
+	}
+}
+
+func TestResultJSON_Deterministic(t *testing.T) {
+	result := &Result{
+		Redundant:   map[string]float64{"0,1": 0.1, "0,2": 0.2, "1,2": 0.3},
+		Unique:      map[string]float64{"2": 1.0 / 3, "0": 0.5, "1": 0.25},
+		Synergistic: map[string]float64{"1,2": 0.05, "0,1": 0.01},
+		MutualInfo:  map[string]float64{"1": 0.4, "0": 0.6},
+		InfoLeak:    0.125,
+		LeakBits:    0.0625,
+	}
+
+	first, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("output not deterministic:\n%s\n%s", first, again)
+		}
+	}
+
+	want := `"unique":{"0":0.5,"1":0.25,"2":0.3333333333333333}`
+	if !strings.Contains(string(first), want) {
+		t.Errorf("expected sorted keys %s in %s", want, first)
+	}
+	if !strings.Contains(string(first), `"info_leak":0.125`) || !strings.Contains(string(first), `"mutual_info"`) {
+		t.Errorf("missing info_leak or mutual_info in %s", first)
+	}
+}
+
+func TestResultJSON_Errors(t *testing.T) {
+	cases := map[string]string{
+		"malformed":     `{"unique": {"0": 0.5`,
+		"wrong type":    `{"unique": {"0": "high"}}`,
+		"unknown field": `{"uniq": {"0": 0.5}}`,
+		"not an object": `[1, 2, 3]`,
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Call the method directly: json.Unmarshal rejects invalid syntax itself
+			var r Result
+			err := r.UnmarshalJSON([]byte(input))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), "surd: invalid result JSON") {
+				t.Errorf("error not descriptive: %v", err)
+			}
+		})
+	}
+
+	nan := &Result{Unique: map[string]float64{"0": math.NaN()}}
+	if _, err := json.Marshal(nan); err == nil {
+		t.Error("expected error for NaN value")
+	}
+}
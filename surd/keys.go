@@ -0,0 +1,52 @@
+package surd
+
+import (
+	"strconv"
+	"strings"
+)
+
+// KeyToIndices parses a Result map key such as "0,1,2" into agent indices.
+//
+// Keys are comma-separated, non-negative, 0-based agent indices without
+// spaces. The empty key yields an empty slice. Malformed keys (empty parts,
+// non-numeric or negative values, e.g. "0,,1", "a", "-1", " 0") yield nil.
+//
+// Example:
+//
+//	for key, v := range result.Synergistic {
+//	    agents := KeyToIndices(key) // "0,2" -> [0 2]
+//	    ...
+//	}
+func KeyToIndices(key string) []int {
+	if key == "" {
+		return []int{}
+	}
+
+	parts := strings.Split(key, ",")
+	indices := make([]int, len(parts))
+	for i, p := range parts {
+		// Atoi accepts a leading sign; keys never have one
+		if p == "" || p[0] < '0' || p[0] > '9' {
+			return nil
+		}
+		val, err := strconv.Atoi(p)
+		if err != nil {
+			return nil
+		}
+		indices[i] = val
+	}
+	return indices
+}
+
+// IndicesToKey formats agent indices as a Result map key, e.g. [0 2] -> "0,2".
+//
+// Indices are written in the given order; Result keys list them in increasing
+// order. Returns "" for an empty slice or if any index is negative.
+func IndicesToKey(idx []int) string {
+	for _, i := range idx {
+		if i < 0 {
+			return ""
+		}
+	}
+	return combToKey(idx)
+}
@@ -0,0 +1,63 @@
+package surd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIndicesToKey(t *testing.T) {
+	tests := []struct {
+		idx      []int
+		expected string
+	}{
+		{[]int{0}, "0"},
+		{[]int{0, 1}, "0,1"},
+		{[]int{0, 2, 5}, "0,2,5"},
+		{[]int{}, ""},
+		{[]int{0, -1}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := IndicesToKey(tt.idx); got != tt.expected {
+			t.Errorf("IndicesToKey(%v) = %q, want %q", tt.idx, got, tt.expected)
+		}
+	}
+}
+
+func TestKeyToIndices(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected []int
+	}{
+		{"0", []int{0}},
+		{"0,1", []int{0, 1}},
+		{"0,2,5", []int{0, 2, 5}},
+		{"", []int{}},
+		// Malformed keys
+		{"a", nil},
+		{"0,,1", nil},
+		{"0,", nil},
+		{"-1", nil},
+		{"+1", nil},
+		{"0, 1", nil},
+	}
+
+	for _, tt := range tests {
+		if got := KeyToIndices(tt.key); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("KeyToIndices(%q) = %#v, want %#v", tt.key, got, tt.expected)
+		}
+	}
+}
+
+func TestKeyToIndices_RoundTrip(t *testing.T) {
+	result, err := DecomposeFromData(generateNoisyCopy(1000, 3), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	for key := range result.MutualInfo {
+		if got := IndicesToKey(KeyToIndices(key)); got != key {
+			t.Errorf("round trip of %q gave %q", key, got)
+		}
+	}
+}
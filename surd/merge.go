@@ -0,0 +1,100 @@
+package surd
+
+import (
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// MergeSparseBins merges target bins whose marginal probability is below
+// minProb into adjacent bins.
+//
+// When the target is binned finer than the data resolution, many target states
+// are nearly empty and carry noisy conditional distributions that fragment
+// the causality estimates. Merging them reduces this noise.
+//
+// The sparsest target bin is merged first, into its neighbor with the smaller
+// probability (the only neighbor at the edges); this repeats until every
+// target bin has probability >= minProb or a single bin remains. Agent axes
+// are unchanged.
+//
+// NOTE: this changes the target alphabet. The returned histogram has fewer
+// target states, so H(target) and all components are in terms of the merged
+// target and are not directly comparable with the unmerged histogram.
+//
+// Returns hist unchanged if minProb <= 0 or nothing needs merging, and nil if
+// hist is nil.
+//
+// Example:
+//
+//	hist, _ := histogram.NewNDHistogram(Y, []int{50, 8, 8})
+//	merged := MergeSparseBins(hist, 0.01)
+//	result, err := Decompose(merged)
+func MergeSparseBins(hist *histogram.NDHistogram, minProb float64) *histogram.NDHistogram {
+	if hist == nil || minProb <= 0 {
+		return hist
+	}
+
+	shape := hist.Shape()
+	probs := hist.Probabilities()
+	stride := len(probs) / shape[0]
+
+	// One block per target state (axis 0 is outermost in row-major order)
+	blocks := make([][]float64, shape[0])
+	mass := make([]float64, shape[0])
+	for t := range blocks {
+		blocks[t] = probs[t*stride : (t+1)*stride]
+		mass[t] = sumValues(blocks[t])
+	}
+
+	merged := false
+	for len(blocks) > 1 {
+		sparsest := 0
+		for t := 1; t < len(mass); t++ {
+			if mass[t] < mass[sparsest] {
+				sparsest = t
+			}
+		}
+		if mass[sparsest] >= minProb {
+			break
+		}
+
+		neighbor := sparsest - 1
+		if sparsest == 0 || (sparsest < len(blocks)-1 && mass[sparsest+1] < mass[sparsest-1]) {
+			neighbor = sparsest + 1
+		}
+
+		for i, p := range blocks[sparsest] {
+			blocks[neighbor][i] += p
+		}
+		mass[neighbor] += mass[sparsest]
+
+		blocks = append(blocks[:sparsest], blocks[sparsest+1:]...)
+		mass = append(mass[:sparsest], mass[sparsest+1:]...)
+		merged = true
+	}
+
+	if !merged {
+		return hist
+	}
+
+	mergedProbs := make([]float64, 0, len(blocks)*stride)
+	for _, block := range blocks {
+		mergedProbs = append(mergedProbs, block...)
+	}
+	shape[0] = len(blocks)
+
+	result, err := histogram.NewNDHistogramFromProbabilities(mergedProbs, shape)
+	if err != nil {
+		// Unreachable: merging preserves a valid distribution
+		return hist
+	}
+	return result
+}
+
+// sumValues returns the sum of a slice.
+func sumValues(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,90 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// uniqueShare returns Unique["0"] as a fraction of R + U + S.
+func uniqueShare(r *Result) float64 {
+	total := sumMap(r.Redundant) + sumMap(r.Unique) + sumMap(r.Synergistic)
+	return r.Unique["0"] / total
+}
+
+func TestMergeSparseBins_OverBinnedTarget(t *testing.T) {
+	// target ≈ x1 (4 levels) with rare outliers, binned into 50 target states:
+	// most target bins are nearly empty and produce spurious synergy with x2
+	rng := rand.New(rand.NewSource(5)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 2000)
+	for i := range data {
+		x1 := float64(rng.Intn(4))
+		x2 := float64(rng.Intn(4))
+		y := x1 + 0.02*rng.NormFloat64()
+		if rng.Float64() < 0.03 {
+			y = 3 * rng.Float64()
+		}
+		data[i] = []float64{y, x1, x2}
+	}
+
+	hist, err := histogram.NewNDHistogram(data, []int{50, 4, 4})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	merged := MergeSparseBins(hist, 0.01)
+
+	shape := merged.Shape()
+	if shape[0] >= 50 || shape[1] != 4 || shape[2] != 4 {
+		t.Errorf("merged shape = %v, want fewer target bins and unchanged agents", shape)
+	}
+	total := 0.0
+	for _, p := range merged.Probabilities() {
+		total += p
+	}
+	if math.Abs(total-1.0) > 1e-10 {
+		t.Errorf("merged probabilities sum to %v, want 1.0", total)
+	}
+
+	raw, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose(raw) failed: %v", err)
+	}
+	clean, err := Decompose(merged)
+	if err != nil {
+		t.Fatalf("Decompose(merged) failed: %v", err)
+	}
+
+	t.Logf("raw:    target bins=50  U0 share=%.3f  S=%.4f", uniqueShare(raw), sumMap(raw.Synergistic))
+	t.Logf("merged: target bins=%d  U0 share=%.3f  S=%.4f", shape[0], uniqueShare(clean), sumMap(clean.Synergistic))
+
+	if uniqueShare(clean) <= uniqueShare(raw) {
+		t.Errorf("unique share after merging = %.3f, want > %.3f", uniqueShare(clean), uniqueShare(raw))
+	}
+	if sumMap(clean.Synergistic) >= sumMap(raw.Synergistic) {
+		t.Errorf("synergy after merging = %.4f, want < %.4f", sumMap(clean.Synergistic), sumMap(raw.Synergistic))
+	}
+}
+
+func TestMergeSparseBins_NoOp(t *testing.T) {
+	hist, err := histogram.NewNDHistogramFromProbabilities([]float64{0.2, 0.3, 0.1, 0.4}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogramFromProbabilities failed: %v", err)
+	}
+
+	if got := MergeSparseBins(hist, 0); got != hist {
+		t.Error("minProb = 0 should return the histogram unchanged")
+	}
+	if got := MergeSparseBins(hist, 0.1); got != hist {
+		t.Error("no sparse target bins should return the histogram unchanged")
+	}
+	if got := MergeSparseBins(nil, 0.1); got != nil {
+		t.Error("nil histogram should return nil")
+	}
+
+	// Everything below minProb collapses to a single target state
+	if got := MergeSparseBins(hist, 2.0).Shape(); got[0] != 1 || got[1] != 2 {
+		t.Errorf("shape = %v, want [1 2]", got)
+	}
+}
@@ -0,0 +1,132 @@
+package surd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DecomposeMultiLag decomposes the data for each of several time lags.
+//
+// data: matrix [samples x variables] of the raw (unlagged) time series
+// targetIdx: column index of the target variable
+// lags: time lags to evaluate (each > 0 and < number of samples)
+// bins: number of bins for each column of the lagged data, i.e. the target
+// future followed by all variables (len(bins) == 1 + variables)
+//
+// For every lag the rows are [target at t+lag, all variables at t] and are
+// decomposed as by DecomposeFromData. Lags run concurrently on a worker pool
+// of runtime.GOMAXPROCS(0) goroutines; see DecomposeMultiLagWithOptions to
+// limit it.
+//
+// If some lags fail, the returned map holds the results of the successful
+// ones and the error joins the failures in the order of lags.
+//
+// Example:
+//
+//	lags := []int{1, 10, 100, 593}
+//	byLag, err := DecomposeMultiLag(data, 0, lags, []int{10, 10, 10})
+//	for _, lag := range lags {
+//		fmt.Println(lag, byLag[lag].Unique["1"])
+//	}
+func DecomposeMultiLag(data [][]float64, targetIdx int, lags []int, bins []int) (map[int]*Result, error) {
+	return DecomposeMultiLagWithOptions(data, targetIdx, lags, bins, DefaultOptions())
+}
+
+// DecomposeMultiLagWithOptions is DecomposeMultiLag with the decomposition
+// options of every lag. opts.Workers sizes the pool of concurrent lags
+// (<= 0 means runtime.GOMAXPROCS(0), 1 runs the lags serially); each lag is
+// decomposed on a single goroutine.
+//
+// opts.NSamples is ignored: each lag uses its own sample count,
+// len(data) - lag, so GrassbergerEntropy and DebiasedMI need no NSamples here.
+//
+// Example:
+//
+//	opts := DefaultOptions()
+//	opts.Workers = 2 // at most 2 lags at a time
+//	byLag, err := DecomposeMultiLagWithOptions(data, 0, lags, bins, opts)
+func DecomposeMultiLagWithOptions(data [][]float64, targetIdx int, lags []int, bins []int, opts Options) (map[int]*Result, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is empty")
+	}
+	if len(lags) == 0 {
+		return nil, fmt.Errorf("lags is empty")
+	}
+	nvars := len(data[0])
+	if targetIdx < 0 || targetIdx >= nvars {
+		return nil, fmt.Errorf("targetIdx (%d) out of range [0, %d)", targetIdx, nvars)
+	}
+	for _, lag := range lags {
+		if lag <= 0 || lag >= len(data) {
+			return nil, fmt.Errorf("lag %d out of range [1, %d)", lag, len(data))
+		}
+	}
+	if len(bins) != 1+nvars {
+		return nil, fmt.Errorf("bins length (%d) must be 1 + number of variables (%d)", len(bins), 1+nvars)
+	}
+	// NSamples is set per lag in decomposeAtLag
+	checked := opts
+	checked.NSamples = len(data)
+	if err := validateOptions(checked); err != nil {
+		return nil, err
+	}
+
+	results := make(map[int]*Result, len(lags))
+	errs := make([]error, len(lags))
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	sem := make(chan struct{}, resolveWorkers(opts.Workers))
+
+	for i, lag := range lags {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i, lag int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := decomposeAtLag(data, targetIdx, lag, bins, opts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[i] = fmt.Errorf("lag %d: %w", lag, err)
+				return
+			}
+			results[lag] = result
+		}(i, lag)
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// decomposeAtLag prepares the lagged data and decomposes it on one goroutine,
+// with NSamples set to the number of lagged rows.
+func decomposeAtLag(data [][]float64, targetIdx, lag int, bins []int, opts Options) (*Result, error) {
+	lagged := laggedData(data, targetIdx, lag)
+	if len(lagged) < 2 {
+		return nil, fmt.Errorf("lag (%d) leaves %d samples, need at least 2", lag, len(lagged))
+	}
+	opts.NSamples = len(lagged)
+	opts.Workers = 1 // the lags already run on opts.Workers goroutines
+	return decomposeFromData(context.Background(), lagged, bins, opts)
+}
+
+// laggedData returns the rows [target at t+lag, all variables at t] for
+// t = 0..len(data)-lag-1.
+func laggedData(data [][]float64, targetIdx, lag int) [][]float64 {
+	lagged := make([][]float64, len(data)-lag)
+	for t := range lagged {
+		row := make([]float64, 1+len(data[t]))
+		row[0] = data[t+lag][targetIdx]
+		copy(row[1:], data[t])
+		lagged[t] = row
+	}
+	return lagged
+}
@@ -0,0 +1,160 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+func TestDecomposeMultiLag(t *testing.T) {
+	// x is random; y copies x with a delay of 3 steps
+	rng := rand.New(rand.NewSource(11)) //nolint:gosec // deterministic test data
+	n := 20000
+	const delay = 3
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = []float64{0, float64(rng.Intn(2))}
+		if i >= delay {
+			data[i][0] = data[i-delay][1]
+		}
+	}
+
+	lags := []int{1, 2, 3, 4}
+	results, err := DecomposeMultiLag(data, 0, lags, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeMultiLag failed: %v", err)
+	}
+	if len(results) != len(lags) {
+		t.Fatalf("expected %d results, got %d", len(lags), len(results))
+	}
+
+	// Agent 1 is x (agent 0 is y's own past)
+	for _, lag := range lags {
+		u := results[lag].Unique["1"]
+		t.Logf("lag %d: U(x) = %.3f, leak = %.3f", lag, u, results[lag].InfoLeak)
+		if lag == delay && u < 0.9 {
+			t.Errorf("lag %d: expected ~1 bit from x, got %.3f", lag, u)
+		}
+		if lag != delay && u > 0.05 {
+			t.Errorf("lag %d: expected no information from x, got %.3f", lag, u)
+		}
+	}
+}
+
+func TestDecomposeMultiLag_InvalidInput(t *testing.T) {
+	data := generateNoisyCopy(100, 2)
+	bins := []int{2, 2, 2, 2}
+
+	if _, err := DecomposeMultiLag(data, 0, []int{1, 0}, bins); err == nil {
+		t.Error("expected error for zero lag")
+	}
+	if _, err := DecomposeMultiLag(data, 0, []int{100}, bins); err == nil {
+		t.Error("expected error for lag >= samples")
+	}
+	if _, err := DecomposeMultiLag(data, 0, nil, bins); err == nil {
+		t.Error("expected error for empty lags")
+	}
+	if _, err := DecomposeMultiLag(data, 0, []int{1}, []int{2, 2, 2}); err == nil {
+		t.Error("expected error for bins length mismatch")
+	}
+	if _, err := DecomposeMultiLag(data, 3, []int{1}, bins); err == nil {
+		t.Error("expected error for target index out of range")
+	}
+}
+
+func TestDecomposeMultiLagWithOptions_Estimators(t *testing.T) {
+	data := generateNoisyCopy(2000, 2)
+	lags := []int{1, 4}
+	bins := []int{2, 2, 2, 2}
+
+	for _, tt := range []struct {
+		name string
+		opts Options
+	}{
+		{"grassberger", Options{EntropyEstimator: GrassbergerEntropy}},
+		{"debiased", Options{DebiasedMI: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// opts.NSamples is unset: each lag uses len(data) - lag
+			got, err := DecomposeMultiLagWithOptions(data, 0, lags, bins, tt.opts)
+			if err != nil {
+				t.Fatalf("DecomposeMultiLagWithOptions failed: %v", err)
+			}
+			for _, lag := range lags {
+				lagged := laggedData(data, 0, lag)
+				hist, err := histogram.NewNDHistogram(lagged, bins)
+				if err != nil {
+					t.Fatalf("NewNDHistogram failed: %v", err)
+				}
+				opts := tt.opts
+				opts.NSamples = len(lagged)
+				want, err := DecomposeWithOptions(hist, opts)
+				if err != nil {
+					t.Fatalf("DecomposeWithOptions failed: %v", err)
+				}
+				if got[lag].InfoLeak != want.InfoLeak || got[lag].InfoLeak == 0 {
+					t.Errorf("lag %d: InfoLeak = %v, want %v (non-zero)", lag, got[lag].InfoLeak, want.InfoLeak)
+				}
+				for key, v := range want.MutualInfo {
+					if got[lag].MutualInfo[key] != v {
+						t.Errorf("lag %d: MutualInfo[%s] = %v, want %v", lag, key, got[lag].MutualInfo[key], v)
+					}
+				}
+			}
+		})
+	}
+
+	// Inconsistent options are rejected as by DecomposeWithOptions
+	for _, opts := range []Options{
+		{MaxOrder: -1},
+		{DebiasedMI: true, EntropyEstimator: GrassbergerEntropy},
+	} {
+		if _, err := DecomposeMultiLagWithOptions(data, 0, lags, bins, opts); err == nil {
+			t.Errorf("expected error for options %+v", opts)
+		}
+	}
+}
+
+func TestDecomposeMultiLagWithOptions_Workers(t *testing.T) {
+	data := generateNoisyCopy(2000, 2)
+	lags := []int{1, 2, 3, 5, 8}
+	bins := []int{2, 2, 2, 2}
+
+	want, err := DecomposeMultiLag(data, 0, lags, bins)
+	if err != nil {
+		t.Fatalf("DecomposeMultiLag failed: %v", err)
+	}
+
+	for _, workers := range []int{1, 2} {
+		opts := DefaultOptions()
+		opts.Workers = workers
+		got, err := DecomposeMultiLagWithOptions(data, 0, lags, bins, opts)
+		if err != nil {
+			t.Fatalf("%d workers: DecomposeMultiLagWithOptions failed: %v", workers, err)
+		}
+		for _, lag := range lags {
+			for key, v := range want[lag].Unique {
+				if got[lag].Unique[key] != v {
+					t.Errorf("%d workers, lag %d: U[%s] = %v, want %v", workers, lag, key, got[lag].Unique[key], v)
+				}
+			}
+		}
+	}
+}
+
+func TestDecomposeMultiLag_PartialFailure(t *testing.T) {
+	data := generateNoisyCopy(100, 2)
+
+	// Lag 99 leaves a single sample, which DecomposeFromData rejects
+	results, err := DecomposeMultiLag(data, 0, []int{1, 99}, []int{2, 2, 2, 2})
+	if err == nil {
+		t.Fatal("expected error for failing lag")
+	}
+	if _, ok := results[1]; !ok {
+		t.Error("expected result for lag 1 despite failure of lag 99")
+	}
+	if _, ok := results[99]; ok {
+		t.Error("unexpected result for failing lag 99")
+	}
+}
@@ -0,0 +1,135 @@
+package surd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// DirectedInfoMatrix computes the directed information network across all variable pairs.
+//
+// data: matrix [samples x variables]
+// lag: time lag (> 0) between source past and target future
+// bins: number of bins for each variable
+//
+// Entry (i, j) is the mutual information from source i's past to target j's future:
+//
+//	M[i][j] = I(X_j(t+lag); X_i(t))
+//
+// The asymmetry between M[i][j] and M[j][i] indicates directionality: a variable
+// driving another has higher directed information toward it than back. Diagonal
+// entries are the self-information of each variable across the lag.
+//
+// Pairs are computed in parallel on runtime.GOMAXPROCS(0) goroutines.
+func DirectedInfoMatrix(data [][]float64, lag int, bins []int) ([][]float64, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is empty")
+	}
+	nvars := len(data[0])
+	if len(bins) != nvars {
+		return nil, fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), nvars)
+	}
+	if lag <= 0 {
+		return nil, fmt.Errorf("lag must be positive, got %d", lag)
+	}
+	if len(data)-lag < 2 {
+		return nil, fmt.Errorf("lag (%d) leaves %d samples, need at least 2", lag, len(data)-lag)
+	}
+	for i, sample := range data {
+		if len(sample) != nvars {
+			return nil, fmt.Errorf("sample %d has length %d, expected %d", i, len(sample), nvars)
+		}
+	}
+
+	matrix := make([][]float64, nvars)
+	for i := range matrix {
+		matrix[i] = make([]float64, nvars)
+	}
+
+	err := forEachPair(nvars, func(i, j int) error {
+		mi, err := laggedMutualInfo(data, i, j, lag, bins)
+		if err != nil {
+			return err
+		}
+		matrix[i][j] = mi
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matrix, nil
+}
+
+// forEachPair calls fn(i, j) for every ordered pair of nvars variables on
+// runtime.GOMAXPROCS(0) goroutines. fn must only write to its own (i, j) slot.
+// The first error is returned, wrapped with its pair.
+func forEachPair(nvars int, fn func(i, j int) error) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, resolveWorkers(0))
+
+	for i := 0; i < nvars; i++ {
+		for j := 0; j < nvars; j++ {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func(i, j int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				if err := fn(i, j); err != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("pair (%d, %d): %w", i, j, err)
+					}
+				}
+			}(i, j)
+		}
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// laggedMutualInfo computes I(X_target(t+lag); X_source(t)).
+func laggedMutualInfo(data [][]float64, source, target, lag int, bins []int) (float64, error) {
+	future, past := laggedColumns(data, source, target, lag)
+	return pairMutualInfo(future, past, bins[target], bins[source])
+}
+
+// laggedColumns returns the target future X_target(t+lag) and source past X_source(t).
+func laggedColumns(data [][]float64, source, target, lag int) (future, past []float64) {
+	n := len(data) - lag
+	future = make([]float64, n)
+	past = make([]float64, n)
+	for t := 0; t < n; t++ {
+		future[t] = data[t+lag][target]
+		past[t] = data[t][source]
+	}
+	return future, past
+}
+
+// pairMutualInfo computes I(X; Y) of two aligned series from a 2D histogram.
+func pairMutualInfo(x, y []float64, xBins, yBins int) (float64, error) {
+	pairs := make([][]float64, len(x))
+	for t := range x {
+		pairs[t] = []float64{x[t], y[t]}
+	}
+
+	hist, err := histogram.NewNDHistogram(pairs, []int{xBins, yBins})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create histogram: %w", err)
+	}
+
+	arr := &entropy.NDArray{
+		Data:  hist.Probabilities(),
+		Shape: hist.Shape(),
+	}
+	return entropy.MutualInformation(arr, []int{0}, []int{1}), nil
+}
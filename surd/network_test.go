@@ -0,0 +1,68 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDirectedInfoMatrix_OneWayCoupling(t *testing.T) {
+	// x drives y: y(t+1) = x(t) + small noise; x is independent of y
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test data
+	n := 5000
+	data := make([][]float64, n)
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = rng.Float64()
+	}
+	for i := 0; i < n; i++ {
+		y := rng.Float64()
+		if i > 0 {
+			y = x[i-1] + 0.05*rng.NormFloat64()
+		}
+		data[i] = []float64{x[i], y}
+	}
+
+	matrix, err := DirectedInfoMatrix(data, 1, []int{8, 8})
+	if err != nil {
+		t.Fatalf("DirectedInfoMatrix failed: %v", err)
+	}
+
+	xToY := matrix[0][1]
+	yToX := matrix[1][0]
+	t.Logf("I(y+; x) = %.4f, I(x+; y) = %.4f", xToY, yToX)
+
+	if xToY <= yToX {
+		t.Errorf("driving direction x→y (%.4f) should exceed y→x (%.4f)", xToY, yToX)
+	}
+	if xToY < 1.0 {
+		t.Errorf("x→y directed information too low: %.4f", xToY)
+	}
+	if yToX > 0.1 {
+		t.Errorf("y→x directed information too high: %.4f", yToX)
+	}
+}
+
+func TestDirectedInfoMatrix_ErrorCases(t *testing.T) {
+	data := [][]float64{{1, 2}, {3, 4}, {5, 6}}
+
+	tests := []struct {
+		name string
+		data [][]float64
+		lag  int
+		bins []int
+	}{
+		{"empty data", [][]float64{}, 1, []int{2, 2}},
+		{"bins mismatch", data, 1, []int{2}},
+		{"zero lag", data, 0, []int{2, 2}},
+		{"lag too large", data, 2, []int{2, 2}},
+		{"ragged rows", [][]float64{{1, 2}, {3}, {5, 6}}, 1, []int{2, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DirectedInfoMatrix(tt.data, tt.lag, tt.bins); err == nil {
+				t.Error("DirectedInfoMatrix() expected error")
+			}
+		})
+	}
+}
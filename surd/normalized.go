@@ -0,0 +1,58 @@
+package surd
+
+// NormalizedResult holds the SURD components as fractions of the total
+// information R + U + S captured by the agents.
+// Keys are the same as in Result.
+type NormalizedResult struct {
+	Redundant   map[string]float64
+	Unique      map[string]float64
+	Synergistic map[string]float64
+
+	// TotalRedundant, TotalUnique and TotalSynergistic are the fractions of
+	// each component type; they sum to 1 unless TotalInformation is zero.
+	TotalRedundant   float64
+	TotalUnique      float64
+	TotalSynergistic float64
+
+	// TotalInformation is the normalization constant R + U + S in bits.
+	TotalInformation float64
+}
+
+// Normalized returns the components divided by their total R + U + S.
+//
+// If the total is zero (e.g. a constant target), every fraction is zero.
+// The leak is not part of the total; it is already normalized in InfoLeak.
+//
+// Example:
+//
+//	n := result.Normalized()
+//	fmt.Printf("R %.1f%% | U %.1f%% | S %.1f%%\n",
+//		100*n.TotalRedundant, 100*n.TotalUnique, 100*n.TotalSynergistic)
+func (r *Result) Normalized() *NormalizedResult {
+	redundant, unique, synergistic := sumMap(r.Redundant), sumMap(r.Unique), sumMap(r.Synergistic)
+	total := redundant + unique + synergistic
+
+	scale := 0.0
+	if total != 0 {
+		scale = 1 / total
+	}
+
+	return &NormalizedResult{
+		Redundant:        scaleMap(r.Redundant, scale),
+		Unique:           scaleMap(r.Unique, scale),
+		Synergistic:      scaleMap(r.Synergistic, scale),
+		TotalRedundant:   redundant * scale,
+		TotalUnique:      unique * scale,
+		TotalSynergistic: synergistic * scale,
+		TotalInformation: total,
+	}
+}
+
+// scaleMap returns a copy of m with every value multiplied by scale.
+func scaleMap(m map[string]float64, scale float64) map[string]float64 {
+	scaled := make(map[string]float64, len(m))
+	for key, v := range m {
+		scaled[key] = v * scale
+	}
+	return scaled
+}
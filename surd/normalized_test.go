@@ -0,0 +1,53 @@
+package surd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalized(t *testing.T) {
+	result := &Result{
+		Redundant:   map[string]float64{"0,1": 0.5},
+		Unique:      map[string]float64{"0": 1, "1": 0.25},
+		Synergistic: map[string]float64{"0,1": 0.25},
+		InfoLeak:    0.3,
+	}
+
+	n := result.Normalized()
+	if n.TotalInformation != 2 {
+		t.Errorf("TotalInformation = %v, want 2", n.TotalInformation)
+	}
+	if n.Unique["0"] != 0.5 || n.Unique["1"] != 0.125 || n.Redundant["0,1"] != 0.25 || n.Synergistic["0,1"] != 0.125 {
+		t.Errorf("unexpected fractions: %+v", n)
+	}
+	if n.TotalRedundant != 0.25 || n.TotalUnique != 0.625 || n.TotalSynergistic != 0.125 {
+		t.Errorf("unexpected totals: R=%v U=%v S=%v", n.TotalRedundant, n.TotalUnique, n.TotalSynergistic)
+	}
+	if sum := n.TotalRedundant + n.TotalUnique + n.TotalSynergistic; math.Abs(sum-1) > 1e-12 {
+		t.Errorf("fractions sum to %v, want 1", sum)
+	}
+
+	// The original result is not modified
+	if result.Unique["0"] != 1 {
+		t.Errorf("Normalized modified the result: Unique[0] = %v", result.Unique["0"])
+	}
+}
+
+func TestNormalized_ZeroTotal(t *testing.T) {
+	result := &Result{
+		Redundant:   map[string]float64{"0,1": 0},
+		Unique:      map[string]float64{"0": 0, "1": 0},
+		Synergistic: map[string]float64{"0,1": 0},
+		InfoLeak:    1,
+	}
+
+	n := result.Normalized()
+	if n.TotalInformation != 0 || n.TotalRedundant != 0 || n.TotalUnique != 0 || n.TotalSynergistic != 0 {
+		t.Errorf("expected all-zero totals, got %+v", n)
+	}
+	for key, v := range n.Unique {
+		if v != 0 || math.IsNaN(v) {
+			t.Errorf("Unique[%s] = %v, want 0", key, v)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// quickMinSamplesPerCell is the average number of samples per joint histogram
+// cell that Quick aims for when choosing bins.
+const quickMinSamplesPerCell = 5
+
+// Quick decomposes the causality from sources to target with automatic binning.
+// It is a one-liner for the common case; use DecomposeFromData to control the
+// bins, or DecomposeWithOptions for the algorithm options.
+//
+// target: target samples
+// sources: one slice per source variable, each of len(target)
+//
+// Bins for each variable are chosen with the Freedman–Diaconis rule (Sturges'
+// rule for zero-IQR data), never exceeding the number of distinct values, and
+// are then capped so that the joint histogram has on average at least 5
+// samples per cell (with a floor of 2 bins). Result keys are source indices:
+// "0" is sources[0], "1" is sources[1], and so on.
+//
+// Example:
+//
+//	result, err := Quick(y, x1, x2)
+//	fmt.Printf("synergy: %.3f bits\n", result.Synergistic["0,1"])
+func Quick(target []float64, sources ...[]float64) (*Result, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("at least one source is required")
+	}
+	for i, s := range sources {
+		if len(s) != len(target) {
+			return nil, fmt.Errorf("source %d has length %d, target has %d", i, len(s), len(target))
+		}
+	}
+
+	columns := append([][]float64{target}, sources...)
+
+	// Per-variable cap keeping the joint grid at >= quickMinSamplesPerCell samples per cell
+	maxPerVar := math.Pow(float64(len(target))/quickMinSamplesPerCell, 1/float64(len(columns)))
+	limit := max(2, int(maxPerVar))
+
+	bins := make([]int, len(columns))
+	for j, col := range columns {
+		bins[j] = min(histogram.SuggestBins(col), limit)
+	}
+
+	data := make([][]float64, len(target))
+	for i := range data {
+		row := make([]float64, len(columns))
+		for j, col := range columns {
+			row[j] = col[i]
+		}
+		data[i] = row
+	}
+
+	return DecomposeFromData(data, bins)
+}
@@ -0,0 +1,61 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestQuick_XOR(t *testing.T) {
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic test data
+	n := 10000
+	target := make([]float64, n)
+	x1 := make([]float64, n)
+	x2 := make([]float64, n)
+	for i := range target {
+		a, b := rng.Intn(2), rng.Intn(2)
+		x1[i], x2[i] = float64(a), float64(b)
+		target[i] = float64(a ^ b)
+	}
+
+	result, err := Quick(target, x1, x2)
+	if err != nil {
+		t.Fatalf("Quick failed: %v", err)
+	}
+
+	if result.Synergistic["0,1"] < 0.9 {
+		t.Errorf("expected ~1 bit of synergy, got %.4f", result.Synergistic["0,1"])
+	}
+	if result.Unique["0"] > 0.05 || result.Unique["1"] > 0.05 {
+		t.Errorf("expected no unique information, got U0=%.4f U1=%.4f", result.Unique["0"], result.Unique["1"])
+	}
+}
+
+func TestQuick_ContinuousCopy(t *testing.T) {
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test data
+	n := 5000
+	target := make([]float64, n)
+	x := make([]float64, n)
+	noise := make([]float64, n)
+	for i := range target {
+		x[i] = rng.NormFloat64()
+		noise[i] = rng.NormFloat64()
+		target[i] = x[i] + 0.1*rng.NormFloat64()
+	}
+
+	result, err := Quick(target, x, noise)
+	if err != nil {
+		t.Fatalf("Quick failed: %v", err)
+	}
+	if result.Unique["0"] < 5*result.Unique["1"] || result.Unique["0"] < 0.5 {
+		t.Errorf("expected x to dominate: U0=%.4f U1=%.4f", result.Unique["0"], result.Unique["1"])
+	}
+}
+
+func TestQuick_InvalidInput(t *testing.T) {
+	if _, err := Quick([]float64{1, 2, 3}); err == nil {
+		t.Error("expected error without sources")
+	}
+	if _, err := Quick([]float64{1, 2, 3}, []float64{1, 2}); err == nil {
+		t.Error("expected error for length mismatch")
+	}
+}
@@ -0,0 +1,79 @@
+package surd
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// ResolutionSensitivity reports how the information leak H(target|agents)
+// changes as one variable's bin count varies while all others stay fixed.
+//
+// data: matrix [samples x variables]
+// targetIdx: column index of the target; all other columns are agents
+// varIdx: column index of the agent whose bin count varies
+// binRange: bin counts to try for varIdx
+// bins: number of bins for each column of data (bins[varIdx] is ignored)
+//
+// Returns the leak in bits for each entry of binRange. A steep drop means the
+// variable carries information about the target at finer resolution; a flat
+// curve means extra bins mostly add estimation noise. Note that finer bins
+// always bias the leak downward at finite sample size, so compare curves of
+// different variables rather than reading absolute drops.
+//
+// Example:
+//
+//	leaks, _ := ResolutionSensitivity(data, 0, 1, []int{2, 4, 8, 16}, []int{8, 8, 8})
+//	for i, b := range []int{2, 4, 8, 16} {
+//	    fmt.Printf("bins=%2d leak=%.3f bits\n", b, leaks[i])
+//	}
+func ResolutionSensitivity(data [][]float64, targetIdx int, varIdx int, binRange []int, bins []int) ([]float64, error) {
+	if len(binRange) == 0 {
+		return nil, fmt.Errorf("binRange is empty")
+	}
+	if len(data) > 0 && (varIdx < 0 || varIdx >= len(data[0])) {
+		return nil, fmt.Errorf("varIdx (%d) out of range [0, %d)", varIdx, len(data[0]))
+	}
+	if varIdx == targetIdx {
+		return nil, fmt.Errorf("varIdx (%d) equals targetIdx", varIdx)
+	}
+
+	var agents []int
+	pos := 0
+	if len(data) > 0 {
+		for j := 0; j < len(data[0]); j++ {
+			if j == targetIdx {
+				continue
+			}
+			agents = append(agents, j)
+			if j == varIdx {
+				pos = len(agents) // position in [target, agents...]
+			}
+		}
+	}
+
+	subData, subBins, err := selectColumns(data, targetIdx, agents, bins)
+	if err != nil {
+		return nil, err
+	}
+
+	agentAxes := make([]int, len(agents))
+	for i := range agentAxes {
+		agentAxes[i] = i + 1
+	}
+
+	leaks := make([]float64, len(binRange))
+	for i, b := range binRange {
+		subBins[pos] = b
+		hist, err := histogram.NewNDHistogram(subData, subBins)
+		if err != nil {
+			return nil, fmt.Errorf("bins=%d: %w", b, err)
+		}
+
+		arr := &entropy.NDArray{Data: hist.Probabilities(), Shape: hist.Shape()}
+		leaks[i] = entropy.ConditionalEntropy(arr, []int{0}, agentAxes)
+	}
+
+	return leaks, nil
+}
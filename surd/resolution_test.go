@@ -0,0 +1,72 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestResolutionSensitivity(t *testing.T) {
+	// target = x + noise; z is independent noise
+	rng := rand.New(rand.NewSource(5)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 20000)
+	for i := range data {
+		x := rng.Float64()
+		data[i] = []float64{x + 0.05*rng.NormFloat64(), x, rng.Float64()}
+	}
+	bins := []int{8, 8, 8}
+	binRange := []int{2, 4, 8, 16}
+
+	informative, err := ResolutionSensitivity(data, 0, 1, binRange, bins)
+	if err != nil {
+		t.Fatalf("ResolutionSensitivity(x) failed: %v", err)
+	}
+	noise, err := ResolutionSensitivity(data, 0, 2, binRange, bins)
+	if err != nil {
+		t.Fatalf("ResolutionSensitivity(z) failed: %v", err)
+	}
+	if len(informative) != len(binRange) || len(noise) != len(binRange) {
+		t.Fatalf("got %d and %d leaks, want %d", len(informative), len(noise), len(binRange))
+	}
+
+	t.Logf("leak vs x bins: %.4f", informative)
+	t.Logf("leak vs z bins: %.4f", noise)
+
+	for i := 1; i < len(binRange); i++ {
+		if informative[i] > informative[i-1] {
+			t.Errorf("informative leak increased from %d to %d bins: %.4f -> %.4f",
+				binRange[i-1], binRange[i], informative[i-1], informative[i])
+		}
+	}
+
+	dropInformative := informative[0] - informative[len(informative)-1]
+	dropNoise := noise[0] - noise[len(noise)-1]
+	if dropInformative < 5*dropNoise || dropInformative < 0.5 {
+		t.Errorf("informative drop %.4f bits should be much steeper than noise drop %.4f bits",
+			dropInformative, dropNoise)
+	}
+}
+
+func TestResolutionSensitivity_ErrorCases(t *testing.T) {
+	data := generateNoisyCopy(100, 1)
+	bins := []int{2, 2, 2}
+
+	tests := []struct {
+		name      string
+		targetIdx int
+		varIdx    int
+		binRange  []int
+	}{
+		{"empty binRange", 0, 1, nil},
+		{"varIdx is target", 0, 0, []int{2}},
+		{"varIdx out of range", 0, 3, []int{2}},
+		{"target out of range", 5, 1, []int{2}},
+		{"invalid bin count", 0, 1, []int{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ResolutionSensitivity(data, tt.targetIdx, tt.varIdx, tt.binRange, bins); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
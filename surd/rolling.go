@@ -0,0 +1,133 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// WindowResult is the SURD decomposition of a single rolling window.
+type WindowResult struct {
+	// Start is the index of the first sample (source time t) in the window.
+	Start int
+
+	// End is one past the index of the last sample in the window.
+	End int
+
+	// Center is the window center time: (Start + End - 1) / 2.
+	Center float64
+
+	// Result is the SURD decomposition of the window.
+	Result *Result
+}
+
+// RollingDecompose computes SURD over sliding windows of a time series.
+//
+// series: matrix [time x variables]
+// targetIdx: column index of the target variable
+// windowSize: number of lagged samples per window
+// step: shift between consecutive windows
+// bins: number of bins for each column of series
+// lag: time lag (> 0) between agents and target
+//
+// Each window is decomposed like PrepareWithLag data: the target is
+// series[t+lag][targetIdx] and the agents are all variables at time t, so
+// agent key "k" refers to column k of series.
+//
+// Bin ranges are computed once from the whole series and shared by all
+// windows, so components are comparable across time.
+//
+// Example:
+//
+//	windows, err := RollingDecompose(series, 0, 1000, 250, []int{8, 8, 8}, 1)
+//	for _, w := range windows {
+//	    fmt.Printf("t=%.0f leak=%.3f\n", w.Center, w.Result.InfoLeak)
+//	}
+func RollingDecompose(series [][]float64, targetIdx, windowSize, step int, bins []int, lag int) ([]WindowResult, error) {
+	if len(series) == 0 {
+		return nil, fmt.Errorf("series is empty")
+	}
+	nvars := len(series[0])
+	if len(bins) != nvars {
+		return nil, fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), nvars)
+	}
+	if targetIdx < 0 || targetIdx >= nvars {
+		return nil, fmt.Errorf("targetIdx (%d) out of range [0, %d)", targetIdx, nvars)
+	}
+	if lag <= 0 {
+		return nil, fmt.Errorf("lag must be positive, got %d", lag)
+	}
+	if windowSize < 2 {
+		return nil, fmt.Errorf("windowSize must be at least 2, got %d", windowSize)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("step must be positive, got %d", step)
+	}
+	nsamples := len(series) - lag
+	if windowSize > nsamples {
+		return nil, fmt.Errorf("windowSize (%d) exceeds available samples (%d) after lag %d", windowSize, nsamples, lag)
+	}
+
+	// Fixed ranges from the whole series
+	colMin := make([]float64, nvars)
+	colMax := make([]float64, nvars)
+	for j := 0; j < nvars; j++ {
+		colMin[j] = math.Inf(1)
+		colMax[j] = math.Inf(-1)
+	}
+	for i, row := range series {
+		if len(row) != nvars {
+			return nil, fmt.Errorf("sample %d has length %d, expected %d", i, len(row), nvars)
+		}
+		for j, val := range row {
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				continue
+			}
+			colMin[j] = math.Min(colMin[j], val)
+			colMax[j] = math.Max(colMax[j], val)
+		}
+	}
+	for j := 0; j < nvars; j++ {
+		if math.IsInf(colMin[j], 0) || math.IsInf(colMax[j], 0) {
+			return nil, fmt.Errorf("variable %d has no valid (non-NaN, non-Inf) values", j)
+		}
+	}
+
+	// Layout: [target, agent0, agent1, ...]
+	lagBins := append([]int{bins[targetIdx]}, bins...)
+	lagMin := append([]float64{colMin[targetIdx]}, colMin...)
+	lagMax := append([]float64{colMax[targetIdx]}, colMax...)
+
+	var windows []WindowResult
+	for start := 0; start+windowSize <= nsamples; start += step {
+		end := start + windowSize
+
+		window := make([][]float64, windowSize)
+		for i := range window {
+			t := start + i
+			row := make([]float64, 1+nvars)
+			row[0] = series[t+lag][targetIdx]
+			copy(row[1:], series[t])
+			window[i] = row
+		}
+
+		hist, err := histogram.NewNDHistogramWithRanges(window, lagBins, lagMin, lagMax)
+		if err != nil {
+			return nil, fmt.Errorf("window [%d, %d): failed to create histogram: %w", start, end, err)
+		}
+		result, err := Decompose(hist)
+		if err != nil {
+			return nil, fmt.Errorf("window [%d, %d): %w", start, end, err)
+		}
+
+		windows = append(windows, WindowResult{
+			Start:  start,
+			End:    end,
+			Center: float64(start+end-1) / 2,
+			Result: result,
+		})
+	}
+
+	return windows, nil
+}
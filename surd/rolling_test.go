@@ -0,0 +1,97 @@
+package surd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// generateRegimeSwitch returns a binary series [y, x1, x2] that is redundant
+// (x2 = x1, y(t+1) = x1(t)) for the first half and synergistic
+// (independent x1, x2, y(t+1) = x1(t) XOR x2(t)) for the second half.
+func generateRegimeSwitch(n int, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	series := make([][]float64, n)
+	for t := 0; t < n; t++ {
+		x1 := rng.Intn(2)
+		x2 := rng.Intn(2)
+		if t < n/2 {
+			x2 = x1
+		}
+		series[t] = []float64{0, float64(x1), float64(x2)}
+	}
+	for t := 1; t < n; t++ {
+		x1, x2 := int(series[t-1][1]), int(series[t-1][2])
+		if t-1 < n/2 {
+			series[t][0] = float64(x1)
+		} else {
+			series[t][0] = float64(x1 ^ x2)
+		}
+	}
+	return series
+}
+
+func TestRollingDecompose_RegimeSwitch(t *testing.T) {
+	series := generateRegimeSwitch(8000, 3)
+
+	windows, err := RollingDecompose(series, 0, 2000, 1000, []int{2, 2, 2}, 1)
+	if err != nil {
+		t.Fatalf("RollingDecompose failed: %v", err)
+	}
+
+	// (7999 - 2000) / 1000 + 1 = 6 windows
+	if len(windows) != 6 {
+		t.Fatalf("got %d windows, want 6", len(windows))
+	}
+
+	for _, w := range windows {
+		t.Logf("t=%6.1f  R=%.3f  U=%.3f  S=%.3f  leak=%.3f", w.Center,
+			sumMap(w.Result.Redundant), sumMap(w.Result.Unique),
+			sumMap(w.Result.Synergistic), w.Result.InfoLeak)
+	}
+
+	first := windows[0].Result
+	last := windows[len(windows)-1].Result
+
+	if r, s := sumMap(first.Redundant), sumMap(first.Synergistic); r < 0.9 || s > 0.1 {
+		t.Errorf("first window: R=%.3f S=%.3f, want redundant regime (R ~1, S ~0)", r, s)
+	}
+	if r, s := sumMap(last.Redundant), sumMap(last.Synergistic); s < 0.9 || r > 0.1 {
+		t.Errorf("last window: R=%.3f S=%.3f, want synergistic regime (S ~1, R ~0)", r, s)
+	}
+
+	if windows[0].Center != 999.5 || windows[1].Start != 1000 {
+		t.Errorf("unexpected window layout: %+v, %+v", windows[0], windows[1])
+	}
+}
+
+func TestRollingDecompose_ErrorCases(t *testing.T) {
+	series := generateRegimeSwitch(100, 1)
+	bins := []int{2, 2, 2}
+
+	tests := []struct {
+		name       string
+		series     [][]float64
+		targetIdx  int
+		windowSize int
+		step       int
+		bins       []int
+		lag        int
+	}{
+		{"empty series", [][]float64{}, 0, 10, 1, bins, 1},
+		{"bins mismatch", series, 0, 10, 1, []int{2}, 1},
+		{"target out of range", series, 5, 10, 1, bins, 1},
+		{"zero lag", series, 0, 10, 1, bins, 0},
+		{"window too small", series, 0, 1, 1, bins, 1},
+		{"zero step", series, 0, 10, 0, bins, 1},
+		{"window too large", series, 0, 100, 1, bins, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RollingDecompose(tt.series, tt.targetIdx, tt.windowSize, tt.step, tt.bins, tt.lag)
+			if err == nil {
+				t.Error("RollingDecompose() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// sampleSizeRepeats is the number of subsamples drawn at each pilot size.
+	sampleSizeRepeats = 10
+
+	// sampleSizeSeed makes RequiredSamples deterministic.
+	sampleSizeSeed = 1
+
+	// minPilotSamples is the smallest pilot accepted by RequiredSamples.
+	minPilotSamples = 40
+)
+
+// sampleSizeFractions are the pilot fractions used to measure the standard error.
+// Fractions stay at or below 1/2 so subsamples are not nearly identical.
+var sampleSizeFractions = []float64{0.125, 0.25, 0.5}
+
+// RequiredSamples estimates the number of samples needed for the dominant SURD
+// component to reach a target standard error.
+//
+// pilot: matrix [samples x variables] from a small pilot run
+// targetIdx: column index of the target; all other columns are agents
+// bins: number of bins for each column of pilot
+// targetStdErr: desired standard error of the dominant component (bits, > 0)
+//
+// The dominant component is the largest R, U or S entry of the decomposition of
+// the full pilot. The pilot is subsampled (without replacement) at 1/8, 1/4 and
+// 1/2 of its size; at each size the standard deviation of the dominant component
+// across subsamples gives its standard error. The model SE(N) = c/√N is fitted
+// by least squares and solved for SE(N) = targetStdErr.
+//
+// The estimate is an extrapolation: it is most reliable when the returned N is
+// within a few orders of magnitude of the pilot size. If the component does not
+// vary across subsamples, the smallest subsample size is returned.
+//
+// Example:
+//
+//	n, err := RequiredSamples(pilot, 0, []int{8, 8, 8}, 0.01)
+//	fmt.Printf("need ~%d samples for ±0.01 bits\n", n)
+func RequiredSamples(pilot [][]float64, targetIdx int, bins []int, targetStdErr float64) (int, error) {
+	if targetStdErr <= 0 || math.IsNaN(targetStdErr) || math.IsInf(targetStdErr, 0) {
+		return 0, fmt.Errorf("targetStdErr must be positive and finite, got %v", targetStdErr)
+	}
+	if len(pilot) < minPilotSamples {
+		return 0, fmt.Errorf("pilot has %d samples, need at least %d", len(pilot), minPilotSamples)
+	}
+
+	nvars := len(pilot[0])
+	agents := make([]int, 0, nvars)
+	for j := 0; j < nvars; j++ {
+		if j != targetIdx {
+			agents = append(agents, j)
+		}
+	}
+	data, subBins, err := selectColumns(pilot, targetIdx, agents, bins)
+	if err != nil {
+		return 0, err
+	}
+
+	full, err := DecomposeFromData(data, subBins)
+	if err != nil {
+		return 0, fmt.Errorf("pilot decomposition failed: %w", err)
+	}
+	component := dominantComponent(full)
+
+	rng := rand.New(rand.NewSource(sampleSizeSeed)) //nolint:gosec // reproducible subsampling
+	var sumXY, sumXX float64
+	for _, fraction := range sampleSizeFractions {
+		n := int(fraction * float64(len(data)))
+
+		values := make([]float64, sampleSizeRepeats)
+		for r := range values {
+			perm := rng.Perm(len(data))
+			sub := make([][]float64, n)
+			for i := range sub {
+				sub[i] = data[perm[i]]
+			}
+
+			result, err := DecomposeFromData(sub, subBins)
+			if err != nil {
+				return 0, fmt.Errorf("subsample of %d samples failed: %w", n, err)
+			}
+			values[r] = component(result)
+		}
+
+		// SE(n) = c / √n  →  least squares through the origin in x = 1/√n
+		x := 1 / math.Sqrt(float64(n))
+		sumXY += sampleStdDev(values) * x
+		sumXX += x * x
+	}
+
+	c := sumXY / sumXX
+	if c <= 0 {
+		return int(sampleSizeFractions[0] * float64(len(data))), nil
+	}
+
+	required := math.Ceil((c / targetStdErr) * (c / targetStdErr))
+	if required > math.MaxInt32 {
+		return math.MaxInt32, nil
+	}
+	return int(required), nil
+}
+
+// dominantComponent returns a getter for the largest R, U or S entry of r.
+func dominantComponent(r *Result) func(*Result) float64 {
+	type entry struct {
+		kind  string
+		key   string
+		value float64
+	}
+
+	var entries []entry
+	for key, v := range r.Redundant {
+		entries = append(entries, entry{"R", key, v})
+	}
+	for key, v := range r.Unique {
+		entries = append(entries, entry{"U", key, v})
+	}
+	for key, v := range r.Synergistic {
+		entries = append(entries, entry{"S", key, v})
+	}
+	// Deterministic order for ties
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].value != entries[j].value {
+			return entries[i].value > entries[j].value
+		}
+		if entries[i].kind != entries[j].kind {
+			return entries[i].kind < entries[j].kind
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	best := entries[0]
+	return func(res *Result) float64 {
+		switch best.kind {
+		case "R":
+			return res.Redundant[best.key]
+		case "U":
+			return res.Unique[best.key]
+		default:
+			return res.Synergistic[best.key]
+		}
+	}
+}
+
+// sampleStdDev returns the sample standard deviation (n-1 denominator).
+func sampleStdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := sumValues(values) / float64(len(values))
+	ss := 0.0
+	for _, v := range values {
+		ss += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(ss / float64(len(values)-1))
+}
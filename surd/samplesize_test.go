@@ -0,0 +1,58 @@
+package surd
+
+import (
+	"testing"
+)
+
+func TestRequiredSamples_GrowsWithPrecision(t *testing.T) {
+	// Dominant unique component of x, estimated with sampling noise
+	pilot := generateNoisyCopy(4000, 21)
+	bins := []int{2, 2, 2}
+
+	var prev int
+	for _, se := range []float64{0.01, 0.005, 0.0025} {
+		n, err := RequiredSamples(pilot, 0, bins, se)
+		if err != nil {
+			t.Fatalf("RequiredSamples(%v) failed: %v", se, err)
+		}
+		t.Logf("targetStdErr=%.4f → N=%d", se, n)
+
+		if n <= prev {
+			t.Errorf("targetStdErr=%v: N=%d, want > %d (tighter precision needs more samples)", se, n, prev)
+		}
+		prev = n
+	}
+
+	// Halving the standard error should need ~4× the samples (SE ∝ 1/√N)
+	n1, _ := RequiredSamples(pilot, 0, bins, 0.01)
+	n2, _ := RequiredSamples(pilot, 0, bins, 0.005)
+	if ratio := float64(n2) / float64(n1); ratio < 3.9 || ratio > 4.1 {
+		t.Errorf("N ratio for half the SE = %.2f, want ~4", ratio)
+	}
+}
+
+func TestRequiredSamples_ErrorCases(t *testing.T) {
+	pilot := generateInformativeAndNoise(200, 1)
+	bins := []int{2, 2, 2}
+
+	tests := []struct {
+		name      string
+		pilot     [][]float64
+		targetIdx int
+		bins      []int
+		se        float64
+	}{
+		{"zero stderr", pilot, 0, bins, 0},
+		{"pilot too small", pilot[:10], 0, bins, 0.01},
+		{"bins mismatch", pilot, 0, []int{2}, 0.01},
+		{"target out of range", pilot, 3, bins, 0.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RequiredSamples(tt.pilot, tt.targetIdx, tt.bins, tt.se); err == nil {
+				t.Error("RequiredSamples() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestDecomposeWithSignificance(t *testing.T) {
+	// [target, x, noise]: x drives the target, noise is independent
+	data := generateNoisyCopy(2000, 13)
+
+	result, sig, err := DecomposeWithSignificance(data, []int{2, 2, 2}, 199, 1)
+	if err != nil {
+		t.Fatalf("DecomposeWithSignificance failed: %v", err)
+	}
+	t.Logf("U0 = %.4f (p=%.3f), U1 = %.4f (p=%.3f)",
+		result.Unique["0"], sig.Unique["0"], result.Unique["1"], sig.Unique["1"])
+
+	if sig.NPermutations != 199 {
+		t.Errorf("NPermutations = %d, want 199", sig.NPermutations)
+	}
+	if p := sig.Unique["0"]; p != 1.0/200 {
+		t.Errorf("p-value of the driver = %v, want minimum 1/200", p)
+	}
+	if p := sig.Unique["1"]; p < 0.05 {
+		t.Errorf("p-value of the noise agent = %v, expected not significant", p)
+	}
+	for _, p := range []map[string]float64{sig.Redundant, sig.Unique, sig.Synergistic} {
+		for key, v := range p {
+			if v <= 0 || v > 1 {
+				t.Errorf("p-value %s = %v outside (0, 1]", key, v)
+			}
+		}
+	}
+}
+
+func TestDecomposeWithSignificance_Reproducible(t *testing.T) {
+	data := generateNoisyCopy(500, 14)
+
+	_, a, err := DecomposeWithSignificance(data, []int{2, 2, 2}, 50, 9)
+	if err != nil {
+		t.Fatalf("DecomposeWithSignificance failed: %v", err)
+	}
+	_, b, err := DecomposeWithSignificance(data, []int{2, 2, 2}, 50, 9)
+	if err != nil {
+		t.Fatalf("DecomposeWithSignificance failed: %v", err)
+	}
+	for key, p := range a.Synergistic {
+		if b.Synergistic[key] != p {
+			t.Errorf("Synergistic[%s]: %v vs %v with the same seed", key, p, b.Synergistic[key])
+		}
+	}
+}
+
+func TestDecomposeWithSignificanceOptions_Workers(t *testing.T) {
+	data := generateNoisyCopy(500, 14)
+
+	_, want, err := DecomposeWithSignificance(data, []int{2, 2, 2}, 30, 9)
+	if err != nil {
+		t.Fatalf("DecomposeWithSignificance failed: %v", err)
+	}
+	_, got, err := DecomposeWithSignificanceOptions(data, []int{2, 2, 2}, 30, 9, SignificanceOptions{Workers: 1})
+	if err != nil {
+		t.Fatalf("DecomposeWithSignificanceOptions failed: %v", err)
+	}
+	for key, p := range want.Unique {
+		if got.Unique[key] != p {
+			t.Errorf("Unique[%s]: %v with 1 worker, want %v", key, got.Unique[key], p)
+		}
+	}
+	for key, p := range want.Synergistic {
+		if got.Synergistic[key] != p {
+			t.Errorf("Synergistic[%s]: %v with 1 worker, want %v", key, got.Synergistic[key], p)
+		}
+	}
+}
+
+func TestDecomposeWithSignificance_InvalidInput(t *testing.T) {
+	data := generateNoisyCopy(100, 15)
+	if _, _, err := DecomposeWithSignificance(data, []int{2, 2, 2}, 0, 1); err == nil {
+		t.Error("expected error for nperm = 0")
+	}
+	if _, _, err := DecomposeWithSignificance(data, []int{2, 2}, 10, 1); err == nil {
+		t.Error("expected error for bins length mismatch")
+	}
+}
+
+func TestDecomposeWithSignificanceOptions_Autocorrelated(t *testing.T) {
+	// Two independent AR(1) processes with long memory: no coupling, but
+	// their finite-sample MI is far above what i.i.d. shuffles produce.
+	rng := rand.New(rand.NewSource(6)) //nolint:gosec // deterministic test data
+	n := 2000
+	data := make([][]float64, n)
+	var x, y float64
+	for i := range data {
+		x = 0.98*x + rng.NormFloat64()
+		y = 0.98*y + rng.NormFloat64()
+		data[i] = []float64{y, x}
+	}
+
+	pvalue := func(opts SignificanceOptions) float64 {
+		t.Helper()
+		_, sig, err := DecomposeWithSignificanceOptions(data, []int{8, 8}, 199, 7, opts)
+		if err != nil {
+			t.Fatalf("%v: DecomposeWithSignificanceOptions failed: %v", opts.Method, err)
+		}
+		return sig.Unique["0"]
+	}
+
+	naive := pvalue(SignificanceOptions{})
+	block := pvalue(SignificanceOptions{Method: BlockShuffle, BlockSize: 200})
+	phase := pvalue(SignificanceOptions{Method: PhaseRandomization})
+	t.Logf("p-values: shuffle %.3f, block %.3f, phase %.3f", naive, block, phase)
+
+	if naive >= 0.05 {
+		t.Errorf("shuffle p = %.3f, expected the (false) significance it is known for", naive)
+	}
+	if block < 0.05 {
+		t.Errorf("block shuffle p = %.3f, want non-significant", block)
+	}
+	if phase < 0.05 {
+		t.Errorf("phase randomization p = %.3f, want non-significant", phase)
+	}
+}
+
+func TestSurrogates_PreserveStructure(t *testing.T) {
+	rng := rand.New(rand.NewSource(3)) //nolint:gosec // deterministic test data
+	x := make([]float64, 1000)
+	for i := 1; i < len(x); i++ {
+		x[i] = 0.9*x[i-1] + rng.NormFloat64()
+	}
+
+	// Block shuffle is a permutation of the values
+	blocked := blockShuffle(x, 37, rng)
+	a, b := append([]float64(nil), x...), append([]float64(nil), blocked...)
+	sort.Float64s(a)
+	sort.Float64s(b)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("block shuffle changed the set of values")
+	}
+
+	// Phase randomization keeps the mean and the lag-1 autocorrelation
+	phased := phaseRandomize(x, rng)
+	if math.Abs(stat.Mean(phased, nil)-stat.Mean(x, nil)) > 1e-9 {
+		t.Errorf("mean changed: %.6f vs %.6f", stat.Mean(phased, nil), stat.Mean(x, nil))
+	}
+	if ac, want := circularAutocorr(phased), circularAutocorr(x); math.Abs(ac-want) > 1e-9 {
+		t.Errorf("circular lag-1 autocorrelation %.6f, want %.6f", ac, want)
+	}
+
+	if _, _, err := DecomposeWithSignificanceOptions([][]float64{{0, 1}, {1, 0}}, []int{2, 2}, 9, 1, SignificanceOptions{Method: SurrogateMethod(9)}); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
+
+// circularAutocorr returns the lag-1 circular autocorrelation of x, which is
+// determined by the power spectrum.
+func circularAutocorr(x []float64) float64 {
+	m := stat.Mean(x, nil)
+	var num, den float64
+	for i := range x {
+		num += (x[i] - m) * (x[(i+1)%len(x)] - m)
+		den += (x[i] - m) * (x[i] - m)
+	}
+	return num / den
+}
@@ -0,0 +1,93 @@
+package surd
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+)
+
+// ResultSimilarity returns the Jensen-Shannon divergence between the
+// component profiles of a and b, in bits: 0 for identical profiles, up to 1
+// for profiles with no component in common.
+//
+// A profile lists every redundant, unique and synergistic component plus the
+// leak in bits (LeakBits), normalized to sum to 1, i.e. how H(target) is split
+// among the components. The two profiles are aligned by component type and
+// key; a component missing from one result counts as 0 there. Negative
+// components (possible with DebiasedMI) are treated as 0.
+//
+// Returns an error if a or b is nil or has an all-zero profile.
+//
+// Example:
+//
+//	// Run-to-run consistency of three cycles
+//	d12, _ := ResultSimilarity(c1, c2)
+//	d13, _ := ResultSimilarity(c1, c3)
+func ResultSimilarity(a, b *Result) (float64, error) {
+	if a == nil || b == nil {
+		return 0, fmt.Errorf("result is nil")
+	}
+
+	// Aligned component index: type + key -> position
+	index := make(map[string]int)
+	for _, r := range []*Result{a, b} {
+		for _, c := range componentProfile(r) {
+			if _, ok := index[c.id]; !ok {
+				index[c.id] = len(index)
+			}
+		}
+	}
+
+	p, err := profileVector(a, index)
+	if err != nil {
+		return 0, fmt.Errorf("first result: %w", err)
+	}
+	q, err := profileVector(b, index)
+	if err != nil {
+		return 0, fmt.Errorf("second result: %w", err)
+	}
+	return entropy.JensenShannonDivergence(p, q), nil
+}
+
+// profileEntry is one component of a result profile.
+type profileEntry struct {
+	id    string // component type and key, e.g. "synergistic:0,1"
+	value float64
+}
+
+// componentProfile lists the components of r, with the leak as "info_leak:".
+func componentProfile(r *Result) []profileEntry {
+	var entries []profileEntry
+	for _, c := range []struct {
+		typ    string
+		values map[string]float64
+	}{
+		{ComponentRedundant, r.Redundant},
+		{ComponentUnique, r.Unique},
+		{ComponentSynergistic, r.Synergistic},
+	} {
+		for key, v := range c.values {
+			entries = append(entries, profileEntry{id: c.typ + ":" + key, value: v})
+		}
+	}
+	return append(entries, profileEntry{id: ComponentInfoLeak + ":", value: r.LeakBits})
+}
+
+// profileVector returns the normalized profile of r laid out by index.
+func profileVector(r *Result, index map[string]int) ([]float64, error) {
+	vec := make([]float64, len(index))
+	total := 0.0
+	for _, c := range componentProfile(r) {
+		if c.value > 0 {
+			vec[index[c.id]] = c.value
+			total += c.value
+		}
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("all components are zero")
+	}
+	for i := range vec {
+		vec[i] /= total
+	}
+	return vec, nil
+}
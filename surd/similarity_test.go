@@ -0,0 +1,89 @@
+package surd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResultSimilarity(t *testing.T) {
+	a := &Result{
+		Redundant:   map[string]float64{"0,1": 0.2},
+		Unique:      map[string]float64{"0": 0.3, "1": 0.1},
+		Synergistic: map[string]float64{"0,1": 0.2},
+		LeakBits:    0.2,
+	}
+
+	// Same profile at twice the scale
+	scaled := &Result{
+		Redundant:   map[string]float64{"0,1": 0.4},
+		Unique:      map[string]float64{"0": 0.6, "1": 0.2},
+		Synergistic: map[string]float64{"0,1": 0.4},
+		LeakBits:    0.4,
+	}
+	if d, err := ResultSimilarity(a, scaled); err != nil || math.Abs(d) > 1e-12 {
+		t.Errorf("scaled copy: got %v, %v; want 0", d, err)
+	}
+
+	// Disjoint components
+	other := &Result{Synergistic: map[string]float64{"0,2": 1}}
+	if d, err := ResultSimilarity(a, other); err != nil || math.Abs(d-1) > 1e-12 {
+		t.Errorf("disjoint: got %v, %v; want 1", d, err)
+	}
+
+	// Shifting information from unique to synergistic increases the divergence
+	shifted := &Result{
+		Redundant:   map[string]float64{"0,1": 0.2},
+		Unique:      map[string]float64{"0": 0.2, "1": 0.1},
+		Synergistic: map[string]float64{"0,1": 0.3},
+		LeakBits:    0.2,
+	}
+	moreShifted := &Result{
+		Redundant:   map[string]float64{"0,1": 0.2},
+		Unique:      map[string]float64{"1": 0.1},
+		Synergistic: map[string]float64{"0,1": 0.5},
+		LeakBits:    0.2,
+	}
+	d1, _ := ResultSimilarity(a, shifted)
+	d2, _ := ResultSimilarity(a, moreShifted)
+	if d1 <= 0 || d2 <= d1 {
+		t.Errorf("expected 0 < d(a, shifted) = %v < d(a, moreShifted) = %v", d1, d2)
+	}
+	if rev, _ := ResultSimilarity(moreShifted, a); math.Abs(rev-d2) > 1e-15 {
+		t.Errorf("not symmetric: %v vs %v", d2, rev)
+	}
+
+	if _, err := ResultSimilarity(nil, a); err == nil {
+		t.Error("expected error for nil result")
+	}
+	if _, err := ResultSimilarity(a, &Result{}); err == nil {
+		t.Error("expected error for an all-zero result")
+	}
+}
+
+func TestResultSimilarity_Decompositions(t *testing.T) {
+	// Two samples of a noisy copy are closer to each other than to an exact copy
+	run1, err := DecomposeFromData(generateNoisyCopy(4000, 1), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	run2, err := DecomposeFromData(generateNoisyCopy(4000, 2), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	exact, err := DecomposeFromData(generateInformativeAndNoise(4000, 1), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	same, err := ResultSimilarity(run1, run2)
+	if err != nil {
+		t.Fatalf("ResultSimilarity failed: %v", err)
+	}
+	different, err := ResultSimilarity(run1, exact)
+	if err != nil {
+		t.Fatalf("ResultSimilarity failed: %v", err)
+	}
+	if same >= different {
+		t.Errorf("expected same-system divergence %v < cross-system divergence %v", same, different)
+	}
+}
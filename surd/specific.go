@@ -0,0 +1,54 @@
+package surd
+
+import (
+	"fmt"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// SpecificMIMatrix returns the specific mutual information of every agent
+// combination for each target state: the [combination x target state] matrix
+// that Decompose builds internally before assigning increments to R, U and S.
+//
+// hist: histogram with the target on axis 0 and agents on the remaining axes
+//
+// Keys are combination keys as in Result ("0", "1", "0,1", ...). Each row has
+// one entry per target state:
+//
+//	I_s(t; a) = Σ_a p(a|t) [log2 p(t|a) - log2 p(t)]
+//
+// Weighting a row by the target marginal recovers the combination's mutual
+// information: Σ_t p(t) I_s(t; a) = I(target; a) = Result.MutualInfo[key].
+//
+// No prescreening is applied: every combination is computed.
+//
+// Example:
+//
+//	hist, _ := histogram.NewNDHistogram(data, []int{8, 8, 8})
+//	specific, err := SpecificMIMatrix(hist)
+//	fmt.Println(specific["0,1"]) // specific MI of the pair per target state
+func SpecificMIMatrix(hist *histogram.NDHistogram) (map[string][]float64, error) {
+	if hist == nil {
+		return nil, fmt.Errorf("histogram is nil")
+	}
+
+	shape := hist.Shape()
+	if len(shape) < 2 {
+		return nil, fmt.Errorf("histogram must have at least 2 dimensions (target + agents), got %d", len(shape))
+	}
+
+	arr := &entropy.NDArray{
+		Data:  hist.Probabilities(),
+		Shape: shape,
+	}
+	pTarget := marginalizeTo(arr, []int{0})
+
+	combs := generateCombinations(len(shape) - 1)
+	specificMI := make(map[string][]float64, len(combs))
+	for _, comb := range combs {
+		specificMI[combToKey(comb)] = computeSpecificMI(arr, comb, pTarget, shape[0])
+	}
+
+	return specificMI, nil
+}
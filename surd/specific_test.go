@@ -0,0 +1,189 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+func TestSpecificMIMatrix_TwoSources(t *testing.T) {
+	data := generateNoisyCopy(5000, 8)
+	bins := []int{2, 2, 2}
+
+	hist, err := histogram.NewNDHistogram(data, bins)
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	specific, err := SpecificMIMatrix(hist)
+	if err != nil {
+		t.Fatalf("SpecificMIMatrix failed: %v", err)
+	}
+	result, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	// One row per combination of 2 agents: "0", "1", "0,1"
+	if len(specific) != 3 {
+		t.Fatalf("expected 3 combinations, got %d: %v", len(specific), specific)
+	}
+
+	// Target marginal from the histogram (target = axis 0)
+	probs := hist.Probabilities()
+	pTarget := make([]float64, bins[0])
+	stride := len(probs) / bins[0]
+	for i, p := range probs {
+		pTarget[i/stride] += p
+	}
+
+	for _, key := range []string{"0", "1", "0,1"} {
+		row, ok := specific[key]
+		if !ok {
+			t.Fatalf("missing combination %q", key)
+		}
+		if len(row) != bins[0] {
+			t.Errorf("%s: %d columns, want %d target states", key, len(row), bins[0])
+		}
+
+		mi := 0.0
+		for s, v := range row {
+			if v < -1e-12 {
+				t.Errorf("%s: negative specific MI %v for state %d", key, v, s)
+			}
+			mi += pTarget[s] * v
+		}
+		if math.Abs(mi-result.MutualInfo[key]) > 1e-10 {
+			t.Errorf("%s: Σ p(t) I_s = %.6f, MutualInfo = %.6f", key, mi, result.MutualInfo[key])
+		}
+	}
+
+	// The copied agent carries information, the noise agent almost none
+	if result.MutualInfo["0"] < 0.2 || result.MutualInfo["1"] > 0.01 {
+		t.Errorf("unexpected MI: copy %.4f, noise %.4f", result.MutualInfo["0"], result.MutualInfo["1"])
+	}
+}
+
+func TestSpecificMIMatrix_InvalidInput(t *testing.T) {
+	if _, err := SpecificMIMatrix(nil); err == nil {
+		t.Error("expected error for nil histogram")
+	}
+
+	hist, err := histogram.NewNDHistogramFromProbabilities([]float64{0.5, 0.5}, []int{2})
+	if err != nil {
+		t.Fatalf("NewNDHistogramFromProbabilities failed: %v", err)
+	}
+	if _, err := SpecificMIMatrix(hist); err == nil {
+		t.Error("expected error for 1D histogram")
+	}
+}
+
+func TestDecomposeWithOptions_KeepSpecificMI(t *testing.T) {
+	data := generateNoisyCopy(5000, 8)
+	hist, err := histogram.NewNDHistogram(data, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	want, err := SpecificMIMatrix(hist)
+	if err != nil {
+		t.Fatalf("SpecificMIMatrix failed: %v", err)
+	}
+
+	plain, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	if plain.SpecificMI != nil {
+		t.Errorf("SpecificMI retained without KeepSpecificMI: %v", plain.SpecificMI)
+	}
+
+	opts := DefaultOptions()
+	opts.KeepSpecificMI = true
+	kept, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+	assertSpecificMIEqual(t, kept.SpecificMI, want)
+
+	// Retaining the matrix must not change the decomposition
+	for key, v := range plain.Synergistic {
+		if kept.Synergistic[key] != v {
+			t.Errorf("S[%s] = %v with KeepSpecificMI, want %v", key, kept.Synergistic[key], v)
+		}
+	}
+	for key, v := range plain.Unique {
+		if kept.Unique[key] != v {
+			t.Errorf("U[%s] = %v with KeepSpecificMI, want %v", key, kept.Unique[key], v)
+		}
+	}
+}
+
+func TestDecomposeWithOptions_KeepSpecificMIShortcut(t *testing.T) {
+	// Target is an exact copy of agent 0: Decompose takes the deterministic
+	// shortcut, which must still report the specific MI.
+	rng := rand.New(rand.NewSource(3)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 2000)
+	for i := range data {
+		x := float64(rng.Intn(2))
+		data[i] = []float64{x, x, float64(rng.Intn(2))}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	want, err := SpecificMIMatrix(hist)
+	if err != nil {
+		t.Fatalf("SpecificMIMatrix failed: %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.KeepSpecificMI = true
+	result, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+	assertSpecificMIEqual(t, result.SpecificMI, want)
+
+	// A rare copied state: H(target) ≈ 0.045 bits, so with prescreening even
+	// the determining agent is pruned and its specific MI reported as zeros
+	p1 := 0.005
+	probs := []float64{
+		(1 - p1) / 2, (1 - p1) / 2, 0, 0, // target 0: x = 0, noise 0/1
+		0, 0, p1 / 2, p1 / 2, // target 1: x = 1, noise 0/1
+	}
+	rare, err := histogram.NewNDHistogramFromProbabilities(probs, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogramFromProbabilities failed: %v", err)
+	}
+	opts.PrescreenThreshold = 0.05
+	result, err = DecomposeWithOptions(rare, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+	for key, row := range result.SpecificMI {
+		for s, v := range row {
+			if v != 0 {
+				t.Errorf("pruned %s[%d] = %v, want 0", key, s, v)
+			}
+		}
+	}
+}
+
+func assertSpecificMIEqual(t *testing.T, got, want map[string][]float64) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("SpecificMI has %d combinations, want %d", len(got), len(want))
+	}
+	for key, row := range want {
+		if len(got[key]) != len(row) {
+			t.Fatalf("%s: %d target states, want %d", key, len(got[key]), len(row))
+		}
+		for s, v := range row {
+			if math.Abs(got[key][s]-v) > 1e-12 {
+				t.Errorf("%s[%d] = %v, want %v", key, s, got[key][s], v)
+			}
+		}
+	}
+}
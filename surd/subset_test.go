@@ -0,0 +1,102 @@
+package surd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecomposeSubset(t *testing.T) {
+	data := generateInformativeAndNoise(10000, 42)
+	bins := []int{2, 2, 2}
+
+	// Only the informative agent
+	result, err := DecomposeSubset(data, 0, []int{1}, bins)
+	if err != nil {
+		t.Fatalf("DecomposeSubset failed: %v", err)
+	}
+	if math.Abs(result.Unique["0"]-1.0) > 0.01 {
+		t.Errorf("Unique[0] = %f, want ~1.0", result.Unique["0"])
+	}
+
+	// Reversed agent order: keys follow positions in agents
+	result, err = DecomposeSubset(data, 0, []int{2, 1}, bins)
+	if err != nil {
+		t.Fatalf("DecomposeSubset failed: %v", err)
+	}
+	if result.Unique["1"] < 0.99 || result.Unique["0"] > 0.01 {
+		t.Errorf("Unique = %v, want key \"1\" (informative) ~1.0 and key \"0\" (noise) ~0", result.Unique)
+	}
+}
+
+func TestDecomposeSubset_ErrorCases(t *testing.T) {
+	data := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	bins := []int{2, 2, 2}
+
+	tests := []struct {
+		name      string
+		data      [][]float64
+		targetIdx int
+		agents    []int
+		bins      []int
+	}{
+		{"empty data", [][]float64{}, 0, []int{1}, bins},
+		{"bins mismatch", data, 0, []int{1}, []int{2, 2}},
+		{"target out of range", data, 3, []int{1}, bins},
+		{"no agents", data, 0, []int{}, bins},
+		{"agent out of range", data, 0, []int{5}, bins},
+		{"agent equals target", data, 0, []int{0}, bins},
+		{"duplicate agent", data, 0, []int{1, 1}, bins},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecomposeSubset(tt.data, tt.targetIdx, tt.agents, tt.bins); err == nil {
+				t.Error("DecomposeSubset() expected error")
+			}
+		})
+	}
+}
+
+func TestIncrementalDecompose(t *testing.T) {
+	data := generateInformativeAndNoise(10000, 42)
+	bins := []int{2, 2, 2}
+
+	tests := []struct {
+		name       string
+		agentOrder []int
+		wantMI     []float64 // approximate total MI after each step
+	}{
+		{
+			name:       "informative first",
+			agentOrder: []int{1, 2},
+			wantMI:     []float64{1.0, 1.0}, // noise barely changes MI
+		},
+		{
+			name:       "noise first",
+			agentOrder: []int{2, 1},
+			wantMI:     []float64{0.0, 1.0}, // informative agent jumps MI
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := IncrementalDecompose(data, 0, tt.agentOrder, bins)
+			if err != nil {
+				t.Fatalf("IncrementalDecompose failed: %v", err)
+			}
+			if len(results) != len(tt.agentOrder) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.agentOrder))
+			}
+			for k, r := range results {
+				got := r.TotalMutualInfo()
+				if math.Abs(got-tt.wantMI[k]) > 0.01 {
+					t.Errorf("step %d: total MI = %f, want ~%f", k, got, tt.wantMI[k])
+				}
+			}
+		})
+	}
+
+	if _, err := IncrementalDecompose(data, 0, nil, bins); err == nil {
+		t.Error("IncrementalDecompose() with empty agentOrder expected error")
+	}
+}
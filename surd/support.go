@@ -0,0 +1,134 @@
+package surd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/causalgo/causalgo/internal/entropy"
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+// BinContribution is the share of a SURD component carried by one histogram bin.
+type BinContribution struct {
+	// Index is the multi-index of the bin: the target bin followed by the bin
+	// of each agent in the component, in key order.
+	Index []int
+
+	// Value is the contribution in bits. Values sum to the component.
+	Value float64
+}
+
+// ComponentSupport returns the bins that carry a SURD component, sorted by
+// contribution in descending order.
+//
+// hist: histogram with the target on axis 0 and agents on the remaining axes
+// componentKey: component type R, U or S followed by a Result key,
+// e.g. "U0", "R0,1", "S0,1"
+//
+// For each target state t, Decompose assigns the component an amount c(t) of
+// information. ComponentSupport splits c(t) over the agent bins a of the
+// component in proportion to their pointwise terms of the specific mutual
+// information,
+//
+//	p(t, a) [log2 p(t|a) - log2 p(t)]
+//
+// so the contributions of all bins add up to the component value. Bins with
+// zero contribution are omitted; negative contributions (misinformative bins)
+// are kept and sort last.
+//
+// Example:
+//
+//	hist, _ := histogram.NewNDHistogram(data, []int{2, 2, 2})
+//	support, err := ComponentSupport(hist, "S0,1")
+//	top := support[0] // target bin top.Index[0], agent bins top.Index[1:]
+func ComponentSupport(hist *histogram.NDHistogram, componentKey string) ([]BinContribution, error) {
+	specificMI, err := SpecificMIMatrix(hist)
+	if err != nil {
+		return nil, err
+	}
+
+	shape := hist.Shape()
+	nvars := len(shape) - 1
+	kind, comb, err := parseComponentKey(componentKey, nvars)
+	if err != nil {
+		return nil, err
+	}
+	key := combToKey(comb)
+
+	arr := &entropy.NDArray{
+		Data:  hist.Probabilities(),
+		Shape: shape,
+	}
+	pTarget := marginalizeTo(arr, []int{0})
+	combs := generateCombinations(nvars)
+	opts := DefaultOptions()
+
+	// scale[t] converts pointwise specific MI of state t into component bits
+	scale := make([]float64, shape[0])
+	for t := range scale {
+		redundant := make(map[string]float64)
+		synergistic := make(map[string]float64)
+		allocateState(redundant, synergistic, combs, specificMI, t, pTarget[t], nvars, opts)
+
+		componentBits := redundant[key] // R and U (singleton redundancy)
+		if kind == 'S' {
+			componentBits = synergistic[key]
+		}
+		if weighted := pTarget[t] * specificMI[key][t]; weighted > 0 {
+			scale[t] = componentBits / weighted
+		}
+	}
+
+	keepAxes := []int{0}
+	agentAxes := make([]int, len(comb))
+	for i, c := range comb {
+		keepAxes = append(keepAxes, c+1)
+		agentAxes[i] = c + 1
+	}
+	pAS := marginalizeNDArray(arr, keepAxes)
+	pA := marginalizeNDArray(arr, agentAxes)
+
+	var support []BinContribution
+	for flatIdx, pASVal := range pAS {
+		multiIdx := flatToMultiIndexCustom(pAS, keepAxes, shape, flatIdx)
+		t := multiIdx[0]
+		pAVal := pA[multiToFlatIndexCustom(agentAxes, multiIdx[1:], shape)]
+		if scale[t] == 0 || pASVal <= 0 || pAVal <= 0 {
+			continue
+		}
+
+		// p(t, a) [log2 p(t|a) - log2 p(t)], scaled to the component
+		pointwise := pASVal * (entropy.Log2Safe(pASVal/pAVal) - entropy.Log2Safe(pTarget[t]))
+		if value := pointwise * scale[t]; value != 0 {
+			support = append(support, BinContribution{Index: multiIdx, Value: value})
+		}
+	}
+
+	sort.SliceStable(support, func(i, j int) bool { return support[i].Value > support[j].Value })
+	return support, nil
+}
+
+// parseComponentKey splits a component key such as "S0,1" into its type
+// letter and agent indices, validating them against nvars agents.
+func parseComponentKey(componentKey string, nvars int) (byte, []int, error) {
+	if componentKey == "" {
+		return 0, nil, fmt.Errorf("component key is empty")
+	}
+	kind := componentKey[0]
+	comb := KeyToIndices(componentKey[1:])
+	if kind != 'R' && kind != 'U' && kind != 'S' || len(comb) == 0 {
+		return 0, nil, fmt.Errorf("invalid component key %q: want R, U or S followed by agent indices, e.g. \"S0,1\"", componentKey)
+	}
+	for i, c := range comb {
+		if c >= nvars {
+			return 0, nil, fmt.Errorf("component key %q: agent %d out of range [0, %d)", componentKey, c, nvars)
+		}
+		if i > 0 && c <= comb[i-1] {
+			return 0, nil, fmt.Errorf("component key %q: agent indices must be increasing", componentKey)
+		}
+	}
+	if (kind == 'U') != (len(comb) == 1) {
+		return 0, nil, fmt.Errorf("component key %q: U needs exactly one agent, R and S at least two", componentKey)
+	}
+	return kind, comb, nil
+}
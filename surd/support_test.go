@@ -0,0 +1,109 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/causalgo/causalgo/internal/histogram"
+)
+
+func TestComponentSupport_XOR(t *testing.T) {
+	// [target, a, b] with target = a XOR b: target 1 means the sources disagree
+	rng := rand.New(rand.NewSource(23)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 4000)
+	for i := range data {
+		a, b := rng.Intn(2), rng.Intn(2)
+		data[i] = []float64{float64(a ^ b), float64(a), float64(b)}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	result, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	support, err := ComponentSupport(hist, "S0,1")
+	if err != nil {
+		t.Fatalf("ComponentSupport failed: %v", err)
+	}
+
+	total := 0.0
+	disagreement := 0.0
+	for _, bin := range support {
+		total += bin.Value
+		target, a, b := bin.Index[0], bin.Index[1], bin.Index[2]
+		if target != a^b && math.Abs(bin.Value) > 1e-9 {
+			t.Errorf("bin %v inconsistent with XOR carries %.4f bits", bin.Index, bin.Value)
+		}
+		if target == 1 && a != b {
+			disagreement += bin.Value
+		}
+	}
+	t.Logf("S = %.4f, support total %.4f, disagreement bins %.4f", result.Synergistic["0,1"], total, disagreement)
+
+	if math.Abs(total-result.Synergistic["0,1"]) > 1e-9 {
+		t.Errorf("support sums to %.6f, want S = %.6f", total, result.Synergistic["0,1"])
+	}
+	// Target state 1 (half of the synergy) is carried entirely by the anti-diagonal
+	if math.Abs(disagreement-total/2) > 0.05 {
+		t.Errorf("anti-diagonal bins carry %.4f bits, want about half of %.4f", disagreement, total)
+	}
+	for _, bin := range support[:4] {
+		if bin.Index[0] == 1 && bin.Index[1] == bin.Index[2] {
+			t.Errorf("top bin %v is on the diagonal for target state 1", bin.Index)
+		}
+	}
+	for i := 1; i < len(support); i++ {
+		if support[i].Value > support[i-1].Value {
+			t.Fatalf("support not sorted descending at %d", i)
+		}
+	}
+}
+
+func TestComponentSupport_SumsToUnique(t *testing.T) {
+	hist, err := histogram.NewNDHistogram(generateNoisyCopy(3000, 24), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	result, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	support, err := ComponentSupport(hist, "U0")
+	if err != nil {
+		t.Fatalf("ComponentSupport failed: %v", err)
+	}
+
+	total := 0.0
+	for _, bin := range support {
+		total += bin.Value
+		if len(bin.Index) != 2 {
+			t.Fatalf("unique support index %v, want [target, agent]", bin.Index)
+		}
+	}
+	if math.Abs(total-result.Unique["0"]) > 1e-9 {
+		t.Errorf("support sums to %.6f, want U = %.6f", total, result.Unique["0"])
+	}
+	// The copy relation: target bin equals source bin in the top bins
+	if top := support[0]; top.Index[0] != top.Index[1] {
+		t.Errorf("top bin %v, expected target bin == source bin", top.Index)
+	}
+}
+
+func TestComponentSupport_InvalidKey(t *testing.T) {
+	hist, err := histogram.NewNDHistogram(generateNoisyCopy(100, 25), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	for _, key := range []string{"", "0", "X0", "U", "U0,1", "S0", "R1,0", "S0,2"} {
+		if _, err := ComponentSupport(hist, key); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+	if _, err := ComponentSupport(nil, "U0"); err == nil {
+		t.Error("expected error for nil histogram")
+	}
+}
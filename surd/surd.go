@@ -17,10 +17,14 @@
 package surd
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/causalgo/causalgo/internal/entropy"
 	"github.com/causalgo/causalgo/internal/histogram"
@@ -45,6 +49,95 @@ type Result struct {
 
 	// InfoLeak is the causality from unobserved variables (0-1 normalized)
 	InfoLeak float64
+
+	// LeakBits is the unnormalized leak H(target|agents) in bits,
+	// i.e. InfoLeak * H(target)
+	LeakBits float64
+
+	// SpecificMI maps variable combinations to their specific mutual
+	// information per target state, SpecificMI[key][t] = I_s(target=t; key),
+	// as used to allocate R, U and S. Only filled when Options.KeepSpecificMI
+	// is set, nil otherwise.
+	SpecificMI map[string][]float64
+}
+
+// RedundancyAttribution selects how redundant increments are assigned to agent combinations.
+type RedundancyAttribution int
+
+const (
+	// WinnerTakesAll assigns each redundant increment to the full set of agents
+	// remaining at that step, as in the reference implementation (default).
+	WinnerTakesAll RedundancyAttribution = iota
+
+	// MIProportional spreads each redundant increment over the agent pairs of the
+	// remaining set, weighted by the pair's individual specific MI
+	// (i(target=t; a) + i(target=t; b)). Redundancy is then reported on pairs
+	// only, which gives a smoother attribution when many agents overlap.
+	// Totals of R, U and S are unchanged.
+	MIProportional
+)
+
+// EntropyEstimator selects the entropy estimator used for the leak and MutualInfo.
+type EntropyEstimator int
+
+const (
+	// PluginEntropy uses the histogram probabilities directly (default).
+	PluginEntropy EntropyEstimator = iota
+
+	// GrassbergerEntropy applies the Grassberger small-sample correction
+	// (entropy.EntropyGrassberger) to every entropy behind InfoLeak, LeakBits
+	// and MutualInfo. Bin counts are recovered as probability × Options.NSamples.
+	// R, U and S are still computed from the plug-in specific MI (see DebiasedMI).
+	GrassbergerEntropy
+)
+
+// Options configures DecomposeWithOptions.
+type Options struct {
+	// RedundancyAttribution selects how redundant increments are distributed.
+	RedundancyAttribution RedundancyAttribution
+
+	// PrescreenThreshold prunes the combination lattice (bits, 0 = disabled).
+	// Combinations whose members all have single-source MI below the threshold
+	// skip the specific MI computation and are treated as carrying no
+	// information. This speeds up wide systems with sparse causality; the
+	// components of non-pruned combinations match the full run up to the
+	// (sub-threshold) information of the pruned sources.
+	PrescreenThreshold float64
+
+	// EntropyEstimator selects the estimator for InfoLeak, LeakBits and MutualInfo.
+	EntropyEstimator EntropyEstimator
+
+	// DebiasedMI replaces every plug-in mutual information in the pipeline
+	// (specific MI per target state and MutualInfo per combination) with its
+	// delete-one jackknife estimate, so R, U and S are attributed from
+	// bias-corrected quantities. Requires NSamples; cannot be combined with
+	// GrassbergerEntropy. InfoLeak and LeakBits stay plug-in.
+	DebiasedMI bool
+
+	// NSamples is the number of samples behind the histogram. Required by
+	// GrassbergerEntropy and DebiasedMI, ignored otherwise.
+	NSamples int
+
+	// MaxOrder limits the combination lattice to combinations of at most
+	// MaxOrder agents (0 = all agents, no limit). See DecomposeSubsets.
+	MaxOrder int
+
+	// Workers is the number of goroutines computing the specific MI and
+	// MutualInfo of the combinations (<= 0 means runtime.GOMAXPROCS(0),
+	// 1 runs serially). Results are identical for every value.
+	Workers int
+
+	// KeepSpecificMI retains the per-target-state specific MI of every
+	// combination in Result.SpecificMI (after prescreening and debiasing),
+	// e.g. to see which target states drive redundancy or synergy.
+	KeepSpecificMI bool
+}
+
+// DefaultOptions returns the options used by Decompose (matching the reference).
+func DefaultOptions() Options {
+	return Options{
+		RedundancyAttribution: WinnerTakesAll,
+	}
 }
 
 // Decompose выполняет SURD декомпозицию на готовой гистограмме.
@@ -56,11 +149,26 @@ type Result struct {
 // Возвращает Result с R, U, S компонентами и утечкой информации.
 //
 // Алгоритм:
-//  1. Вычисляет утечку информации: H(target|agents) / H(target)
+//  1. Вычисляет утечку информации: H(target|agents) / H(target) (0, если H(target) = 0)
+//     Если target детерминирован отдельными агентами без синергии, сразу
+//     возвращает точное U/R разложение (см. deterministicShortcut)
 //  2. Для всех комбинаций агентов вычисляет specific MI
 //  3. Для каждого состояния target распределяет specific MI в R или S
 //  4. Извлекает Unique из Redundant (комбинации длины 1)
 func Decompose(hist *histogram.NDHistogram) (*Result, error) {
+	return DecomposeWithOptions(hist, DefaultOptions())
+}
+
+// DecomposeWithOptions выполняет SURD декомпозицию с заданными опциями.
+//
+// С DefaultOptions() результат совпадает с Decompose.
+//
+// Пример:
+//
+//	opts := DefaultOptions()
+//	opts.RedundancyAttribution = MIProportional
+//	result, err := DecomposeWithOptions(hist, opts)
+func DecomposeWithOptions(hist *histogram.NDHistogram, opts Options) (*Result, error) {
 	if hist == nil {
 		return nil, fmt.Errorf("histogram is nil")
 	}
@@ -70,7 +178,81 @@ func Decompose(hist *histogram.NDHistogram) (*Result, error) {
 		return nil, fmt.Errorf("histogram must have at least 2 dimensions (target + agents), got %d", len(shape))
 	}
 
-	probs := hist.Probabilities()
+	if err := validateOptions(opts); err != nil {
+		return nil, err
+	}
+
+	return decomposeProbs(context.Background(), hist.Probabilities(), shape, opts)
+}
+
+// validateOptions проверяет согласованность opts: MaxOrder >= 0, NSamples > 0
+// для GrassbergerEntropy и DebiasedMI, DebiasedMI без GrassbergerEntropy.
+func validateOptions(opts Options) error {
+	if opts.MaxOrder < 0 {
+		return fmt.Errorf("MaxOrder must be non-negative, got %d", opts.MaxOrder)
+	}
+	if opts.EntropyEstimator == GrassbergerEntropy && opts.NSamples <= 0 {
+		return fmt.Errorf("GrassbergerEntropy requires positive NSamples, got %d", opts.NSamples)
+	}
+	if opts.DebiasedMI {
+		if opts.NSamples <= 0 {
+			return fmt.Errorf("DebiasedMI requires positive NSamples, got %d", opts.NSamples)
+		}
+		if opts.EntropyEstimator == GrassbergerEntropy {
+			return fmt.Errorf("DebiasedMI cannot be combined with GrassbergerEntropy")
+		}
+	}
+	return nil
+}
+
+// DecomposeSubsets выполняет SURD декомпозицию, перебирая только комбинации
+// не более чем из maxOrder агентов вместо всех 2^n - 1.
+//
+// Число вычислений specific MI падает с 2^n - 1 до Σ_{k<=maxOrder} C(n, k),
+// что делает задачи с 8-10 агентами (и больше) решаемыми.
+//
+// Фильтрация specific MI и сортировка инкрементов работают на усеченной
+// решетке. Так как после фильтрации комбинации порядка k+1 не меньше
+// максимума порядка k, отброшенные комбинации порядка > maxOrder стоят в
+// конце сортировки, и компоненты порядка <= maxOrder совпадают с полной
+// декомпозицией:
+//   - Unique и Redundant совпадают полностью (ключи Redundant могут
+//     содержать больше maxOrder агентов: избыточность определяется по
+//     одиночным агентам);
+//   - Synergistic и MutualInfo содержат только комбинации до maxOrder;
+//     синергия высших порядков опускается;
+//   - InfoLeak и LeakBits не зависят от maxOrder.
+//
+// Result.TotalMutualInfo() возвращает MI комбинации наибольшего порядка,
+// то есть I(target; агенты) только при maxOrder >= числа агентов.
+//
+// Пример:
+//
+//	// 10 агентов: 55 комбинаций вместо 1023
+//	result, err := DecomposeSubsets(hist, 2)
+func DecomposeSubsets(hist *histogram.NDHistogram, maxOrder int) (*Result, error) {
+	if maxOrder < 1 {
+		return nil, fmt.Errorf("maxOrder must be at least 1, got %d", maxOrder)
+	}
+	opts := DefaultOptions()
+	opts.MaxOrder = maxOrder
+	return DecomposeWithOptions(hist, opts)
+}
+
+// decomposeProbs выполняет декомпозицию совместного распределения probs
+// формы shape (ось 0 = target). Опции уже проверены вызывающим.
+//
+// ctx проверяется на границах комбинаций и в цикле по состояниям target:
+// при отмене возвращается ctx.Err().
+//
+// probs перенормируется (см. renormalize): после слияния бинов или на
+// пользовательских сетках сумма может отклоняться от 1 из-за ошибок
+// округления, что смещало бы InfoLeak.
+func decomposeProbs(ctx context.Context, probs []float64, shape []int, opts Options) (*Result, error) {
+	probs, err := renormalize(probs)
+	if err != nil {
+		return nil, err
+	}
 
 	// Создаем NDArray для функций entropy
 	arr := &entropy.NDArray{
@@ -89,35 +271,102 @@ func Decompose(hist *histogram.NDHistogram) (*Result, error) {
 		agents[i] = i + 1
 	}
 	hCondTarget := entropy.ConditionalEntropy(arr, []int{0}, agents)
-	infoLeak := hCondTarget / hTarget
 
-	// Шаг 2: Вычислить specific MI для всех комбинаций агентов
-	// combs[i] = список индексов агентов в комбинации
-	combs := generateCombinations(nvars)
+	// Если H(target) = 0 (например, bins цели = 1), target детерминирован
+	// и неизвестных причин нет: утечка определяется как 0 вместо 0/0 = NaN
+	infoLeak := 0.0
+	if hTarget > 0 {
+		infoLeak = hCondTarget / hTarget
+	}
 
-	// specificMI[comb][targetState] = specific mutual information
-	specificMI := make(map[string][]float64)
+	// Предварительный отбор: агенты с MI ниже порога
+	pruned := prescreenAgents(arr, nvars, opts.PrescreenThreshold)
+
+	// Быстрый точный путь: target детерминирован одним агентом
+	// (не для DebiasedMI: короткий путь использует plug-in MI)
+	if !opts.DebiasedMI {
+		if res := deterministicShortcut(arr, nvars, hTarget, hCondTarget, pruned, opts); res != nil {
+			if opts.KeepSpecificMI {
+				// Короткий путь не считает specific MI: досчитываем по запросу,
+				// с нулями для отсеянных комбинаций, как в основном пути
+				pTarget := marginalizeTo(arr, []int{0})
+				res.SpecificMI = make(map[string][]float64)
+				for _, comb := range latticeCombinations(nvars, opts) {
+					specific := make([]float64, ntarget)
+					if !allPruned(comb, pruned) {
+						specific = computeSpecificMI(arr, comb, pTarget, ntarget)
+					}
+					res.SpecificMI[combToKey(comb)] = specific
+				}
+			}
+			correctEntropies(res, arr, nvars, opts)
+			return res, nil
+		}
+	}
+
+	// Шаг 2: Вычислить specific MI для всех комбинаций агентов
+	// (до opts.MaxOrder агентов), combs[i] = список индексов агентов в комбинации
+	combs := latticeCombinations(nvars, opts)
 
 	// Маргинальное распределение target: p_s
 	pTarget := marginalizeTo(arr, []int{0})
 
-	for _, comb := range combs {
-		combKey := combToKey(comb)
+	// Комбинации независимы и только читают arr: считаем их параллельно,
+	// каждая в свой слот, поэтому результат не зависит от числа воркеров.
+	// combSpecific[i][targetState] = specific MI комбинации combs[i]
+	combSpecific := make([][]float64, len(combs))
+	// combDebiased[i] = jackknife MI (только при opts.DebiasedMI)
+	combDebiased := make([]float64, len(combs))
+
+	err = forEachCombination(ctx, len(combs), opts.Workers, func(i int) {
+		comb := combs[i]
+		switch {
+		case allPruned(comb, pruned):
+			// Пропускаем: комбинация считается неинформативной
+			combSpecific[i] = make([]float64, ntarget)
+			if testHookPrescreenSkip != nil {
+				testHookPrescreenSkip(comb)
+			}
+		case opts.DebiasedMI:
+			combSpecific[i], combDebiased[i] = jackknifeSpecificMI(arr, comb, opts.NSamples)
+		default:
+			combSpecific[i] = computeSpecificMI(arr, comb, pTarget, ntarget)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// Вычисляем specific MI для этой комбинации
-		specificMI[combKey] = computeSpecificMI(arr, comb, pTarget, ntarget)
+	// specificMI[comb][targetState] = specific mutual information
+	specificMI := make(map[string][]float64, len(combs))
+	for i, comb := range combs {
+		specificMI[combToKey(comb)] = combSpecific[i]
 	}
 
 	// Шаг 3: Вычислить обычный MI для всех комбинаций
-	mutualInfo := make(map[string]float64)
-	for _, comb := range combs {
-		combKey := combToKey(comb)
-		agentIndices := make([]int, len(comb))
-		for i, c := range comb {
-			agentIndices[i] = c + 1 // +1 потому что target = axis 0
+	combMI := make([]float64, len(combs))
+	err = forEachCombination(ctx, len(combs), opts.Workers, func(i int) {
+		comb := combs[i]
+		switch {
+		case allPruned(comb, pruned):
+			combMI[i] = 0
+		case opts.DebiasedMI:
+			combMI[i] = combDebiased[i]
+		default:
+			agentIndices := make([]int, len(comb))
+			for k, c := range comb {
+				agentIndices[k] = c + 1 // +1 потому что target = axis 0
+			}
+			combMI[i] = entropy.MutualInformation(arr, []int{0}, agentIndices)
 		}
-		mi := entropy.MutualInformation(arr, []int{0}, agentIndices)
-		mutualInfo[combKey] = mi
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mutualInfo := make(map[string]float64, len(combs))
+	for i, comb := range combs {
+		mutualInfo[combToKey(comb)] = combMI[i]
 	}
 
 	// Шаг 4: Инициализируем R и S
@@ -134,62 +383,10 @@ func Decompose(hist *histogram.NDHistogram) (*Result, error) {
 
 	// Шаг 5: Обработка каждого состояния target
 	for t := 0; t < ntarget; t++ {
-		// Извлечь specific MI для этого состояния target
-		i1 := make([]float64, len(combs))
-		for idx, comb := range combs {
-			combKey := combToKey(comb)
-			i1[idx] = specificMI[combKey][t]
-		}
-
-		// Сортировка по specific MI
-		indices := argsort(i1)
-		sortedCombs := make([][]int, len(combs))
-		sortedI1 := make([]float64, len(combs))
-		for i, idx := range indices {
-			sortedCombs[i] = combs[idx]
-			sortedI1[i] = i1[idx]
-		}
-
-		// Обновление: если higher-order комбинация имеет меньше MI, чем max(lower-order), обнулить
-		sortedI1 = filterSpecificMI(sortedCombs, sortedI1)
-
-		// Пересортировка после фильтрации
-		indices = argsort(sortedI1)
-		finalCombs := make([][]int, len(sortedCombs))
-		finalI1 := make([]float64, len(sortedI1))
-		for i, idx := range indices {
-			finalCombs[i] = sortedCombs[idx]
-			finalI1[i] = sortedI1[idx]
-		}
-
-		// Вычисляем инкременты
-		diffs := make([]float64, len(finalI1))
-		diffs[0] = finalI1[0]
-		for i := 1; i < len(finalI1); i++ {
-			diffs[i] = finalI1[i] - finalI1[i-1]
-		}
-
-		// Распределение инкрементов в R или S
-		redVars := make([]int, nvars)
-		for i := 0; i < nvars; i++ {
-			redVars[i] = i
-		}
-
-		for i, comb := range finalCombs {
-			info := diffs[i] * pTarget[t]
-
-			if len(comb) == 1 {
-				// Redundant
-				key := combToKey(redVars)
-				redundant[key] += info
-				// Удалить этот агент из redVars
-				redVars = removeElement(redVars, comb[0])
-			} else {
-				// Synergistic
-				key := combToKey(comb)
-				synergistic[key] += info
-			}
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
+		allocateState(redundant, synergistic, combs, specificMI, t, pTarget[t], nvars, opts)
 	}
 
 	// Шаг 6: Извлечь Unique из Redundant
@@ -202,13 +399,168 @@ func Decompose(hist *histogram.NDHistogram) (*Result, error) {
 		}
 	}
 
-	return &Result{
+	res := &Result{
 		Redundant:   redundant,
 		Unique:      unique,
 		Synergistic: synergistic,
 		MutualInfo:  mutualInfo,
 		InfoLeak:    infoLeak,
-	}, nil
+		LeakBits:    hCondTarget,
+	}
+	if opts.KeepSpecificMI {
+		res.SpecificMI = specificMI
+	}
+	correctEntropies(res, arr, nvars, opts)
+	return res, nil
+}
+
+// Допустимый диапазон суммы вероятностей для перенормировки. Сумма вне
+// диапазона означает ошибку в данных, а не накопленную ошибку округления.
+const (
+	minProbSum = 0.5
+	maxProbSum = 2.0
+)
+
+// resolveWorkers возвращает число горутин для Options.Workers:
+// runtime.GOMAXPROCS(0) при workers <= 0.
+func resolveWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// forEachCombination вызывает fn(i) для i = 0..n-1 на пуле из workers
+// горутин (<= 0: runtime.GOMAXPROCS(0); 1: последовательно).
+//
+// fn должна писать только в свой слот i. ctx проверяется перед выдачей каждой
+// комбинации; при отмене новые комбинации не выдаются, уже начатые
+// завершаются, и возвращается ctx.Err().
+func forEachCombination(ctx context.Context, n, workers int, fn func(i int)) error {
+	workers = min(resolveWorkers(workers), n)
+
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			fn(i)
+		}
+		return nil
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	var err error
+	for i := 0; i < n; i++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return err
+}
+
+// renormalize возвращает probs, деленные на их сумму.
+// Ошибка, если сумма вне [minProbSum, maxProbSum] или не конечна.
+func renormalize(probs []float64) ([]float64, error) {
+	total := 0.0
+	for _, p := range probs {
+		total += p
+	}
+	if math.IsNaN(total) || total < minProbSum || total > maxProbSum {
+		return nil, fmt.Errorf("probabilities sum to %v, expected ~1 (allowed [%v, %v])", total, minProbSum, maxProbSum)
+	}
+	if total == 1 {
+		return probs, nil
+	}
+
+	scaled := make([]float64, len(probs))
+	for i, p := range probs {
+		scaled[i] = p / total
+	}
+	return scaled, nil
+}
+
+// allocateState распределяет specific MI состояния t цели по R и S.
+//
+// Комбинации сортируются по specific MI, инкременты между соседними
+// значениями (взвешенные pT = p(t)) добавляются в redundant (одиночные
+// агенты, ключ = оставшиеся агенты) или synergistic (комбинации).
+func allocateState(redundant, synergistic map[string]float64, combs [][]int, specificMI map[string][]float64, t int, pT float64, nvars int, opts Options) {
+	// Извлечь specific MI для этого состояния target
+	i1 := make([]float64, len(combs))
+	for idx, comb := range combs {
+		combKey := combToKey(comb)
+		i1[idx] = specificMI[combKey][t]
+	}
+
+	// Сортировка по specific MI
+	indices := argsort(i1)
+	sortedCombs := make([][]int, len(combs))
+	sortedI1 := make([]float64, len(combs))
+	for i, idx := range indices {
+		sortedCombs[i] = combs[idx]
+		sortedI1[i] = i1[idx]
+	}
+
+	// Обновление: если higher-order комбинация имеет меньше MI, чем max(lower-order), обнулить
+	sortedI1 = filterSpecificMI(sortedCombs, sortedI1)
+
+	// Пересортировка после фильтрации
+	indices = argsort(sortedI1)
+	finalCombs := make([][]int, len(sortedCombs))
+	finalI1 := make([]float64, len(sortedI1))
+	for i, idx := range indices {
+		finalCombs[i] = sortedCombs[idx]
+		finalI1[i] = sortedI1[idx]
+	}
+
+	// Вычисляем инкременты
+	diffs := make([]float64, len(finalI1))
+	diffs[0] = finalI1[0]
+	for i := 1; i < len(finalI1); i++ {
+		diffs[i] = finalI1[i] - finalI1[i-1]
+	}
+
+	// Распределение инкрементов в R или S
+	redVars := make([]int, nvars)
+	for i := 0; i < nvars; i++ {
+		redVars[i] = i
+	}
+
+	for i, comb := range finalCombs {
+		info := diffs[i] * pT
+
+		if len(comb) == 1 {
+			// Redundant
+			if opts.RedundancyAttribution == MIProportional && len(redVars) > 2 {
+				// Распределить по парам оставшихся агентов пропорционально их specific MI
+				distributeRedundancy(redundant, redVars, specificMI, t, info)
+			} else {
+				key := combToKey(redVars)
+				redundant[key] += info
+			}
+			// Удалить этот агент из redVars
+			redVars = removeElement(redVars, comb[0])
+		} else {
+			// Synergistic
+			key := combToKey(comb)
+			synergistic[key] += info
+		}
+	}
 }
 
 // DecomposeFromData создает гистограмму из данных и выполняет декомпозицию.
@@ -216,6 +568,8 @@ func Decompose(hist *histogram.NDHistogram) (*Result, error) {
 // data: матрица [samples x variables], первый столбец = target
 // bins: количество бинов для каждой переменной
 //
+// Требуется минимум 2 сэмпла: по одному сэмплу распределение не оценить.
+//
 // Пример:
 //
 //	data := [][]float64{
@@ -226,37 +580,335 @@ func Decompose(hist *histogram.NDHistogram) (*Result, error) {
 //	bins := []int{10, 10, 10}  // 10 bins для каждой переменной
 //	result, err := DecomposeFromData(data, bins)
 func DecomposeFromData(data [][]float64, bins []int) (*Result, error) {
+	return DecomposeFromDataContext(context.Background(), data, bins)
+}
+
+// DecomposeFromDataContext как DecomposeFromData, но с возможностью отмены
+// через ctx.
+//
+// Комбинации агентов считаются на пуле из runtime.GOMAXPROCS(0) горутин
+// (Options.Workers по умолчанию, см. forEachCombination).
+//
+// ctx проверяется после построения гистограммы, перед выдачей каждой
+// комбинации агентов и в цикле по состояниям target. При отмене или
+// истечении срока новые комбинации не выдаются, уже начатые дорабатывают до
+// конца, после чего возвращается ctx.Err() без частичного результата.
+//
+// Пример:
+//
+//	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+//	defer cancel()
+//	result, err := DecomposeFromDataContext(ctx, data, bins)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    ...
+//	}
+func DecomposeFromDataContext(ctx context.Context, data [][]float64, bins []int) (*Result, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("data is empty")
 	}
+	if len(data) < 2 {
+		return nil, fmt.Errorf("data must have at least 2 samples, got %d", len(data))
+	}
 	if len(data[0]) < 2 {
 		return nil, fmt.Errorf("data must have at least 2 variables (target + agents)")
 	}
 	if len(bins) != len(data[0]) {
 		return nil, fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), len(data[0]))
 	}
+	return decomposeFromData(ctx, data, bins, DefaultOptions())
+}
+
+// decomposeFromData строит гистограмму по data и выполняет декомпозицию с
+// opts. Размеры data и bins должны быть уже проверены.
+func decomposeFromData(ctx context.Context, data [][]float64, bins []int, opts Options) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	hist, err := histogram.NewNDHistogram(data, bins)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create histogram: %w", err)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return decomposeProbs(ctx, hist.Probabilities(), hist.Shape(), opts)
+}
+
+// DecomposeFromDiscreteData выполняет декомпозицию для уже дискретных данных
+// (целочисленные коды категорий, например сигналы 0/1), без бинирования.
+//
+// data: матрица [samples x variables] индексов категорий, первый столбец = target
+// levels: число категорий каждой переменной; значения столбца j в [0, levels[j])
+//
+// Каждое значение используется как индекс бина напрямую (см.
+// histogram.NewNDHistogramDiscrete), поэтому краевые значения не попадают в
+// соседний бин, а неиспользованные категории не растягивают диапазон.
+//
+// Пример:
+//
+//	// XOR: target = a ^ b
+//	data := [][]int{{0, 0, 0}, {1, 0, 1}, {1, 1, 0}, {0, 1, 1}}
+//	result, err := DecomposeFromDiscreteData(data, []int{2, 2, 2})
+func DecomposeFromDiscreteData(data [][]int, levels []int) (*Result, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is empty")
+	}
+	if len(data) < 2 {
+		return nil, fmt.Errorf("data must have at least 2 samples, got %d", len(data))
+	}
+	if len(data[0]) < 2 {
+		return nil, fmt.Errorf("data must have at least 2 variables (target + agents)")
+	}
+	if len(levels) != len(data[0]) {
+		return nil, fmt.Errorf("levels length (%d) must match number of variables (%d)", len(levels), len(data[0]))
+	}
+
+	hist, err := histogram.NewNDHistogramDiscrete(data, levels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create histogram: %w", err)
+	}
 
 	return Decompose(hist)
 }
 
+// DecomposeFromDataAsymmetric как DecomposeFromData, но с отдельным числом
+// бинов для target (первый столбец) и для всех агентов.
+//
+// Target часто требует более тонкого разрешения, чем агенты, чтобы точно
+// оценить InfoLeak: здесь не нужно вручную собирать полный срез bins.
+//
+// Пример:
+//
+//	// target: 20 бинов, каждый агент: 8 бинов
+//	result, err := DecomposeFromDataAsymmetric(data, 20, 8)
+func DecomposeFromDataAsymmetric(data [][]float64, targetBins, sourceBins int) (*Result, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is empty")
+	}
+	return DecomposeFromData(data, asymmetricBins(len(data[0]), targetBins, sourceBins))
+}
+
+// asymmetricBins возвращает bins длины nCols: targetBins для столбца 0,
+// sourceBins для остальных.
+func asymmetricBins(nCols, targetBins, sourceBins int) []int {
+	bins := make([]int, nCols)
+	for i := range bins {
+		bins[i] = sourceBins
+	}
+	if nCols > 0 {
+		bins[0] = targetBins
+	}
+	return bins
+}
+
 // --- Helper functions ---
 
+// prescreenAgents возвращает маску агентов, у которых I(target; agent) < threshold.
+// При threshold <= 0 отбор отключен и возвращается nil.
+func prescreenAgents(arr *entropy.NDArray, nvars int, threshold float64) []bool {
+	if threshold <= 0 {
+		return nil
+	}
+	pruned := make([]bool, nvars)
+	for a := 0; a < nvars; a++ {
+		pruned[a] = entropy.MutualInformation(arr, []int{0}, []int{a + 1}) < threshold
+	}
+	return pruned
+}
+
+// deterministicTolerance — порог (бит), ниже которого энтропия считается нулевой.
+// Покрывает вклад сглаживания гистограммы (1e-14 на бин).
+const deterministicTolerance = 1e-8
+
+// correctEntropies пересчитывает утечку и MutualInfo оценщиком opts.EntropyEstimator.
+// Для PluginEntropy ничего не меняет. Комбинации с нулевым MI (отсеянные
+// предварительным отбором) остаются нулевыми.
+func correctEntropies(res *Result, arr *entropy.NDArray, nvars int, opts Options) {
+	if opts.EntropyEstimator != GrassbergerEntropy {
+		return
+	}
+
+	// H по осям: маргинальные частоты = вероятности × N
+	h := func(axes []int) float64 {
+		counts := marginalizeNDArray(arr, axes)
+		for i := range counts {
+			counts[i] *= float64(opts.NSamples)
+		}
+		return entropy.EntropyGrassberger(counts, opts.NSamples)
+	}
+
+	agents := make([]int, nvars)
+	for i := range agents {
+		agents[i] = i + 1
+	}
+	hTarget := h([]int{0})
+	hCondTarget := math.Max(0, h(append([]int{0}, agents...))-h(agents))
+
+	res.LeakBits = hCondTarget
+	res.InfoLeak = 0
+	if hTarget > 0 {
+		res.InfoLeak = math.Min(1, hCondTarget/hTarget)
+	}
+
+	for key, mi := range res.MutualInfo {
+		if mi == 0 {
+			continue
+		}
+		axes := keyToComb(key)
+		for i := range axes {
+			axes[i]++ // +1 потому что target = axis 0
+		}
+		res.MutualInfo[key] = hTarget + h(axes) - h(append([]int{0}, axes...))
+	}
+}
+
+// deterministicShortcut возвращает точное разложение, если target полностью
+// определяется агентами без синергии, иначе nil.
+//
+// Условия:
+//   - H(target|agents) ≈ 0 (нет утечки);
+//   - множество D агентов, каждый из которых сам определяет target
+//     (H(target|a) ≈ 0), не пусто;
+//   - остальные агенты совместно независимы от target: I(target; agents \ D) ≈ 0.
+//
+// Тогда вся информация H(target) — уникальная для единственного агента D или
+// избыточная для комбинации D, а синергия и утечка равны нулю в точности
+// (без шума порядка 1e-15 от полного перебора комбинаций).
+//
+// pruned — результат prescreenAgents. Агенты D имеют MI = H(target); если
+// они отсеяны (H(target) ниже PrescreenThreshold), возвращается nil, и
+// основной путь обнуляет их компоненты.
+func deterministicShortcut(arr *entropy.NDArray, nvars int, hTarget, hCondTarget float64, pruned []bool, opts Options) *Result {
+	if hCondTarget > deterministicTolerance || hTarget <= deterministicTolerance {
+		return nil
+	}
+
+	var determining, others []int
+	for a := 0; a < nvars; a++ {
+		if entropy.ConditionalEntropy(arr, []int{0}, []int{a + 1}) <= deterministicTolerance {
+			determining = append(determining, a)
+		} else {
+			others = append(others, a+1)
+		}
+	}
+	if len(determining) == 0 || allPruned(determining, pruned) {
+		return nil
+	}
+	if len(determining) > 2 && opts.RedundancyAttribution == MIProportional {
+		// Распределение по парам требует полного алгоритма
+		return nil
+	}
+	if len(others) > 0 && entropy.MutualInformation(arr, []int{0}, others) > deterministicTolerance {
+		return nil
+	}
+
+	isDetermining := make([]bool, nvars)
+	for _, a := range determining {
+		isDetermining[a] = true
+	}
+
+	redundant := make(map[string]float64)
+	unique := make(map[string]float64)
+	synergistic := make(map[string]float64)
+	mutualInfo := make(map[string]float64)
+	for _, comb := range latticeCombinations(nvars, opts) {
+		key := combToKey(comb)
+		if len(comb) == 1 {
+			unique[key] = 0
+		} else {
+			redundant[key] = 0
+			synergistic[key] = 0
+		}
+
+		// I(target; comb) = H(target), если comb содержит агента из D, иначе 0
+		mutualInfo[key] = 0
+		for _, a := range comb {
+			if isDetermining[a] {
+				mutualInfo[key] = hTarget
+				break
+			}
+		}
+	}
+
+	if len(determining) == 1 {
+		unique[combToKey(determining)] = hTarget
+	} else {
+		redundant[combToKey(determining)] = hTarget
+	}
+
+	return &Result{
+		Redundant:   redundant,
+		Unique:      unique,
+		Synergistic: synergistic,
+		MutualInfo:  mutualInfo,
+	}
+}
+
+// testHookPrescreenSkip, если задан, вызывается для каждой комбинации,
+// пропущенной предварительным отбором (из нескольких горутин). Только для тестов.
+var testHookPrescreenSkip func(comb []int)
+
+// allPruned возвращает true, если все агенты комбинации отсеяны.
+func allPruned(comb []int, pruned []bool) bool {
+	if pruned == nil {
+		return false
+	}
+	for _, a := range comb {
+		if !pruned[a] {
+			return false
+		}
+	}
+	return true
+}
+
+// distributeRedundancy распределяет избыточный инкремент info по всем парам агентов из vars.
+// Вес пары {a, b} = specific MI агента a + specific MI агента b для состояния target t.
+// Если все веса нулевые, инкремент делится поровну.
+func distributeRedundancy(redundant map[string]float64, vars []int, specificMI map[string][]float64, t int, info float64) {
+	var pairs [][]int
+	var weights []float64
+	total := 0.0
+	for i := 0; i < len(vars); i++ {
+		for j := i + 1; j < len(vars); j++ {
+			pair := []int{vars[i], vars[j]}
+			w := specificMI[combToKey(pair[:1])][t] + specificMI[combToKey(pair[1:])][t]
+			pairs = append(pairs, pair)
+			weights = append(weights, w)
+			total += w
+		}
+	}
+
+	for i, pair := range pairs {
+		share := 1.0 / float64(len(pairs))
+		if total > 0 {
+			share = weights[i] / total
+		}
+		redundant[combToKey(pair)] += info * share
+	}
+}
+
 // generateCombinations генерирует все комбинации индексов агентов от 1 до nvars.
 // Возвращает список комбинаций, где каждая комбинация = slice индексов (0-based).
 // Например, для nvars=3: [[0], [1], [2], [0,1], [0,2], [1,2], [0,1,2]]
 func generateCombinations(nvars int) [][]int {
-	result := [][]int{}
+	return latticeCombinations(nvars, Options{})
+}
 
-	for length := 1; length <= nvars; length++ {
-		combos := combinations(nvars, length)
-		result = append(result, combos...)
+// latticeCombinations возвращает комбинации агентов, которые перебирает
+// декомпозиция: все комбинации длины от 1 до opts.MaxOrder (до nvars, если
+// MaxOrder = 0 или больше nvars), в порядке generateCombinations.
+func latticeCombinations(nvars int, opts Options) [][]int {
+	maxOrder := opts.MaxOrder
+	if maxOrder <= 0 || maxOrder > nvars {
+		maxOrder = nvars
 	}
 
+	result := [][]int{}
+	for length := 1; length <= maxOrder; length++ {
+		result = append(result, combinations(nvars, length)...)
+	}
 	return result
 }
 
@@ -1,8 +1,14 @@
 package surd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/causalgo/causalgo/internal/histogram"
 )
@@ -49,6 +55,96 @@ func TestDecompose_DeterministicSystem(t *testing.T) {
 	t.Logf("  InfoLeak: %f", result.InfoLeak)
 }
 
+// TestDecompose_DeterministicShortcut tests the exact path for targets fully
+// determined by single agents: no synergy or leak noise, clean attribution.
+func TestDecompose_DeterministicShortcut(t *testing.T) {
+	// target = x, agents [x, x, z] with z exactly independent of x
+	// (every (x, z) pair appears once)
+	data := [][]float64{}
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			data = append(data, []float64{float64(i), float64(i), float64(i), float64(j)})
+		}
+	}
+	hTarget := math.Log2(10)
+
+	result, err := DecomposeFromData(data, []int{10, 10, 10, 10})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	if result.InfoLeak != 0 || result.LeakBits != 0 {
+		t.Errorf("InfoLeak = %g, LeakBits = %g, want exactly 0", result.InfoLeak, result.LeakBits)
+	}
+	for key, v := range result.Synergistic {
+		if v != 0 {
+			t.Errorf("Synergistic[%s] = %g, want exactly 0", key, v)
+		}
+	}
+	for key, v := range result.Unique {
+		if v != 0 {
+			t.Errorf("Unique[%s] = %g, want exactly 0 (information is redundant)", key, v)
+		}
+	}
+	for key, v := range result.Redundant {
+		want := 0.0
+		if key == "0,1" {
+			want = hTarget
+		}
+		if math.Abs(v-want) > 1e-9 {
+			t.Errorf("Redundant[%s] = %g, want %g", key, v, want)
+		}
+	}
+	if math.Abs(result.MutualInfo["0,2"]-hTarget) > 1e-9 || result.MutualInfo["2"] != 0 {
+		t.Errorf("MutualInfo[0,2] = %g, MutualInfo[2] = %g, want %g and 0",
+			result.MutualInfo["0,2"], result.MutualInfo["2"], hTarget)
+	}
+
+	// Single determining agent: all information is unique
+	single := make([][]float64, len(data))
+	for i, row := range data {
+		single[i] = []float64{row[0], row[1], row[3]}
+	}
+	result, err = DecomposeFromData(single, []int{10, 10, 10})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	if math.Abs(result.Unique["0"]-hTarget) > 1e-9 || result.Unique["1"] != 0 || result.Synergistic["0,1"] != 0 || result.Redundant["0,1"] != 0 {
+		t.Errorf("single agent: U=%v R=%v S=%v, want U[0]=%g only", result.Unique, result.Redundant, result.Synergistic, hTarget)
+	}
+
+	// Threshold above H(target) prunes the determining agent too: the result
+	// matches the main path (all zeros), including the specific MI
+	hist, err := histogram.NewNDHistogram(single, []int{10, 10, 10})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	opts := DefaultOptions()
+	opts.PrescreenThreshold = hTarget + 1
+	opts.KeepSpecificMI = true
+	result, err = DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+	for name, m := range map[string]map[string]float64{
+		"Unique": result.Unique, "Redundant": result.Redundant,
+		"Synergistic": result.Synergistic, "MutualInfo": result.MutualInfo,
+	} {
+		for key, v := range m {
+			if v != 0 {
+				t.Errorf("pruned: %s[%s] = %g, want 0", name, key, v)
+			}
+		}
+	}
+	for key, row := range result.SpecificMI {
+		for s, v := range row {
+			if v != 0 {
+				t.Errorf("pruned: SpecificMI %s[%d] = %g, want 0", key, s, v)
+			}
+		}
+	}
+}
+
 // TestDecompose_IndependentVariables tests SURD on independent variables.
 // Expected: Low mutual information or high InfoLeak.
 // Note: With discrete binning, fully independent variables might still show
@@ -178,6 +274,168 @@ func TestDecompose_RedundantSystem(t *testing.T) {
 	t.Logf("  InfoLeak: %f", result.InfoLeak)
 }
 
+// TestDecomposeWithOptions_RedundancyAttribution compares redundancy attribution modes
+// on a partially redundant system: target = x1, x2 and x3 are noisy copies of x1.
+func TestDecomposeWithOptions_RedundancyAttribution(t *testing.T) {
+	rng := rand.New(rand.NewSource(17)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 20000)
+	for i := range data {
+		x1 := float64(rng.Intn(2))
+		x2, x3 := x1, x1
+		if rng.Float64() < 0.2 {
+			x2 = float64(rng.Intn(2))
+		}
+		if rng.Float64() < 0.4 {
+			x3 = float64(rng.Intn(2))
+		}
+		data[i] = []float64{x1, x1, x2, x3}
+	}
+
+	hist, err := histogram.NewNDHistogram(data, []int{2, 2, 2, 2})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+
+	winner, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+	opts := DefaultOptions()
+	opts.RedundancyAttribution = MIProportional
+	proportional, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+
+	t.Logf("WinnerTakesAll: R=%v", winner.Redundant)
+	t.Logf("MIProportional: R=%v", proportional.Redundant)
+
+	// Default concentrates the shared part in the triple
+	if winner.Redundant["0,1,2"] <= 0.1 {
+		t.Errorf("WinnerTakesAll R[0,1,2] = %.4f, want concentrated redundancy", winner.Redundant["0,1,2"])
+	}
+
+	// Proportional mode moves it onto the pairs: no triple, more pairs populated
+	if proportional.Redundant["0,1,2"] != 0 {
+		t.Errorf("MIProportional R[0,1,2] = %.4f, want 0", proportional.Redundant["0,1,2"])
+	}
+	countPairs := func(r *Result) int {
+		n := 0
+		for _, key := range []string{"0,1", "0,2", "1,2"} {
+			if r.Redundant[key] > 1e-3 {
+				n++
+			}
+		}
+		return n
+	}
+	if countPairs(proportional) <= countPairs(winner) {
+		t.Errorf("MIProportional populates %d pairs, want more than WinnerTakesAll (%d)",
+			countPairs(proportional), countPairs(winner))
+	}
+
+	// Totals are unchanged
+	for _, c := range []struct {
+		name string
+		a, b float64
+	}{
+		{"redundant", sumMap(winner.Redundant), sumMap(proportional.Redundant)},
+		{"unique", sumMap(winner.Unique), sumMap(proportional.Unique)},
+		{"synergistic", sumMap(winner.Synergistic), sumMap(proportional.Synergistic)},
+	} {
+		if math.Abs(c.a-c.b) > tolerance {
+			t.Errorf("total %s differs: %.6f vs %.6f", c.name, c.a, c.b)
+		}
+	}
+}
+
+// TestDecomposeWithOptions_Prescreen compares a pruned run with the full lattice
+// on a system with one informative and three noise sources.
+func TestDecomposeWithOptions_Prescreen(t *testing.T) {
+	hist := prescreenSystem(t)
+
+	opts := DefaultOptions()
+	opts.PrescreenThreshold = 0.05
+
+	full, err := DecomposeWithOptions(hist, DefaultOptions())
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+
+	// Pruning skips the 7 noise-only combinations of agents 1, 2 and 3
+	var skipped atomic.Int64
+	testHookPrescreenSkip = func(comb []int) {
+		for _, a := range comb {
+			if a == 0 {
+				t.Errorf("informative combination %v was pruned", comb)
+			}
+		}
+		skipped.Add(1)
+	}
+	defer func() { testHookPrescreenSkip = nil }()
+
+	pruned, err := DecomposeWithOptions(hist, opts)
+	if err != nil {
+		t.Fatalf("DecomposeWithOptions failed: %v", err)
+	}
+	if got := skipped.Load(); got != 7 {
+		t.Errorf("skipped %d combinations, want 7", got)
+	}
+
+	// Components of combinations containing the informative agent match
+	compare := func(name string, a, b map[string]float64) {
+		for key, v := range a {
+			if !containsAgent(key, 0) {
+				continue
+			}
+			if math.Abs(v-b[key]) > 0.02 {
+				t.Errorf("%s[%s]: full %.4f, pruned %.4f", name, key, v, b[key])
+			}
+		}
+	}
+	compare("Unique", full.Unique, pruned.Unique)
+	compare("Redundant", full.Redundant, pruned.Redundant)
+	compare("Synergistic", full.Synergistic, pruned.Synergistic)
+
+	// Noise-only combinations carry nothing
+	if pruned.MutualInfo["1,2,3"] != 0 || pruned.Synergistic["1,2,3"] != 0 {
+		t.Errorf("pruned noise combination: MI=%.4f S=%.4f, want 0",
+			pruned.MutualInfo["1,2,3"], pruned.Synergistic["1,2,3"])
+	}
+	t.Logf("Unique[0]: full %.4f, pruned %.4f", full.Unique["0"], pruned.Unique["0"])
+}
+
+// prescreenSystem builds a 6-bin system whose target copies agent 0 with 20%
+// noise; agents 1-3 are independent noise.
+func prescreenSystem(tb testing.TB) *histogram.NDHistogram {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(23)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 50000)
+	for i := range data {
+		x := rng.Intn(6)
+		target := x
+		if rng.Float64() < 0.2 {
+			target = rng.Intn(6)
+		}
+		data[i] = []float64{float64(target), float64(x), float64(rng.Intn(6)), float64(rng.Intn(6)), float64(rng.Intn(6))}
+	}
+
+	hist, err := histogram.NewNDHistogram(data, []int{6, 6, 6, 6, 6})
+	if err != nil {
+		tb.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	return hist
+}
+
+// containsAgent reports whether a comma-separated key includes agent a.
+func containsAgent(key string, a int) bool {
+	for _, c := range keyToComb(key) {
+		if c == a {
+			return true
+		}
+	}
+	return false
+}
+
 // TestDecomposeFromData_ErrorCases tests error handling
 func TestDecomposeFromData_ErrorCases(t *testing.T) {
 	tests := []struct {
@@ -194,10 +452,16 @@ func TestDecomposeFromData_ErrorCases(t *testing.T) {
 		},
 		{
 			name:    "too few variables",
-			data:    [][]float64{{1.0}},
+			data:    [][]float64{{1.0}, {2.0}},
 			bins:    []int{10},
 			wantErr: true,
 		},
+		{
+			name:    "single sample",
+			data:    [][]float64{{1.0, 2.0}},
+			bins:    []int{2, 2},
+			wantErr: true,
+		},
 		{
 			name:    "mismatched bins",
 			data:    [][]float64{{1.0, 2.0}, {3.0, 4.0}},
@@ -222,6 +486,110 @@ func TestDecomposeFromData_ErrorCases(t *testing.T) {
 	}
 }
 
+// TestDecomposeFromData_SingleBin tests the degenerate bins=[1,1] case.
+// Expected: H(target) = 0, so every component is zero and InfoLeak is 0 (not NaN).
+func TestDecomposeFromData_SingleBin(t *testing.T) {
+	data := [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+
+	result, err := DecomposeFromData(data, []int{1, 1})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	if math.IsNaN(result.InfoLeak) || result.InfoLeak != 0 {
+		t.Errorf("InfoLeak = %f, want 0", result.InfoLeak)
+	}
+
+	for name, m := range map[string]map[string]float64{
+		"Unique":      result.Unique,
+		"Redundant":   result.Redundant,
+		"Synergistic": result.Synergistic,
+		"MutualInfo":  result.MutualInfo,
+	} {
+		for key, val := range m {
+			if math.IsNaN(val) || math.Abs(val) > tolerance {
+				t.Errorf("%s[%s] = %f, want 0", name, key, val)
+			}
+		}
+	}
+}
+
+// cancelAfterContext cancels itself once Err has been called n times, to
+// cancel a decomposition while it is running. Err may be called concurrently.
+type cancelAfterContext struct {
+	context.Context
+	cancel context.CancelFunc
+	n      atomic.Int64
+}
+
+func newCancelAfterContext(n int64) *cancelAfterContext {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &cancelAfterContext{Context: ctx, cancel: cancel}
+	c.n.Store(n)
+	return c
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.n.Add(-1) < 0 {
+		c.cancel()
+	}
+	return c.Context.Err()
+}
+
+// TestDecomposeFromDataContext tests that a live context gives the same result
+// as DecomposeFromData and that cancellation before or during the run aborts
+// with ctx.Err() and no result.
+func TestDecomposeFromDataContext(t *testing.T) {
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 2000)
+	for i := range data {
+		a, b := rng.Float64(), rng.Float64()
+		data[i] = []float64{a + b + 0.3*rng.Float64(), a, b, rng.Float64()}
+	}
+	bins := []int{4, 4, 4, 4}
+
+	want, err := DecomposeFromData(data, bins)
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	got, err := DecomposeFromDataContext(context.Background(), data, bins)
+	if err != nil {
+		t.Fatalf("DecomposeFromDataContext failed: %v", err)
+	}
+	for key, v := range want.Synergistic {
+		if got.Synergistic[key] != v {
+			t.Errorf("Synergistic[%s] = %v, want %v", key, got.Synergistic[key], v)
+		}
+	}
+	if got.InfoLeak != want.InfoLeak {
+		t.Errorf("InfoLeak = %v, want %v", got.InfoLeak, want.InfoLeak)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result, err := DecomposeFromDataContext(ctx, data, bins); !errors.Is(err, context.Canceled) || result != nil {
+		t.Errorf("canceled context: result = %v, err = %v; want nil, context.Canceled", result, err)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := DecomposeFromDataContext(ctx, data, bins); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expired deadline: err = %v, want context.DeadlineExceeded", err)
+	}
+
+	// Canceled mid-run, after a few cancellation checks have passed
+	for _, n := range []int64{1, 3, 8} {
+		ctx := newCancelAfterContext(n)
+		result, err := DecomposeFromDataContext(ctx, data, bins)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("canceled after %d checks: err = %v, want context.Canceled", n, err)
+		}
+		if result != nil {
+			t.Errorf("canceled after %d checks: got a partial result", n)
+		}
+	}
+}
+
 // TestDecompose_NilHistogram tests nil histogram handling
 func TestDecompose_NilHistogram(t *testing.T) {
 	_, err := Decompose(nil)
@@ -559,3 +927,314 @@ func BenchmarkDecompose_LargeSystem(b *testing.B) {
 		}
 	}
 }
+
+// TestDecomposeWithOptions_Workers tests that the parallel combination loop
+// gives bit-identical results for any number of workers.
+func TestDecomposeWithOptions_Workers(t *testing.T) {
+	hist := randomSystem(t, 5000, 5, 3, 17)
+
+	for _, base := range []struct {
+		name string
+		opts Options
+	}{
+		{"default", DefaultOptions()},
+		{"debiased", Options{DebiasedMI: true, NSamples: 5000}},
+		{"prescreen", Options{PrescreenThreshold: 0.05}},
+	} {
+		t.Run(base.name, func(t *testing.T) {
+			serialOpts := base.opts
+			serialOpts.Workers = 1
+			want, err := DecomposeWithOptions(hist, serialOpts)
+			if err != nil {
+				t.Fatalf("serial DecomposeWithOptions failed: %v", err)
+			}
+
+			for _, workers := range []int{0, 2, 8, 100} {
+				opts := base.opts
+				opts.Workers = workers
+				got, err := DecomposeWithOptions(hist, opts)
+				if err != nil {
+					t.Fatalf("Workers=%d: DecomposeWithOptions failed: %v", workers, err)
+				}
+				for name, m := range map[string][2]map[string]float64{
+					"Redundant":   {got.Redundant, want.Redundant},
+					"Unique":      {got.Unique, want.Unique},
+					"Synergistic": {got.Synergistic, want.Synergistic},
+					"MutualInfo":  {got.MutualInfo, want.MutualInfo},
+				} {
+					if len(m[0]) != len(m[1]) {
+						t.Errorf("Workers=%d: %s has %d entries, want %d", workers, name, len(m[0]), len(m[1]))
+					}
+					for key, v := range m[1] {
+						if m[0][key] != v {
+							t.Errorf("Workers=%d: %s[%s] = %v, want %v", workers, name, key, m[0][key], v)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecompose_Workers compares the serial and parallel combination loop
+// on 6 agents (63 combinations).
+func BenchmarkDecompose_Workers(b *testing.B) {
+	hist := randomSystem(b, 20000, 6, 4, 3)
+
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			opts := DefaultOptions()
+			opts.Workers = workers
+			for i := 0; i < b.N; i++ {
+				if _, err := DecomposeWithOptions(hist, opts); err != nil {
+					b.Fatalf("DecomposeWithOptions failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecomposeWithOptions_Prescreen compares the full lattice with a run
+// that prunes the noise-only combinations.
+func BenchmarkDecomposeWithOptions_Prescreen(b *testing.B) {
+	hist := prescreenSystem(b)
+
+	for _, threshold := range []float64{0, 0.05} {
+		b.Run(fmt.Sprintf("threshold=%g", threshold), func(b *testing.B) {
+			opts := DefaultOptions()
+			opts.PrescreenThreshold = threshold
+			for i := 0; i < b.N; i++ {
+				if _, err := DecomposeWithOptions(hist, opts); err != nil {
+					b.Fatalf("DecomposeWithOptions failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestDecompose_Renormalization tests that probabilities drifting from 1 are
+// rescaled, and that grossly unnormalized ones are rejected.
+func TestDecompose_Renormalization(t *testing.T) {
+	rng := rand.New(rand.NewSource(91)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 5000)
+	for i := range data {
+		x1, x2 := rng.Intn(3), rng.Intn(3)
+		data[i] = []float64{float64((x1 + x2) % 3), float64(x1), float64(x2)}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{3, 3, 3})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	want, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	drifted := hist.Probabilities()
+	for i := range drifted {
+		drifted[i] *= 1.0001
+	}
+	got, err := decomposeProbs(context.Background(), drifted, hist.Shape(), DefaultOptions())
+	if err != nil {
+		t.Fatalf("decomposeProbs failed for sum 1.0001: %v", err)
+	}
+
+	components := []struct {
+		name      string
+		got, want map[string]float64
+	}{
+		{"redundant", got.Redundant, want.Redundant},
+		{"unique", got.Unique, want.Unique},
+		{"synergistic", got.Synergistic, want.Synergistic},
+		{"mutual info", got.MutualInfo, want.MutualInfo},
+	}
+	for _, c := range components {
+		for key, w := range c.want {
+			if math.Abs(c.got[key]-w) > 1e-12 {
+				t.Errorf("%s[%s] = %v, want %v", c.name, key, c.got[key], w)
+			}
+		}
+	}
+	if math.Abs(got.InfoLeak-want.InfoLeak) > 1e-12 {
+		t.Errorf("InfoLeak = %v, want %v", got.InfoLeak, want.InfoLeak)
+	}
+
+	scaled := hist.Probabilities()
+	for i := range scaled {
+		scaled[i] *= 0.1
+	}
+	if _, err := decomposeProbs(context.Background(), scaled, hist.Shape(), DefaultOptions()); err == nil {
+		t.Error("expected error for probabilities summing to 0.1")
+	}
+}
+
+// TestDecomposeFromDataAsymmetric tests separate target and source bin counts.
+func TestDecomposeFromDataAsymmetric(t *testing.T) {
+	rng := rand.New(rand.NewSource(92)) //nolint:gosec // deterministic test data
+	n := 50000
+	data := make([][]float64, n)
+	for i := range data {
+		x1, x2 := rng.Float64(), rng.Float64()
+		data[i] = []float64{x1 + x2 + 0.3*rng.NormFloat64(), x1, x2}
+	}
+
+	bins := asymmetricBins(3, 20, 8)
+	hist, err := histogram.NewNDHistogram(data, bins)
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	if shape := hist.Shape(); shape[0] != 20 || shape[1] != 8 || shape[2] != 8 {
+		t.Errorf("shape = %v, want [20 8 8]", shape)
+	}
+
+	fine, err := DecomposeFromDataAsymmetric(data, 20, 8)
+	if err != nil {
+		t.Fatalf("DecomposeFromDataAsymmetric failed: %v", err)
+	}
+	want, err := DecomposeFromData(data, bins)
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	if fine.InfoLeak != want.InfoLeak {
+		t.Errorf("InfoLeak = %v, want %v (same as explicit bins)", fine.InfoLeak, want.InfoLeak)
+	}
+
+	// A finer target resolves the noise the agents cannot explain, so a
+	// larger fraction of H(target) is left unexplained.
+	coarse, err := DecomposeFromDataAsymmetric(data, 4, 8)
+	if err != nil {
+		t.Fatalf("DecomposeFromDataAsymmetric failed: %v", err)
+	}
+	if fine.InfoLeak <= coarse.InfoLeak {
+		t.Errorf("InfoLeak with 20 target bins (%.4f) should exceed 4 bins (%.4f)", fine.InfoLeak, coarse.InfoLeak)
+	}
+	t.Logf("InfoLeak: target 4 bins %.4f, 20 bins %.4f", coarse.InfoLeak, fine.InfoLeak)
+
+	if _, err := DecomposeFromDataAsymmetric(nil, 20, 8); err == nil {
+		t.Error("expected error for empty data")
+	}
+	if _, err := DecomposeFromDataAsymmetric(data, 0, 8); err == nil {
+		t.Error("expected error for zero target bins")
+	}
+}
+
+// TestDecomposeSubsets checks that truncating the lattice at maxOrder leaves
+// every component of order <= maxOrder unchanged and only omits higher-order
+// synergy.
+func TestDecomposeSubsets(t *testing.T) {
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test data
+	data := make([][]float64, 20000)
+	for i := range data {
+		x := []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+		// Pairwise and triple interactions plus a redundant copy of x0
+		target := x[0] + 0.5*math.Sin(6*x[1]*x[2]) + float64(int(3*x[1])^int(3*x[2])^int(3*x[3])) + 0.2*rng.NormFloat64()
+		data[i] = []float64{target, x[0], x[1], x[2], x[3]}
+	}
+	hist, err := histogram.NewNDHistogram(data, []int{4, 3, 3, 3, 3})
+	if err != nil {
+		t.Fatalf("NewNDHistogram failed: %v", err)
+	}
+	full, err := Decompose(hist)
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	for maxOrder := 1; maxOrder <= 4; maxOrder++ {
+		sub, err := DecomposeSubsets(hist, maxOrder)
+		if err != nil {
+			t.Fatalf("maxOrder=%d: DecomposeSubsets failed: %v", maxOrder, err)
+		}
+
+		for key, v := range full.Unique {
+			if math.Abs(sub.Unique[key]-v) > 1e-12 {
+				t.Errorf("maxOrder=%d: U[%s] = %v, full %v", maxOrder, key, sub.Unique[key], v)
+			}
+		}
+		for key, v := range full.Redundant {
+			if math.Abs(sub.Redundant[key]-v) > 1e-12 {
+				t.Errorf("maxOrder=%d: R[%s] = %v, full %v", maxOrder, key, sub.Redundant[key], v)
+			}
+		}
+		omitted := 0.0
+		for key, v := range full.Synergistic {
+			if len(keyToComb(key)) > maxOrder {
+				if _, ok := sub.Synergistic[key]; ok {
+					t.Errorf("maxOrder=%d: unexpected synergy key %s", maxOrder, key)
+				}
+				omitted += v
+				continue
+			}
+			if math.Abs(sub.Synergistic[key]-v) > 1e-12 {
+				t.Errorf("maxOrder=%d: S[%s] = %v, full %v", maxOrder, key, sub.Synergistic[key], v)
+			}
+		}
+		for key := range sub.MutualInfo {
+			if len(keyToComb(key)) > maxOrder {
+				t.Errorf("maxOrder=%d: unexpected MI key %s", maxOrder, key)
+			}
+		}
+		if sub.InfoLeak != full.InfoLeak {
+			t.Errorf("maxOrder=%d: InfoLeak = %v, full %v", maxOrder, sub.InfoLeak, full.InfoLeak)
+		}
+
+		// The omitted higher-order synergy accounts for the whole difference
+		total := func(r *Result) float64 { return sumMap(r.Redundant) + sumMap(r.Unique) + sumMap(r.Synergistic) }
+		if diff := total(full) - total(sub); math.Abs(diff-omitted) > 1e-9 {
+			t.Errorf("maxOrder=%d: total differs by %v, omitted synergy %v", maxOrder, diff, omitted)
+		}
+		if maxOrder == 2 && omitted <= 0 {
+			t.Errorf("expected positive synergy above order 2 in the test system")
+		}
+	}
+
+	if _, err := DecomposeSubsets(hist, 0); err == nil {
+		t.Error("expected error for maxOrder 0")
+	}
+	opts := DefaultOptions()
+	opts.MaxOrder = -1
+	if _, err := DecomposeWithOptions(hist, opts); err == nil {
+		t.Error("expected error for negative MaxOrder")
+	}
+}
+
+// TestDecomposeFromDiscreteData checks the binning-free path on XOR and that
+// it matches DecomposeFromData when binning is exact.
+func TestDecomposeFromDiscreteData(t *testing.T) {
+	rng := rand.New(rand.NewSource(11)) //nolint:gosec // deterministic test data
+	data := make([][]int, 4000)
+	floatData := make([][]float64, len(data))
+	for i := range data {
+		a, b := rng.Intn(2), rng.Intn(2)
+		data[i] = []int{a ^ b, a, b}
+		floatData[i] = []float64{float64(a ^ b), float64(a), float64(b)}
+	}
+
+	result, err := DecomposeFromDiscreteData(data, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromDiscreteData failed: %v", err)
+	}
+	if s := result.Synergistic["0,1"]; math.Abs(s-1) > 0.01 {
+		t.Errorf("expected ~1 bit of synergy for XOR, got %v", s)
+	}
+	if u := result.Unique["0"] + result.Unique["1"]; u > 0.01 {
+		t.Errorf("expected no unique information for XOR, got %v", u)
+	}
+
+	want, err := DecomposeFromData(floatData, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	if math.Abs(result.Synergistic["0,1"]-want.Synergistic["0,1"]) > 1e-12 || math.Abs(result.InfoLeak-want.InfoLeak) > 1e-12 {
+		t.Errorf("discrete result %+v differs from binned %+v", result, want)
+	}
+
+	if _, err := DecomposeFromDiscreteData(data[:1], []int{2, 2, 2}); err == nil {
+		t.Error("expected error for a single sample")
+	}
+	if _, err := DecomposeFromDiscreteData(data, []int{2, 2}); err == nil {
+		t.Error("expected error for levels length mismatch")
+	}
+	if _, err := DecomposeFromDiscreteData([][]int{{0, 2}, {1, 0}}, []int{2, 2}); err == nil {
+		t.Error("expected error for a value outside its levels")
+	}
+}
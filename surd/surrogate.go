@@ -0,0 +1,131 @@
+package surd
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+
+	"gonum.org/v1/gonum/dsp/fourier"
+)
+
+// SurrogateMethod selects how DecomposeWithSignificanceOptions builds
+// surrogate targets for the null distribution.
+type SurrogateMethod int
+
+const (
+	// Shuffle permutes the target samples independently (default). Correct
+	// for i.i.d. samples; on autocorrelated series it also destroys the
+	// target's own memory and overstates significance.
+	Shuffle SurrogateMethod = iota
+
+	// BlockShuffle permutes contiguous blocks of the target series, keeping
+	// its autocorrelation within each block.
+	BlockShuffle
+
+	// PhaseRandomization randomizes the Fourier phases of the target series,
+	// keeping its power spectrum (hence its autocorrelation) exactly.
+	PhaseRandomization
+)
+
+// String returns the method name.
+func (m SurrogateMethod) String() string {
+	switch m {
+	case Shuffle:
+		return "shuffle"
+	case BlockShuffle:
+		return "block-shuffle"
+	case PhaseRandomization:
+		return "phase-randomization"
+	default:
+		return fmt.Sprintf("SurrogateMethod(%d)", int(m))
+	}
+}
+
+// SignificanceOptions configures DecomposeWithSignificanceOptions.
+type SignificanceOptions struct {
+	// Method is the surrogate generator for the target column.
+	Method SurrogateMethod
+
+	// BlockSize is the block length for BlockShuffle; it should exceed the
+	// target's autocorrelation time. 0 means round(sqrt(samples)).
+	BlockSize int
+
+	// Workers is the number of permutations decomposed concurrently, and the
+	// Options.Workers of each decomposition (<= 0 means runtime.GOMAXPROCS(0),
+	// 1 runs serially). P-values do not depend on Workers.
+	Workers int
+}
+
+// surrogateTarget returns a copy of data with the target column (column 0)
+// replaced by a surrogate of itself.
+func surrogateTarget(data [][]float64, rng *rand.Rand, opts SignificanceOptions) [][]float64 {
+	target := make([]float64, len(data))
+	for i, row := range data {
+		target[i] = row[0]
+	}
+
+	var surrogate []float64
+	switch opts.Method {
+	case BlockShuffle:
+		surrogate = blockShuffle(target, opts.BlockSize, rng)
+	case PhaseRandomization:
+		surrogate = phaseRandomize(target, rng)
+	default:
+		surrogate = make([]float64, len(target))
+		for i, j := range rng.Perm(len(target)) {
+			surrogate[i] = target[j]
+		}
+	}
+
+	out := make([][]float64, len(data))
+	for i, row := range data {
+		out[i] = make([]float64, len(row))
+		copy(out[i], row)
+		out[i][0] = surrogate[i]
+	}
+	return out
+}
+
+// blockShuffle concatenates the blocks of x (length blockSize, the last one
+// possibly shorter) in random order. blockSize <= 0 means round(sqrt(len(x))).
+func blockShuffle(x []float64, blockSize int, rng *rand.Rand) []float64 {
+	n := len(x)
+	if blockSize <= 0 {
+		blockSize = max(1, int(math.Round(math.Sqrt(float64(n)))))
+	}
+	nblocks := (n + blockSize - 1) / blockSize
+
+	out := make([]float64, 0, n)
+	for _, b := range rng.Perm(nblocks) {
+		out = append(out, x[b*blockSize:min((b+1)*blockSize, n)]...)
+	}
+	return out
+}
+
+// phaseRandomize returns a series with the same power spectrum as x and
+// uniformly random Fourier phases. The mean (zero frequency) and, for even
+// lengths, the Nyquist component are kept so the result stays real.
+func phaseRandomize(x []float64, rng *rand.Rand) []float64 {
+	n := len(x)
+	if n < 3 {
+		out := make([]float64, n)
+		copy(out, x)
+		return out
+	}
+
+	fft := fourier.NewFFT(n)
+	coeffs := fft.Coefficients(nil, x)
+	for k := 1; k < len(coeffs); k++ {
+		if 2*k == n {
+			continue // Nyquist component must stay real
+		}
+		coeffs[k] *= cmplx.Rect(1, 2*math.Pi*rng.Float64())
+	}
+
+	out := fft.Sequence(nil, coeffs)
+	for i := range out {
+		out[i] /= float64(n) // Sequence is unnormalized
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package surd
+
+import "strconv"
+
+// SynergyExcess returns, for every pair of agents, how much their synergy
+// exceeds what they provide individually:
+//
+//	excess[i,j] = Synergistic[i,j] - (Unique[i] + Unique[j])
+//
+// Positive values flag pairs whose joint effect exceeds the sum of the parts
+// (XOR-like interactions); values near zero or negative mean the pair adds
+// little beyond its members. Keys are pair keys as in Result ("0,1", ...);
+// higher-order synergies are not included. Returns nil for a nil result.
+//
+// Example:
+//
+//	result, _ := DecomposeFromData(data, []int{8, 8, 8, 8})
+//	for pair, excess := range SynergyExcess(result) {
+//	    if excess > 0.1 {
+//	        fmt.Printf("pair %s is synergistic (+%.2f bits)\n", pair, excess)
+//	    }
+//	}
+func SynergyExcess(result *Result) map[string]float64 {
+	if result == nil {
+		return nil
+	}
+
+	excess := make(map[string]float64)
+	for key, syn := range result.Synergistic {
+		idx := KeyToIndices(key)
+		if len(idx) != 2 {
+			continue
+		}
+		excess[key] = syn - result.Unique[strconv.Itoa(idx[0])] - result.Unique[strconv.Itoa(idx[1])]
+	}
+	return excess
+}
+
+// SynergyAttribution distributes each synergistic component equally among its
+// member agents and returns the per-agent total in bits:
+//
+//	attribution[i] = Σ_{c ∋ i} Synergistic[c] / |c|
+//
+// The score measures how much of the synergy each agent takes part in, i.e.
+// which source would be most worth observing better to exploit information
+// that is only available jointly. The scores sum to the total synergy.
+// Agents without any synergy are omitted. Returns nil for a nil result.
+//
+// Example:
+//
+//	for agent, bits := range SynergyAttribution(result) {
+//	    fmt.Printf("agent %d: %.3f bits of synergy\n", agent, bits)
+//	}
+func SynergyAttribution(result *Result) map[int]float64 {
+	if result == nil {
+		return nil
+	}
+
+	attribution := make(map[int]float64)
+	for key, syn := range result.Synergistic {
+		idx := KeyToIndices(key)
+		if len(idx) == 0 || syn == 0 {
+			continue
+		}
+		share := syn / float64(len(idx))
+		for _, agent := range idx {
+			attribution[agent] += share
+		}
+	}
+	return attribution
+}
@@ -0,0 +1,112 @@
+package surd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSynergyExcess(t *testing.T) {
+	rng := rand.New(rand.NewSource(11)) //nolint:gosec // deterministic test data
+	n := 20000
+
+	// xor: target = x1 XOR x2; independent: target unrelated to x1, x2
+	xor := make([][]float64, n)
+	independent := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		x1, x2 := rng.Intn(2), rng.Intn(2)
+		xor[i] = []float64{float64(x1 ^ x2), float64(x1), float64(x2)}
+		independent[i] = []float64{float64(rng.Intn(2)), float64(x1), float64(x2)}
+	}
+
+	result, err := DecomposeFromData(xor, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	excess := SynergyExcess(result)
+	if len(excess) != 1 {
+		t.Fatalf("expected one pair, got %v", excess)
+	}
+	if excess["0,1"] < 0.9 {
+		t.Errorf("XOR: excess = %.4f, want ~1 bit", excess["0,1"])
+	}
+
+	result, err = DecomposeFromData(independent, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	if e := SynergyExcess(result)["0,1"]; e > 0.01 {
+		t.Errorf("independent inputs: excess = %.4f, want <= ~0", e)
+	}
+}
+
+func TestSynergyExcess_PairsOnly(t *testing.T) {
+	result := &Result{
+		Unique:      map[string]float64{"0": 0.2, "1": 0.1, "2": 0.3},
+		Synergistic: map[string]float64{"0,1": 0.5, "0,2": 0.4, "1,2": 0.1, "0,1,2": 0.9},
+	}
+
+	excess := SynergyExcess(result)
+	want := map[string]float64{"0,1": 0.2, "0,2": -0.1, "1,2": -0.3}
+	if len(excess) != len(want) {
+		t.Fatalf("got %v, want %v", excess, want)
+	}
+	for key, w := range want {
+		if diff := excess[key] - w; diff > 1e-12 || diff < -1e-12 {
+			t.Errorf("%s: excess = %v, want %v", key, excess[key], w)
+		}
+	}
+
+	if SynergyExcess(nil) != nil {
+		t.Error("expected nil for nil result")
+	}
+}
+
+func TestSynergyAttribution(t *testing.T) {
+	rng := rand.New(rand.NewSource(12)) //nolint:gosec // deterministic test data
+	n := 50000
+
+	// xor: target = x0 XOR x1, symmetric in the two agents.
+	// asym: target = x0 XOR x1 with x2 a noisy copy of x1, so x0 is needed for
+	// every synergy while x1 and x2 substitute for each other.
+	xor := make([][]float64, n)
+	asym := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		x0, x1 := rng.Intn(2), rng.Intn(2)
+		xor[i] = []float64{float64(x0 ^ x1), float64(x0), float64(x1)}
+		x2 := x1
+		if rng.Float64() < 0.1 {
+			x2 = 1 - x1
+		}
+		asym[i] = []float64{float64(x0 ^ x1), float64(x0), float64(x1), float64(x2)}
+	}
+
+	result, err := DecomposeFromData(xor, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	attr := SynergyAttribution(result)
+	if math.Abs(attr[0]-attr[1]) > 1e-9 {
+		t.Errorf("XOR: attribution %v should be equal", attr)
+	}
+	if math.Abs(attr[0]+attr[1]-sumMap(result.Synergistic)) > 1e-9 {
+		t.Errorf("XOR: attribution %v should sum to total synergy %.4f", attr, sumMap(result.Synergistic))
+	}
+	if attr[0] < 0.45 {
+		t.Errorf("XOR: attribution[0] = %.4f, want ~0.5 bit", attr[0])
+	}
+
+	result, err = DecomposeFromData(asym, []int{2, 2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+	attr = SynergyAttribution(result)
+	if attr[0] < 1.5*attr[1] || attr[0] < 1.5*attr[2] {
+		t.Errorf("asymmetric: attribution %v should be skewed toward agent 0", attr)
+	}
+	t.Logf("asymmetric: attribution %v, synergy %v", attr, result.Synergistic)
+
+	if SynergyAttribution(nil) != nil {
+		t.Error("expected nil for nil result")
+	}
+}
@@ -0,0 +1,171 @@
+package surd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Component types used in the component_type column of a tidy table and in
+// FlatEntry.Type.
+const (
+	ComponentDirectedMI  = "directed_mi"
+	ComponentRedundant   = "redundant"
+	ComponentUnique      = "unique"
+	ComponentSynergistic = "synergistic"
+	ComponentMutualInfo  = "mutual_info"
+	ComponentInfoLeak    = "info_leak"
+)
+
+// TidyHeader is the column layout of TidyTable and WriteTidyCSV.
+var TidyHeader = []string{"source", "target", "lag", "component_type", "value"}
+
+// TidyRow is one row of a long-format (tidy) causality table.
+type TidyRow struct {
+	// Source is the source column key, e.g. "0" or "0,2" (comma-separated
+	// column indices of data). Empty for info_leak.
+	Source string
+
+	// Target is the target column index.
+	Target int
+
+	// Lag is the time lag between sources and target.
+	Lag int
+
+	// Component is one of the Component* constants.
+	Component string
+
+	// Value is the component value in bits (info_leak: normalized, as InfoLeak).
+	Value float64
+}
+
+// TidyScreen runs a multi-target, multi-lag screen and returns all results as
+// a long-format table, ready for export to pandas or R.
+//
+// data: matrix [time x variables]
+// targets: column indices to use as targets
+// lags: time lags (> 0) to screen
+// bins: number of bins for each column of data
+//
+// For each target and lag the sources are all variables at time t and the
+// target is data[t+lag][target] (as in RollingDecompose), so source keys refer
+// to columns of data. Each (target, lag) block contains, in order:
+//
+//   - directed_mi: I(X_target(t+lag); X_source(t)) for every single source
+//   - unique: for every single source
+//   - redundant, synergistic: for every combination of two or more sources
+//   - info_leak: one row with an empty source
+//
+// Every combination is listed even when its value is zero, so each block has
+// the same shape: 2·p + 2·(2^p - p - 1) + 1 rows for p variables.
+//
+// Example:
+//
+//	rows, err := TidyScreen(data, []int{0, 1, 2}, []int{1, 5}, []int{8, 8, 8})
+//	f, _ := os.Create("screen.csv")
+//	defer f.Close()
+//	err = WriteTidyCSV(f, rows)
+func TidyScreen(data [][]float64, targets []int, lags []int, bins []int) ([]TidyRow, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is empty")
+	}
+	nvars := len(data[0])
+	if len(bins) != nvars {
+		return nil, fmt.Errorf("bins length (%d) must match number of variables (%d)", len(bins), nvars)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("targets is empty")
+	}
+	if len(lags) == 0 {
+		return nil, fmt.Errorf("lags is empty")
+	}
+	for _, target := range targets {
+		if target < 0 || target >= nvars {
+			return nil, fmt.Errorf("target (%d) out of range [0, %d)", target, nvars)
+		}
+	}
+	for _, lag := range lags {
+		if lag <= 0 {
+			return nil, fmt.Errorf("lag must be positive, got %d", lag)
+		}
+		if len(data)-lag < 2 {
+			return nil, fmt.Errorf("lag (%d) leaves %d samples, need at least 2", lag, len(data)-lag)
+		}
+	}
+	for i, sample := range data {
+		if len(sample) != nvars {
+			return nil, fmt.Errorf("sample %d has length %d, expected %d", i, len(sample), nvars)
+		}
+	}
+
+	combs := generateCombinations(nvars)
+
+	var rows []TidyRow
+	for _, target := range targets {
+		for _, lag := range lags {
+			// Layout: [target future, all variables at time t]
+			lagged := laggedData(data, target, lag)
+			result, err := DecomposeFromData(lagged, append([]int{bins[target]}, bins...))
+			if err != nil {
+				return nil, fmt.Errorf("target %d, lag %d: %w", target, lag, err)
+			}
+
+			add := func(source, component string, value float64) {
+				rows = append(rows, TidyRow{Source: source, Target: target, Lag: lag, Component: component, Value: value})
+			}
+
+			for source := 0; source < nvars; source++ {
+				mi, err := laggedMutualInfo(data, source, target, lag, bins)
+				if err != nil {
+					return nil, fmt.Errorf("target %d, lag %d, source %d: %w", target, lag, source, err)
+				}
+				add(strconv.Itoa(source), ComponentDirectedMI, mi)
+			}
+			for source := 0; source < nvars; source++ {
+				key := strconv.Itoa(source)
+				add(key, ComponentUnique, result.Unique[key])
+			}
+			for _, comb := range combs {
+				if len(comb) >= 2 {
+					key := combToKey(comb)
+					add(key, ComponentRedundant, result.Redundant[key])
+				}
+			}
+			for _, comb := range combs {
+				if len(comb) >= 2 {
+					key := combToKey(comb)
+					add(key, ComponentSynergistic, result.Synergistic[key])
+				}
+			}
+			add("", ComponentInfoLeak, result.InfoLeak)
+		}
+	}
+
+	return rows, nil
+}
+
+// TidyTable converts rows to string records, starting with TidyHeader.
+func TidyTable(rows []TidyRow) [][]string {
+	table := make([][]string, 0, len(rows)+1)
+	table = append(table, append([]string(nil), TidyHeader...))
+	for _, r := range rows {
+		table = append(table, []string{
+			r.Source,
+			strconv.Itoa(r.Target),
+			strconv.Itoa(r.Lag),
+			r.Component,
+			strconv.FormatFloat(r.Value, 'g', -1, 64),
+		})
+	}
+	return table
+}
+
+// WriteTidyCSV writes rows as CSV with a TidyHeader header line.
+func WriteTidyCSV(w io.Writer, rows []TidyRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(TidyTable(rows)); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package surd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// generateChain3 returns [x, y, z] with y(t+1) = x(t) + noise and z independent.
+func generateChain3(n int, seed int64) [][]float64 {
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // deterministic test data
+	data := make([][]float64, n)
+	prevX := rng.Float64()
+	for i := range data {
+		x := rng.Float64()
+		data[i] = []float64{x, prevX + 0.2*rng.NormFloat64(), rng.Float64()}
+		prevX = x
+	}
+	return data
+}
+
+func TestTidyScreen(t *testing.T) {
+	data := generateChain3(2000, 11)
+	targets := []int{0, 1, 2}
+	lags := []int{1, 2}
+
+	rows, err := TidyScreen(data, targets, lags, []int{4, 4, 4})
+	if err != nil {
+		t.Fatalf("TidyScreen failed: %v", err)
+	}
+
+	// Per (target, lag): 3 directed MI + 3 unique + 4 redundant + 4 synergistic + 1 leak
+	perType := map[string]int{
+		ComponentDirectedMI:  3,
+		ComponentUnique:      3,
+		ComponentRedundant:   4,
+		ComponentSynergistic: 4,
+		ComponentInfoLeak:    1,
+	}
+	counts := make(map[string]int)
+	for _, r := range rows {
+		counts[r.Component]++
+	}
+	blocks := len(targets) * len(lags)
+	for component, n := range perType {
+		if counts[component] != blocks*n {
+			t.Errorf("%s rows = %d, want %d", component, counts[component], blocks*n)
+		}
+	}
+	if len(rows) != blocks*15 {
+		t.Errorf("rows = %d, want %d", len(rows), blocks*15)
+	}
+
+	// x drives y at lag 1: the strongest directed link into y
+	var best TidyRow
+	for _, r := range rows {
+		if r.Component == ComponentDirectedMI && r.Target == 1 && r.Lag == 1 && r.Value > best.Value {
+			best = r
+		}
+	}
+	if best.Source != "0" {
+		t.Errorf("strongest lag-1 link into y is from %q, want \"0\"", best.Source)
+	}
+
+	// CSV layout
+	var buf bytes.Buffer
+	if err := WriteTidyCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteTidyCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV back: %v", err)
+	}
+	if len(records) != len(rows)+1 {
+		t.Fatalf("CSV has %d records, want %d", len(records), len(rows)+1)
+	}
+	if !reflect.DeepEqual(records[0], []string{"source", "target", "lag", "component_type", "value"}) {
+		t.Errorf("header = %v", records[0])
+	}
+	if first := records[1]; first[0] != "0" || first[1] != "0" || first[2] != "1" || first[3] != ComponentDirectedMI {
+		t.Errorf("first row = %v, want source 0, target 0, lag 1, directed_mi", first)
+	}
+}
+
+func TestTidyScreen_ErrorCases(t *testing.T) {
+	data := generateChain3(100, 1)
+	bins := []int{4, 4, 4}
+
+	tests := []struct {
+		name    string
+		targets []int
+		lags    []int
+		bins    []int
+	}{
+		{"no targets", nil, []int{1}, bins},
+		{"no lags", []int{0}, nil, bins},
+		{"target out of range", []int{3}, []int{1}, bins},
+		{"zero lag", []int{0}, []int{0}, bins},
+		{"lag too large", []int{0}, []int{99}, bins},
+		{"bins mismatch", []int{0}, []int{1}, []int{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TidyScreen(data, tt.targets, tt.lags, tt.bins); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package surd
+
+import (
+	"math"
+	"sort"
+)
+
+// KeyValue is a single component of a Result: its key and value in bits.
+type KeyValue struct {
+	Key   string
+	Value float64
+}
+
+// TopUnique returns the k largest unique components, largest first.
+//
+// Ties are broken by key, so the order is deterministic. If k exceeds the
+// number of components all of them are returned; k <= 0 returns nil.
+//
+// Example:
+//
+//	// Variable with the strongest unique causality
+//	if top := result.TopUnique(1); len(top) > 0 {
+//	    fmt.Printf("X%s: %.3f bits\n", top[0].Key, top[0].Value)
+//	}
+func (r *Result) TopUnique(k int) []KeyValue {
+	return topComponents(r.Unique, k)
+}
+
+// TopRedundant returns the k largest redundant components, largest first.
+// Ties and k are handled as in TopUnique.
+func (r *Result) TopRedundant(k int) []KeyValue {
+	return topComponents(r.Redundant, k)
+}
+
+// TopSynergistic returns the k largest synergistic components, largest first.
+// Ties and k are handled as in TopUnique.
+//
+// Example:
+//
+//	// Top-3 synergistic combinations
+//	for _, kv := range result.TopSynergistic(3) {
+//	    fmt.Println(KeyToIndices(kv.Key), kv.Value)
+//	}
+func (r *Result) TopSynergistic(k int) []KeyValue {
+	return topComponents(r.Synergistic, k)
+}
+
+// topComponents returns the k largest entries of m by value, then by key.
+// NaN values sort last.
+func topComponents(m map[string]float64, k int) []KeyValue {
+	if k <= 0 {
+		return nil
+	}
+
+	entries := make([]KeyValue, 0, len(m))
+	for key, v := range m {
+		entries = append(entries, KeyValue{Key: key, Value: v})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if math.IsNaN(a.Value) != math.IsNaN(b.Value) {
+			return !math.IsNaN(a.Value)
+		}
+		if a.Value != b.Value && !math.IsNaN(a.Value) {
+			return a.Value > b.Value
+		}
+		return a.Key < b.Key
+	})
+
+	if k < len(entries) {
+		entries = entries[:k]
+	}
+	return entries
+}
@@ -0,0 +1,55 @@
+package surd
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestResult_Top(t *testing.T) {
+	r := &Result{
+		Unique:      map[string]float64{"0": 0.2, "1": 0.5, "2": 0.2, "3": 0.1},
+		Redundant:   map[string]float64{"0,1": 0.3, "0,1,2": math.NaN(), "1,2": 0.4},
+		Synergistic: map[string]float64{},
+	}
+
+	tests := []struct {
+		name string
+		got  []KeyValue
+		want []KeyValue
+	}{
+		{"unique top 1", r.TopUnique(1), []KeyValue{{"1", 0.5}}},
+		{"unique ties by key", r.TopUnique(3), []KeyValue{{"1", 0.5}, {"0", 0.2}, {"2", 0.2}}},
+		{"unique k > len", r.TopUnique(10), []KeyValue{{"1", 0.5}, {"0", 0.2}, {"2", 0.2}, {"3", 0.1}}},
+		{"redundant", r.TopRedundant(2), []KeyValue{{"1,2", 0.4}, {"0,1", 0.3}}},
+		{"synergistic empty", r.TopSynergistic(3), []KeyValue{}},
+		{"k zero", r.TopUnique(0), nil},
+		{"k negative", r.TopUnique(-1), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// NaN sorts last
+	all := r.TopRedundant(3)
+	if len(all) != 3 || all[2].Key != "0,1,2" {
+		t.Errorf("TopRedundant(3) = %v, want NaN entry last", all)
+	}
+}
+
+func TestResult_Top_Decomposition(t *testing.T) {
+	result, err := DecomposeFromData(generateInformativeAndNoise(5000, 3), []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("DecomposeFromData failed: %v", err)
+	}
+
+	top := result.TopUnique(1)
+	if len(top) != 1 || top[0].Key != "0" {
+		t.Errorf("TopUnique(1) = %v, want the informative agent \"0\"", top)
+	}
+}